@@ -0,0 +1,42 @@
+// Command fakeapi serves an in-memory Meta Networks API, to apply Terraform
+// configurations without a tenant. Point the provider at it with the
+// endpoint argument:
+//
+//	provider "metanetworks" {
+//	  endpoint   = "http://127.0.0.1:8080"
+//	  api_key    = "fake-api-key"
+//	  api_secret = "fake-api-secret"
+//	  org        = "fake-org"
+//	}
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"terraform-provider-metanetworks/internal/fakeapi"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:8080", "address to listen on")
+	apiKey := flag.String("api-key", fakeapi.DefaultAPIKey, "API key accepted by the OAuth endpoint")
+	apiSecret := flag.String("api-secret", fakeapi.DefaultAPISecret, "API secret accepted by the OAuth endpoint")
+	org := flag.String("org", fakeapi.DefaultOrg, "name of the org")
+	tokenLifetime := flag.Duration("token-lifetime", 0, "lifetime of the access tokens (default 1h)")
+	consistencyDelay := flag.Duration("consistency-delay", 0, "delay before writes show up in reads")
+	etags := flag.Bool("etags", false, "return ETag headers and honor If-Match")
+	flag.Parse()
+
+	api := fakeapi.NewAPI(&fakeapi.Options{
+		APIKey:           *apiKey,
+		APISecret:        *apiSecret,
+		Org:              *org,
+		TokenLifetime:    *tokenLifetime,
+		ConsistencyDelay: *consistencyDelay,
+		ETags:            *etags,
+	})
+
+	log.Printf("Serving the fake Meta Networks API of org %q on http://%s", *org, *addr)
+	log.Fatal(http.ListenAndServe(*addr, api))
+}
@@ -0,0 +1,81 @@
+// Command importgen writes the Terraform configuration of the objects of an
+// existing org, each as a resource preceded by an import block, to adopt them
+// with Terraform 1.5 or later. It reads the credentials like the provider, from
+// the METANETWORKS_* environment variables or the credentials file:
+//
+//	$ METANETWORKS_PROFILE=production go run ./cmd/importgen -dir imported
+//	$ cd imported && terraform plan
+//
+// The IDs of other objects are replaced by references to their resources, and
+// the users and built-in protocol groups are read by data sources.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"terraform-provider-metanetworks/internal/importgen"
+	"terraform-provider-metanetworks/metanetworks"
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory to write the .tf files to")
+	force := flag.Bool("force", false, "overwrite the existing files")
+	flag.Parse()
+
+	ctx := context.Background()
+	provider := metanetworks.Provider()
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(nil)); diags.HasError() {
+		for _, d := range diags {
+			if d.Detail != "" {
+				log.Printf("%s: %s", d.Summary, d.Detail)
+			} else {
+				log.Print(d.Summary)
+			}
+		}
+		os.Exit(1)
+	}
+
+	files, err := importgen.Generate(ctx, provider.Meta().(sdk.API))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(files) == 0 {
+		log.Print("The org has no objects to import")
+		return
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if !*force {
+		for _, name := range names {
+			path := filepath.Join(*dir, name)
+			if _, err := os.Stat(path); err == nil {
+				log.Fatalf("%s already exists, remove it or use -force", path)
+			}
+		}
+	}
+	if err := os.MkdirAll(*dir, 0755); err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range names {
+		path := filepath.Join(*dir, name)
+		if err := ioutil.WriteFile(path, files[name], 0644); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(path)
+	}
+}
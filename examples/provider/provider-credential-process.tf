@@ -0,0 +1,4 @@
+provider "metanetworks" {
+  credential_process = "vault kv get -format=json -field=data secret/metanetworks"
+  org                = "example_organization"
+}
@@ -0,0 +1,6 @@
+provider "metanetworks" {
+  endpoint        = "https://api.eu.example.com"
+  proxy_url       = "http://proxy.internal:3128"
+  ca_bundle_file  = "/etc/ssl/certs/internal-ca.pem"
+  request_timeout = 120
+}
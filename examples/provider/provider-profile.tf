@@ -0,0 +1,4 @@
+provider "metanetworks" {
+  profile          = "staging"
+  credentials_file = "/etc/metanetworks/credentials.json"
+}
@@ -0,0 +1,5 @@
+provider "metanetworks" {
+  rate_limit              = 5
+  rate_limit_burst        = 10
+  max_concurrent_requests = 4
+}
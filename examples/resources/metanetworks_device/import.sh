@@ -0,0 +1,3 @@
+# The ID of a device is the ID of its network element, which must have an
+# owner_id and a platform.
+terraform import metanetworks_device.example ne-1234abcd
@@ -0,0 +1,3 @@
+# A device alias is imported with the ID of the device and the alias, separated
+# by an underscore.
+terraform import metanetworks_device_alias.example ne-1234abcd_example.com
@@ -0,0 +1,3 @@
+# The ID of a mapped service is the ID of its network element, which must have
+# a mapped_service.
+terraform import metanetworks_mapped_service.example ne-1234abcd
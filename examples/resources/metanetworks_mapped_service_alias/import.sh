@@ -0,0 +1,3 @@
+# A mapped service alias is imported with the ID of the mapped service and the
+# alias, separated by an underscore.
+terraform import metanetworks_mapped_service_alias.example ne-1234abcd_example.com
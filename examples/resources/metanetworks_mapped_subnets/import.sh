@@ -0,0 +1,3 @@
+# The ID of mapped subnets is the ID of its network element, which must have
+# mapped_subnets.
+terraform import metanetworks_mapped_subnets.example ne-1234abcd
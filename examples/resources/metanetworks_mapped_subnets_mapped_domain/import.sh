@@ -0,0 +1,3 @@
+# A mapped domain is imported with the ID of the mapped subnets and the name of
+# the mapped domain, separated by an underscore.
+terraform import metanetworks_mapped_subnets_mapped_domain.example ne-1234abcd_ec2.internal
@@ -0,0 +1,3 @@
+# A mapped host is imported with the ID of the mapped subnets and the name of
+# the mapped host, separated by an underscore.
+terraform import metanetworks_mapped_subnets_mapped_host.example ne-1234abcd_host.internal
@@ -0,0 +1,3 @@
+# A Metaport attachment is imported with the ID of the Metaport and the ID of the
+# network element, separated by an underscore.
+terraform import metanetworks_metaport_attachment.example mp-1234abcd_ne-5678abcd
@@ -0,0 +1,3 @@
+# A Metaport Cluster attachment is imported with the ID of the Metaport Cluster
+# and the ID of the network element, separated by an underscore.
+terraform import metanetworks_metaport_cluster_attachment.example mpc-1234abcd_ne-5678abcd
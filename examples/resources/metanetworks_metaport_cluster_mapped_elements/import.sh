@@ -0,0 +1,3 @@
+# The network elements of a Metaport cluster are imported with the ID of the
+# Metaport cluster.
+terraform import metanetworks_metaport_cluster_mapped_elements.example mpc-1234abcd
@@ -0,0 +1,2 @@
+# The network elements of a Metaport are imported with the ID of the Metaport.
+terraform import metanetworks_metaport_mapped_elements.example mp-1234abcd
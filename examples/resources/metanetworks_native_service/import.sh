@@ -0,0 +1,3 @@
+# The ID of a native service is the ID of its network element, which must have
+# no mapped_service, mapped_subnets, owner_id or platform.
+terraform import metanetworks_native_service.example ne-1234abcd
@@ -0,0 +1,3 @@
+# A native service alias is imported with the ID of the native service and the
+# alias, separated by an underscore.
+terraform import metanetworks_native_service_alias.example ne-1234abcd_example.com
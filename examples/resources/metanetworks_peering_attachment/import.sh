@@ -0,0 +1,3 @@
+# A peering attachment is imported with the ID of the peering and the ID of the
+# network element, separated by an underscore.
+terraform import metanetworks_peering_attachment.example peer-1234abcd_ne-5678abcd
@@ -0,0 +1,2 @@
+# The peers of a peering are imported with the ID of the peering.
+terraform import metanetworks_peering_peers.example pr-1234abcd
@@ -0,0 +1,14 @@
+resource "metanetworks_peering" "example" {
+  name = "example"
+}
+
+resource "metanetworks_mapped_subnets" "example" {
+  count          = 2
+  name           = "example-${count.index}"
+  mapped_subnets = ["10.20.${count.index}.0/24"]
+}
+
+resource "metanetworks_peering_peers" "example" {
+  peering_id = metanetworks_peering.example.id
+  peers      = metanetworks_mapped_subnets.example[*].id
+}
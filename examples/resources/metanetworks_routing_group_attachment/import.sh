@@ -0,0 +1,3 @@
+# A routing group attachment is imported with the ID of the routing group and
+# the ID of the network element, separated by an underscore.
+terraform import metanetworks_routing_group_attachment.example rg-1234abcd_ne-5678abcd
@@ -0,0 +1,3 @@
+# The network elements of a routing group are imported with the ID of the routing
+# group.
+terraform import metanetworks_routing_group_mapped_elements.example rg-1234abcd
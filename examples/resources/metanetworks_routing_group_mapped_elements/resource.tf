@@ -0,0 +1,14 @@
+resource "metanetworks_routing_group" "example" {
+  name = "example"
+}
+
+resource "metanetworks_mapped_subnets" "example" {
+  count          = 2
+  name           = "example-${count.index}"
+  mapped_subnets = ["10.20.${count.index}.0/24"]
+}
+
+resource "metanetworks_routing_group_mapped_elements" "example" {
+  routing_group_id = metanetworks_routing_group.example.id
+  mapped_elements  = metanetworks_mapped_subnets.example[*].id
+}
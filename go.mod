@@ -3,7 +3,9 @@ module terraform-provider-metanetworks
 go 1.16
 
 require (
+	github.com/hashicorp/go-hclog v0.16.1
 	github.com/hashicorp/terraform-plugin-docs v0.5.1
+	github.com/hashicorp/terraform-plugin-log v0.2.0
 	github.com/hashicorp/terraform-plugin-sdk v1.17.2
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.10.1
 	github.com/pkg/errors v0.9.1
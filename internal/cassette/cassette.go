@@ -0,0 +1,357 @@
+// Package cassette records the interactions of a client with the Meta Networks
+// API into a file, a cassette, and replays them later without a tenant. It lets
+// the acceptance tests run against real API responses offline.
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Mode selects whether a Recorder records or replays the interactions.
+type Mode string
+
+const (
+	// ModeRecord sends the requests to the API and records the interactions.
+	ModeRecord Mode = "record"
+	// ModeReplay answers the requests with the recorded responses.
+	ModeReplay Mode = "replay"
+)
+
+// redacted replaces the secrets of the recorded requests.
+const redacted string = "REDACTED"
+
+// ScrubbedFields are the json keys whose values are not recorded. The values
+// sent by the client are replaced with REDACTED and the ones returned by the
+// API with a digest, so that distinct secrets stay distinct in replays. The
+// scope holds the org, which would tie the cassette to the credentials.
+var ScrubbedFields = []string{
+	"access_token",
+	"api_key",
+	"api_secret",
+	"client_id",
+	"client_secret",
+	"password",
+	"refresh_token",
+	"scope",
+	"secret",
+}
+
+// recordedHeaders are the response headers kept in the cassette, the client
+// doesn't need the others.
+var recordedHeaders = []string{"Content-Type", "Retry-After", "X-Request-Id"}
+
+// ParseMode returns the Mode named s.
+func ParseMode(s string) (Mode, error) {
+	switch mode := Mode(s); mode {
+	case ModeRecord, ModeReplay:
+		return mode, nil
+	}
+	return "", fmt.Errorf("Invalid cassette mode %q, must be %q or %q", s, ModeRecord, ModeReplay)
+}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response of the API.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. The path includes the query string with its
+// parameters sorted, and the body is normalized json with secrets scrubbed.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a recorded response, with secrets scrubbed from the body.
+type Response struct {
+	StatusCode int               `json:"status_code"`
+	Header     map[string]string `json:"header,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+// key identifies the requests a recorded response answers.
+func (r *Request) key() string {
+	return r.Method + " " + r.Path + "\n" + r.Body
+}
+
+// Recorder is an http.RoundTripper which records the interactions with the
+// API, or replays them.
+//
+// Requests are matched by method, path and body. When the same request was
+// recorded several times its responses are replayed in order, and the last one
+// is repeated once they run out, as the client may poll the API a different
+// number of times than when recording. The order of the requests of concurrent
+// callers is not deterministic, so interactions are only replayed reliably
+// when recorded and replayed from a single caller at a time.
+type Recorder struct {
+	mode Mode
+	path string
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	replays  map[string][]*Interaction
+}
+
+// New returns a Recorder in mode for the cassette at path. Recorded requests
+// are sent with next, or http.DefaultTransport if nil. The cassette must exist
+// to be replayed.
+func New(path string, mode Mode, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	r := &Recorder{
+		mode: mode,
+		path: path,
+		next: next,
+	}
+
+	switch mode {
+	case ModeRecord:
+	case ModeReplay:
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read cassette: %s", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("Could not parse cassette %s: %s", path, err)
+		}
+
+		r.replays = make(map[string][]*Interaction)
+		for _, interaction := range r.cassette.Interactions {
+			key := interaction.Request.key()
+			r.replays[key] = append(r.replays[key], interaction)
+		}
+	default:
+		return nil, fmt.Errorf("Invalid cassette mode %q", mode)
+	}
+
+	return r, nil
+}
+
+// Mode returns the mode of the Recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Interactions returns the interactions recorded or to replay.
+func (r *Recorder) Interactions() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]*Interaction(nil), r.cassette.Interactions...)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	request := Request{
+		Method: req.Method,
+		Path:   normalizePath(req.URL),
+		Body:   normalizeBody(body, scrubRequestValue),
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, &request)
+	}
+	return r.record(req, body, &request)
+}
+
+func (r *Recorder) replay(req *http.Request, request *Request) (*http.Response, error) {
+	r.mu.Lock()
+	key := request.key()
+	interactions := r.replays[key]
+	if len(interactions) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("Cassette %s has no response to %s %s", r.path, request.Method, request.Path)
+	}
+	interaction := interactions[0]
+	if len(interactions) > 1 {
+		r.replays[key] = interactions[1:]
+	}
+	r.mu.Unlock()
+
+	response := interaction.Response
+	header := make(http.Header)
+	for name, value := range response.Header {
+		header.Set(name, value)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", response.StatusCode, http.StatusText(response.StatusCode)),
+		StatusCode:    response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(response.Body))),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, request *Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if req.Body != nil {
+		out.Body = ioutil.NopCloser(bytes.NewReader(body))
+		out.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	resp, err := r.next.RoundTrip(out)
+	if err != nil {
+		// Transport errors are not recorded, the client sees them when
+		// recording only.
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	response := Response{
+		StatusCode: resp.StatusCode,
+		Body:       normalizeBody(respBody, scrubResponseValue),
+	}
+	for _, name := range recordedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			if response.Header == nil {
+				response.Header = make(map[string]string)
+			}
+			response.Header[name] = value
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request:  *request,
+		Response: response,
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette file, creating its
+// directory if needed. It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(&r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("Could not create the directory of cassette %s: %s", r.path, err)
+	}
+	if err := ioutil.WriteFile(r.path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("Could not write cassette: %s", err)
+	}
+	return nil
+}
+
+// normalizePath returns the path of u with its query parameters sorted.
+func normalizePath(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.Path
+	}
+	return u.Path + "?" + u.Query().Encode()
+}
+
+// normalizeBody returns the json body with its keys sorted, no whitespace and
+// the values of ScrubbedFields replaced by scrub. Other bodies are returned as
+// is.
+func normalizeBody(body []byte, scrub func(string) string) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+
+	data, err := json.Marshal(scrubJSON(value, scrub))
+	if err != nil {
+		return string(body)
+	}
+	return string(data)
+}
+
+// scrubJSON replaces the string values of ScrubbedFields in value, at any
+// depth.
+func scrubJSON(value interface{}, scrub func(string) string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if s, ok := item.(string); ok && isScrubbedField(key) && s != "" {
+				v[key] = scrub(s)
+				continue
+			}
+			v[key] = scrubJSON(item, scrub)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = scrubJSON(item, scrub)
+		}
+	}
+	return value
+}
+
+func isScrubbedField(key string) bool {
+	for _, field := range ScrubbedFields {
+		if key == field {
+			return true
+		}
+	}
+	return false
+}
+
+// scrubRequestValue replaces the secrets sent by the client with a constant,
+// so that requests match whatever the credentials of the replay.
+func scrubRequestValue(string) string {
+	return redacted
+}
+
+// scrubResponseValue replaces the secrets returned by the API with a digest,
+// which can't be reversed but keeps distinct secrets distinct.
+func scrubResponseValue(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8]) + "-" + redacted
+}
@@ -0,0 +1,182 @@
+package cassette_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"terraform-provider-metanetworks/internal/cassette"
+	"terraform-provider-metanetworks/internal/fakeapi"
+	"terraform-provider-metanetworks/sdk"
+)
+
+func newTestClient(t *testing.T, baseURL, key, secret, org string, recorder *cassette.Recorder) *sdk.Client {
+	t.Helper()
+
+	client, err := sdk.NewClient(key, secret, org, &sdk.ClientOptions{
+		BaseURL:   baseURL,
+		Transport: recorder,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	client.MaxRetries = 0
+
+	return client
+}
+
+func TestRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+
+	server := fakeapi.NewServer(nil)
+	recorder, err := cassette.New(path, cassette.ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	client := newTestClient(t, server.URL, fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, recorder)
+
+	created, err := client.CreatePolicy(ctx, &sdk.CreatePolicyRequest{Name: "policy", Enabled: true})
+	if err != nil {
+		t.Fatalf("CreatePolicy: %s", err)
+	}
+	if err := client.DeletePolicy(ctx, created.ID); err != nil {
+		t.Fatalf("DeletePolicy: %s", err)
+	}
+	if _, err := client.GetPolicy(ctx, created.ID); !sdk.IsNotFound(err) {
+		t.Fatalf("GetPolicy after delete: want not found, got %v", err)
+	}
+	server.Close()
+
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save: %s", err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %s", err)
+	}
+	for _, secret := range []string{fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	// Replays need neither the API nor the same credentials
+	replayer, err := cassette.New(path, cassette.ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	if got, want := len(replayer.Interactions()), len(recorder.Interactions()); got != want {
+		t.Errorf("replayer has %d interactions, want %d", got, want)
+	}
+	client = newTestClient(t, "https://api.invalid", "other-key", "other-secret", "other-org", replayer)
+
+	replayed, err := client.CreatePolicy(ctx, &sdk.CreatePolicyRequest{Name: "policy", Enabled: true})
+	if err != nil {
+		t.Fatalf("replayed CreatePolicy: %s", err)
+	}
+	if replayed.ID != created.ID {
+		t.Errorf("replayed CreatePolicy: got ID %q, want %q", replayed.ID, created.ID)
+	}
+	if err := client.DeletePolicy(ctx, created.ID); err != nil {
+		t.Fatalf("replayed DeletePolicy: %s", err)
+	}
+	// The last response is repeated
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetPolicy(ctx, created.ID); !sdk.IsNotFound(err) {
+			t.Fatalf("replayed GetPolicy after delete: want not found, got %v", err)
+		}
+	}
+
+	if _, err := client.CreatePolicy(ctx, &sdk.CreatePolicyRequest{Name: "other", Enabled: true}); err == nil {
+		t.Error("CreatePolicy not in the cassette succeeded")
+	}
+}
+
+func TestReplayMatching(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "token-%[1]d", "n": %[1]d}`, calls)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "test.json")
+	recorder, err := cassette.New(path, cassette.ModeRecord, nil)
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	client := &http.Client{Transport: recorder}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Post(server.URL+"/v1/things?b=2&a=1", "application/json", strings.NewReader(`{"name": "thing", "secret": "s3cret"}`))
+		if err != nil {
+			t.Fatalf("Post: %s", err)
+		}
+		resp.Body.Close()
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save: %s", err)
+	}
+
+	interactions := recorder.Interactions()
+	if len(interactions) != 2 {
+		t.Fatalf("recorded %d interactions, want 2", len(interactions))
+	}
+	request := interactions[0].Request
+	if request.Path != "/v1/things?a=1&b=2" {
+		t.Errorf("recorded path %q", request.Path)
+	}
+	if request.Body != `{"name":"thing","secret":"REDACTED"}` {
+		t.Errorf("recorded request body %s", request.Body)
+	}
+	first, second := interactions[0].Response.Body, interactions[1].Response.Body
+	if strings.Contains(first, "token-1") || first == second {
+		t.Errorf("tokens not scrubbed into distinct values: %s, %s", first, second)
+	}
+
+	replayer, err := cassette.New(path, cassette.ModeReplay, nil)
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	client = &http.Client{Transport: replayer}
+
+	// Query parameters and json keys in another order, and another secret,
+	// match the recorded requests
+	for _, want := range []string{`"n":1`, `"n":2`, `"n":2`} {
+		resp, err := client.Post("https://api.invalid/v1/things?a=1&b=2", "application/json", strings.NewReader(`{"secret":"other","name":"thing"}`))
+		if err != nil {
+			t.Fatalf("Post: %s", err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), want) {
+			t.Errorf("replayed %d %s, want %s", resp.StatusCode, body, want)
+		}
+		if resp.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("replayed Content-Type %q", resp.Header.Get("Content-Type"))
+		}
+	}
+
+	if _, err := client.Post("https://api.invalid/v1/things", "application/json", strings.NewReader(`{"name":"other"}`)); err == nil {
+		t.Error("Post not in the cassette succeeded")
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, s := range []string{"record", "replay"} {
+		if mode, err := cassette.ParseMode(s); err != nil || string(mode) != s {
+			t.Errorf("ParseMode(%q) = %q, %v", s, mode, err)
+		}
+	}
+	if _, err := cassette.ParseMode("rewind"); err == nil {
+		t.Error("ParseMode(\"rewind\") succeeded")
+	}
+}
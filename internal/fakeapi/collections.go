@@ -0,0 +1,395 @@
+package fakeapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// collectionSpec describes the objects of an endpoint.
+type collectionSpec struct {
+	// prefix is the prefix of the generated ids
+	prefix string
+	// key is the field identifying the objects, id unless set
+	key string
+	// required fields must be set and not empty
+	required []string
+	// computed fields are set by the API only, like the id
+	computed []string
+	// defaults are the values of the fields missing on creation
+	defaults map[string]interface{}
+	// created sets the computed fields of a new object
+	created func(a *API, data map[string]interface{})
+	// items wraps the lists in an object, {"items": [...]}
+	items bool
+	// readOnly collections only hold the seed objects
+	readOnly bool
+	seed     []map[string]interface{}
+	// tags adds the tags of the objects, served by their tags subresource,
+	// to the objects read
+	tags bool
+
+	subresources map[string]subresourceHandler
+}
+
+// subresourceHandler serves /v1/<collection>/<id>/<subresource>[/<name>].
+type subresourceHandler func(a *API, w http.ResponseWriter, r *http.Request, c *collection, id, name string, payload interface{})
+
+var collectionSpecs = map[string]collectionSpec{
+	"network_elements": {
+		prefix:   "ne-",
+		required: []string{"name"},
+		computed: []string{"aliases", "dns_name", "expires_at", "net_id"},
+		defaults: map[string]interface{}{
+			"enabled": true,
+		},
+		created: networkElementCreated,
+		tags:    true,
+		subresources: map[string]subresourceHandler{
+			"aliases":        serveAlias,
+			"mapped_domains": mappedEntriesHandler("mapped_domains", "mapped_domain"),
+			"mapped_hosts":   mappedEntriesHandler("mapped_hosts", "mapped_host"),
+			"tags":           serveTags,
+		},
+	},
+	"groups": {
+		prefix:   "grp-",
+		required: []string{"name"},
+		computed: []string{"members", "provisioned_by", "roles", "users"},
+		items:    true,
+		subresources: map[string]subresourceHandler{
+			"add_users":    groupUsersHandler(true),
+			"remove_users": groupUsersHandler(false),
+			"roles":        serveGroupRoles,
+		},
+	},
+	"users": {
+		prefix:   "usr-",
+		required: []string{"email"},
+		computed: []string{"inventory", "mfa_enabled", "roles", "tags"},
+		created:  userCreated,
+		items:    true,
+		subresources: map[string]subresourceHandler{
+			"tags": serveTags,
+		},
+	},
+	"policies": {
+		prefix:   "pol-",
+		required: []string{"name"},
+	},
+	"metaports": {
+		prefix:   "mp-",
+		required: []string{"name"},
+		computed: []string{"connection", "dns_name", "expires_at"},
+		created: func(a *API, data map[string]interface{}) {
+			data["dns_name"] = fmt.Sprintf("%s.%s.nsof", data["id"], a.options.Org)
+		},
+		subresources: map[string]subresourceHandler{
+			"otac": serveOTAC,
+		},
+	},
+	"metaport_clusters": {
+		prefix:   "mpc-",
+		required: []string{"name"},
+	},
+	"routing_groups": {
+		prefix:   "rg-",
+		required: []string{"name"},
+		created: func(a *API, data map[string]interface{}) {
+			data["priority"] = len(a.collections["routing_groups"].objects) + 1
+		},
+	},
+	"peerings": {
+		prefix:   "peer-",
+		required: []string{"name"},
+	},
+	"egress_routes": {
+		prefix:   "er-",
+		required: []string{"name", "via"},
+	},
+	"protocol_groups": {
+		prefix:   "pg-",
+		required: []string{"name"},
+		computed: []string{"read_only"},
+		seed: []map[string]interface{}{
+			builtinProtocolGroup("HTTP", "tcp", 80),
+			builtinProtocolGroup("HTTPS", "tcp", 443),
+			builtinProtocolGroup("SSH", "tcp", 22),
+			builtinProtocolGroup("RDP", "tcp", 3389),
+			builtinProtocolGroup("DNS", "udp", 53),
+		},
+	},
+	"posture_checks": {
+		prefix:   "pc-",
+		required: []string{"name", "action", "platform"},
+	},
+	"content_categories": {
+		prefix:   "cc-",
+		required: []string{"name"},
+	},
+	"threat_categories": {
+		prefix:   "tc-",
+		required: []string{"name"},
+	},
+	"url_filtering_rules": {
+		prefix:   "ufr-",
+		required: []string{"name", "action"},
+	},
+	"locations": {
+		key:      "name",
+		readOnly: true,
+		seed: []map[string]interface{}{
+			{"name": "AMS", "city": "Amsterdam", "country": "NL", "latitude": 52.37, "longitude": 4.89, "status": "active"},
+			{"name": "FRA", "city": "Frankfurt", "country": "DE", "latitude": 50.11, "longitude": 8.68, "status": "active"},
+			{"name": "NYC", "city": "New York", "country": "US", "state": "NY", "latitude": 40.71, "longitude": -74.01, "status": "active"},
+			{"name": "SFO", "city": "San Francisco", "country": "US", "state": "CA", "latitude": 37.77, "longitude": -122.42, "status": "active"},
+			{"name": "TLV", "city": "Tel Aviv", "country": "IL", "latitude": 32.09, "longitude": 34.78, "status": "active"},
+		},
+	},
+}
+
+func builtinProtocolGroup(name, proto string, port int) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        name,
+		"description": name + " traffic",
+		"read_only":   true,
+		"protocols": []interface{}{
+			map[string]interface{}{"proto": proto, "port": port, "from_port": port, "to_port": port},
+		},
+	}
+}
+
+// networkElementCreated sets the computed fields of a new network element.
+func networkElementCreated(a *API, data map[string]interface{}) {
+	data["aliases"] = []interface{}{}
+	data["dns_name"] = fmt.Sprintf("%s.%s.nsof", data["id"], a.options.Org)
+	data["net_id"] = a.nextID
+}
+
+func userCreated(a *API, data map[string]interface{}) {
+	if data["name"] == nil || data["name"] == "" {
+		data["name"] = strings.TrimSpace(fmt.Sprintf("%v %v", data["given_name"], data["family_name"]))
+	}
+}
+
+// serveAlias adds and removes the aliases of a network element.
+func serveAlias(a *API, w http.ResponseWriter, r *http.Request, c *collection, id, alias string, payload interface{}) {
+	current := c.current(id)
+	if current == nil {
+		writeNotFound(w, c, id)
+		return
+	}
+	if alias == "" {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed on "+r.URL.Path)
+		return
+	}
+
+	aliases := stringList(current["aliases"])
+	index := indexOf(aliases, alias)
+	switch r.Method {
+	case http.MethodPut:
+		if index < 0 {
+			aliases = append(aliases, alias)
+		}
+	case http.MethodDelete:
+		if index < 0 {
+			writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("No alias %s on %s", alias, id))
+			return
+		}
+		aliases = append(aliases[:index], aliases[index+1:]...)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed on "+r.URL.Path)
+		return
+	}
+
+	data := clone(current)
+	data["aliases"] = aliases
+	writeJSON(w, a.update(c, id, data))
+}
+
+// mappedEntriesHandler serves the named entries of a network element list,
+// like its mapped domains, whose value is in valueField.
+func mappedEntriesHandler(field, valueField string) subresourceHandler {
+	return func(a *API, w http.ResponseWriter, r *http.Request, c *collection, id, name string, payload interface{}) {
+		o, ok := c.objects[id]
+		if !ok || o.latest() == nil {
+			writeNotFound(w, c, id)
+			return
+		}
+		if name == "" {
+			writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed on "+r.URL.Path)
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			data := o.visible(a.options.ConsistencyDelay)
+			if data == nil {
+				writeNotFound(w, c, id)
+				return
+			}
+			entries, _ := data[field].([]interface{})
+			if index := entryIndex(entries, name); index >= 0 {
+				writeJSON(w, entries[index])
+				return
+			}
+			writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("No %s %s on %s", valueField, name, id))
+			return
+		}
+
+		data := clone(o.latest())
+		entries, _ := data[field].([]interface{})
+		index := entryIndex(entries, name)
+		switch r.Method {
+		case http.MethodPut:
+			entry, ok := payload.(map[string]interface{})
+			if !ok {
+				writeError(w, http.StatusBadRequest, "bad_request", "The request body must be a json object")
+				return
+			}
+			if value, _ := entry[valueField].(string); value == "" {
+				writeError(w, http.StatusBadRequest, "validation_error", "Invalid request", fieldError{Field: valueField, Message: "This field is required"})
+				return
+			}
+			entry["name"] = name
+			if index < 0 {
+				entries = append(entries, entry)
+			} else {
+				entries[index] = entry
+			}
+			data[field] = entries
+			a.update(c, id, data)
+			writeJSON(w, entry)
+		case http.MethodDelete:
+			if index < 0 {
+				writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("No %s %s on %s", valueField, name, id))
+				return
+			}
+			data[field] = append(entries[:index], entries[index+1:]...)
+			a.update(c, id, data)
+			w.WriteHeader(http.StatusOK)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed on "+r.URL.Path)
+		}
+	}
+}
+
+// serveTags gets and replaces the tags of an object. Tags are not subject to
+// the consistency delay.
+func serveTags(a *API, w http.ResponseWriter, r *http.Request, c *collection, id, name string, payload interface{}) {
+	o, ok := c.objects[id]
+	if !ok || o.latest() == nil {
+		writeNotFound(w, c, id)
+		return
+	}
+	if name != "" {
+		writeError(w, http.StatusNotFound, "not_found", "Unknown endpoint "+r.URL.Path)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		items, ok := payload.([]interface{})
+		if !ok {
+			writeError(w, http.StatusBadRequest, "bad_request", "The request body must be a json array")
+			return
+		}
+		tags := make(map[string]string, len(items))
+		for _, item := range items {
+			tag, _ := item.(map[string]interface{})
+			tagName, _ := tag["name"].(string)
+			if tagName == "" {
+				writeError(w, http.StatusBadRequest, "validation_error", "Invalid request", fieldError{Field: "name", Message: "This field is required"})
+				return
+			}
+			tags[tagName] = fmt.Sprint(tag["value"])
+		}
+		o.tags = tags
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed on "+r.URL.Path)
+		return
+	}
+
+	writeJSON(w, sortedTags(o.tags))
+}
+
+// groupUsersHandler adds or removes users from a group.
+func groupUsersHandler(add bool) subresourceHandler {
+	return func(a *API, w http.ResponseWriter, r *http.Request, c *collection, id, name string, payload interface{}) {
+		if r.Method != http.MethodPost || name != "" {
+			writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed on "+r.URL.Path)
+			return
+		}
+		current := c.current(id)
+		if current == nil {
+			writeNotFound(w, c, id)
+			return
+		}
+
+		users := stringList(current["users"])
+		for _, user := range stringList(payload) {
+			index := indexOf(users, user)
+			if add && index < 0 {
+				users = append(users, user)
+			} else if !add && index >= 0 {
+				users = append(users[:index], users[index+1:]...)
+			}
+		}
+
+		data := clone(current)
+		data["users"] = users
+		writeJSON(w, a.update(c, id, data))
+	}
+}
+
+func serveGroupRoles(a *API, w http.ResponseWriter, r *http.Request, c *collection, id, name string, payload interface{}) {
+	if r.Method != http.MethodPut || name != "" {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed on "+r.URL.Path)
+		return
+	}
+	current := c.current(id)
+	if current == nil {
+		writeNotFound(w, c, id)
+		return
+	}
+
+	data := clone(current)
+	data["roles"] = stringList(payload)
+	writeJSON(w, a.update(c, id, data))
+}
+
+// serveOTAC generates a one time code to install a metaport.
+func serveOTAC(a *API, w http.ResponseWriter, r *http.Request, c *collection, id, name string, payload interface{}) {
+	if r.Method != http.MethodPost || name != "" {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed on "+r.URL.Path)
+		return
+	}
+	if c.current(id) == nil {
+		writeNotFound(w, c, id)
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"expires_in": 3600,
+		"secret":     randomString(8),
+	})
+}
+
+func indexOf(list []string, value string) int {
+	for i, item := range list {
+		if item == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// entryIndex returns the index of the entry with name in a list of objects.
+func entryIndex(entries []interface{}, name string) int {
+	for i, entry := range entries {
+		if fields, ok := entry.(map[string]interface{}); ok && fields["name"] == name {
+			return i
+		}
+	}
+	return -1
+}
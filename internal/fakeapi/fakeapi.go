@@ -0,0 +1,320 @@
+// Package fakeapi is an in-memory stand-in for the /v1 endpoints of the Meta
+// Networks API used by the provider. It lets the provider and Terraform
+// configurations run without a tenant.
+package fakeapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultAPIKey    string = "fake-api-key"
+	DefaultAPISecret string = "fake-api-secret"
+	DefaultOrg       string = "fake-org"
+
+	defaultTokenLifetime  time.Duration = time.Hour
+	refreshTokenLifetime  time.Duration = 24 * time.Hour
+	mergePatchContentType string        = "application/merge-patch+json"
+)
+
+// Options holds the settings of an API. The zero value accepts the default
+// credentials and makes every write visible at once.
+type Options struct {
+	APIKey    string
+	APISecret string
+	Org       string
+
+	// TokenLifetime is the lifetime of the access tokens issued by
+	// /v1/oauth/token.
+	TokenLifetime time.Duration
+
+	// ConsistencyDelay is how long a write takes to show up in reads. Until
+	// then reads return the previous version of the object, or 404 for a new
+	// one, like the API does while a change propagates.
+	ConsistencyDelay time.Duration
+
+	// ETags makes the API return the version of the objects in an ETag
+	// header, and reject with 412 the PATCH requests whose If-Match header
+	// doesn't match the latest version. Without it changes can only be
+	// detected with modified_at, like with the API.
+	ETags bool
+}
+
+// API is the http.Handler of the fake API. It is safe for concurrent use.
+type API struct {
+	mu sync.Mutex
+
+	options       Options
+	collections   map[string]*collection
+	accessTokens  map[string]time.Time
+	refreshTokens map[string]time.Time
+	faults        []fault
+	nextID        int
+	requests      int
+}
+
+// fault is an error returned once, in place of the response of the next
+// request matching its method and path.
+type fault struct {
+	method  string
+	path    string
+	status  int
+	message string
+}
+
+// Server is an API served by an httptest.Server.
+type Server struct {
+	*httptest.Server
+	API *API
+}
+
+// NewAPI returns an API holding only the builtin objects of a new org, like
+// the default protocol groups and the locations.
+func NewAPI(options *Options) *API {
+	a := &API{
+		collections:   make(map[string]*collection),
+		accessTokens:  make(map[string]time.Time),
+		refreshTokens: make(map[string]time.Time),
+	}
+	if options != nil {
+		a.options = *options
+	}
+	if a.options.APIKey == "" {
+		a.options.APIKey = DefaultAPIKey
+	}
+	if a.options.APISecret == "" {
+		a.options.APISecret = DefaultAPISecret
+	}
+	if a.options.Org == "" {
+		a.options.Org = DefaultOrg
+	}
+	if a.options.TokenLifetime == 0 {
+		a.options.TokenLifetime = defaultTokenLifetime
+	}
+
+	names := make([]string, 0, len(collectionSpecs))
+	for name := range collectionSpecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		c := newCollection(name, collectionSpecs[name])
+		for _, seed := range c.spec.seed {
+			data := clone(seed)
+			if c.spec.key == "" {
+				a.complete(c, data)
+			}
+			a.store(c, data, true)
+		}
+		a.collections[name] = c
+	}
+
+	return a
+}
+
+// NewServer starts and returns a Server. The caller should call Close when
+// finished, to shut it down.
+func NewServer(options *Options) *Server {
+	api := NewAPI(options)
+	return &Server{
+		Server: httptest.NewServer(api),
+		API:    api,
+	}
+}
+
+// SetConsistencyDelay changes the delay before writes show up in reads.
+func (a *API) SetConsistencyDelay(delay time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.options.ConsistencyDelay = delay
+}
+
+// SetETags turns the ETag and If-Match headers on or off.
+func (a *API) SetETags(enabled bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.options.ETags = enabled
+}
+
+// RevokeTokens invalidates every access and refresh token issued so far.
+func (a *API) RevokeTokens() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.accessTokens = make(map[string]time.Time)
+	a.refreshTokens = make(map[string]time.Time)
+}
+
+// FailNext makes the next request with method to path, without the query,
+// fail with status and message.
+func (a *API) FailNext(method, path string, status int, message string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.faults = append(a.faults, fault{method: method, path: path, status: status, message: message})
+}
+
+// Requests returns the number of requests served so far.
+func (a *API) Requests() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.requests
+}
+
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.requests++
+	requestID := r.Header.Get("X-Request-Id")
+	if requestID == "" {
+		requestID = fmt.Sprintf("fake-%d", a.requests)
+	}
+	w.Header().Set("X-Request-Id", requestID)
+
+	if !strings.HasPrefix(r.URL.Path, "/v1/") {
+		writeError(w, http.StatusNotFound, "not_found", "Unknown endpoint "+r.URL.Path)
+		return
+	}
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/"), "/")
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+
+	if path == "oauth/token" {
+		a.serveToken(w, r, body)
+		return
+	}
+
+	if !a.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "The access token is invalid or expired")
+		return
+	}
+
+	if f, ok := a.takeFault(r.Method, r.URL.Path); ok {
+		writeError(w, f.status, http.StatusText(f.status), f.message)
+		return
+	}
+
+	var payload interface{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			writeError(w, http.StatusBadRequest, "bad_request", "The request body is not valid json: "+err.Error())
+			return
+		}
+	}
+
+	a.route(w, r, strings.Split(path, "/"), payload)
+}
+
+func (a *API) route(w http.ResponseWriter, r *http.Request, parts []string, payload interface{}) {
+	c, ok := a.collections[parts[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "Unknown endpoint "+r.URL.Path)
+		return
+	}
+
+	switch len(parts) {
+	case 1:
+		switch r.Method {
+		case http.MethodGet:
+			a.list(w, r, c)
+			return
+		case http.MethodPost:
+			if !c.spec.readOnly {
+				a.create(w, c, payload)
+				return
+			}
+		}
+	case 2:
+		switch r.Method {
+		case http.MethodGet:
+			a.get(w, c, parts[1])
+			return
+		case http.MethodPatch:
+			if !c.spec.readOnly {
+				a.patch(w, r, c, parts[1], payload)
+				return
+			}
+		case http.MethodDelete:
+			if !c.spec.readOnly {
+				a.delete(w, c, parts[1])
+				return
+			}
+		}
+	default:
+		if handler, ok := c.spec.subresources[parts[2]]; ok && len(parts) <= 4 {
+			var name string
+			if len(parts) == 4 {
+				name = parts[3]
+			}
+			handler(a, w, r, c, parts[1], name, payload)
+			return
+		}
+		writeError(w, http.StatusNotFound, "not_found", "Unknown endpoint "+r.URL.Path)
+		return
+	}
+
+	writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed on "+r.URL.Path)
+}
+
+func (a *API) takeFault(method, path string) (fault, bool) {
+	for i, f := range a.faults {
+		if f.method == method && f.path == path {
+			a.faults = append(a.faults[:i], a.faults[i+1:]...)
+			return f, true
+		}
+	}
+	return fault{}, false
+}
+
+func (a *API) newID(prefix string) string {
+	a.nextID++
+	return fmt.Sprintf("%s%08x", prefix, a.nextID)
+}
+
+func randomString(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+// fieldError is a validation error of a single field, as returned by the API.
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string, details ...fieldError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(struct {
+		Code    string       `json:"code"`
+		Message string       `json:"message"`
+		Errors  []fieldError `json:"errors,omitempty"`
+	}{code, message, details})
+}
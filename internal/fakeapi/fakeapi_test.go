@@ -0,0 +1,370 @@
+package fakeapi_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-metanetworks/internal/fakeapi"
+	"terraform-provider-metanetworks/sdk"
+)
+
+func newTestClient(t *testing.T, options *fakeapi.Options) (*fakeapi.Server, *sdk.Client) {
+	t.Helper()
+
+	server := fakeapi.NewServer(options)
+	t.Cleanup(server.Close)
+
+	client, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, &sdk.ClientOptions{
+		BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	client.MaxRetries = 0
+
+	return server, client
+}
+
+func TestAuthentication(t *testing.T) {
+	server := fakeapi.NewServer(nil)
+	defer server.Close()
+
+	options := &sdk.ClientOptions{BaseURL: server.URL}
+	if _, err := sdk.NewClient(fakeapi.DefaultAPIKey, "wrong", fakeapi.DefaultOrg, options); err == nil {
+		t.Error("NewClient with a wrong secret succeeded")
+	}
+	if _, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, "other-org", options); err == nil {
+		t.Error("NewClient with a wrong org succeeded")
+	}
+
+	client, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, options)
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	// The client authenticates again once its token is revoked
+	server.API.RevokeTokens()
+	if _, err := client.GetLocations(context.Background()); err != nil {
+		t.Errorf("GetLocations after revoking the tokens: %s", err)
+	}
+}
+
+func TestCRUD(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestClient(t, nil)
+
+	created, err := client.CreatePolicy(ctx, &sdk.CreatePolicyRequest{
+		Name:         "policy",
+		Description:  "description",
+		Destinations: []string{"ne-1"},
+		Sources:      []string{"grp-1"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePolicy: %s", err)
+	}
+	if created.ID == "" || created.CreatedAt == "" || created.OrgID != fakeapi.DefaultOrg {
+		t.Errorf("CreatePolicy returned no computed fields: %+v", created)
+	}
+
+	// Fields left out of the patch are kept, null ones are cleared
+	_, err = client.UpdatePolicy(ctx, created.ID, &sdk.UpdatePolicyRequest{
+		Description: sdk.String("changed"),
+		Sources:     sdk.StringsOrNull(nil),
+	})
+	if err != nil {
+		t.Fatalf("UpdatePolicy: %s", err)
+	}
+
+	policy, err := client.GetPolicy(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetPolicy: %s", err)
+	}
+	if policy.Description != "changed" || len(policy.Sources) != 0 || len(policy.Destinations) != 1 {
+		t.Errorf("GetPolicy returned %+v", policy)
+	}
+
+	if err := client.DeletePolicy(ctx, created.ID); err != nil {
+		t.Fatalf("DeletePolicy: %s", err)
+	}
+	if _, err := client.GetPolicy(ctx, created.ID); !sdk.IsNotFound(err) {
+		t.Errorf("GetPolicy of a deleted policy returned %v", err)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	_, client := newTestClient(t, nil)
+
+	_, err := client.CreateEgressRoute(context.Background(), &sdk.CreateEgressRouteRequest{Name: "route"})
+	if !sdk.IsValidation(err) {
+		t.Fatalf("CreateEgressRoute without via returned %v", err)
+	}
+	if !strings.Contains(err.Error(), "via: This field is required") {
+		t.Errorf("the error does not name the invalid field: %s", err)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t, nil)
+
+	id := server.API.PutObject("peerings", map[string]interface{}{
+		"name":        "peering",
+		"description": "description",
+		"peers":       []interface{}{"ne-1", "ne-2"},
+	})
+
+	body := `{"description": null, "peers": ["ne-3"], "egress_nat": true}`
+	_, err := client.Request(ctx, "/v1/peerings/"+id, "PATCH", []byte(body), "application/merge-patch+json")
+	if err != nil {
+		t.Fatalf("PATCH: %s", err)
+	}
+
+	peering, ok := server.API.Object("peerings", id)
+	if !ok {
+		t.Fatal("the peering is gone")
+	}
+	if _, ok := peering["description"]; ok {
+		t.Error("null did not remove the description")
+	}
+	if peers := peering["peers"].([]interface{}); len(peers) != 1 || peers[0] != "ne-3" {
+		t.Errorf("the peers were not replaced: %v", peers)
+	}
+	if peering["name"] != "peering" || peering["egress_nat"] != true {
+		t.Errorf("unexpected peering %v", peering)
+	}
+
+	_, err = client.Request(ctx, "/v1/peerings/"+id, "PATCH", []byte(body), "application/json")
+	if !hasStatus(err, http.StatusUnsupportedMediaType) {
+		t.Errorf("PATCH with a json body returned %v", err)
+	}
+}
+
+func TestETags(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t, &fakeapi.Options{ETags: true})
+
+	peering, err := client.CreatePeering(ctx, &sdk.CreatePeeringRequest{Name: "peering", Peers: []string{"ne-1"}})
+	if err != nil {
+		t.Fatalf("CreatePeering: %s", err)
+	}
+	read, err := client.GetPeering(ctx, peering.ID)
+	if err != nil || read.ETag == "" {
+		t.Fatalf("GetPeering returned no ETag: %+v, %v", read, err)
+	}
+
+	updated, err := client.UpdatePeering(ctx, peering.ID, &sdk.UpdatePeeringRequest{
+		Peers:   sdk.StringsOrNull([]string{"ne-1", "ne-2"}),
+		IfMatch: read.ETag,
+	})
+	if err != nil {
+		t.Fatalf("UpdatePeering with the current ETag: %s", err)
+	}
+	if updated.ETag == "" || updated.ETag == read.ETag {
+		t.Errorf("UpdatePeering returned ETag %q, read %q", updated.ETag, read.ETag)
+	}
+
+	// The ETag read before the update is stale
+	_, err = client.UpdatePeering(ctx, peering.ID, &sdk.UpdatePeeringRequest{
+		Peers:   sdk.StringsOrNull([]string{"ne-3"}),
+		IfMatch: read.ETag,
+	})
+	if !sdk.IsPreconditionFailed(err) {
+		t.Fatalf("UpdatePeering with a stale ETag returned %v", err)
+	}
+	current, _ := server.API.Object("peerings", peering.ID)
+	if peers := current["peers"].([]interface{}); len(peers) != 2 {
+		t.Errorf("the rejected update changed the peers: %v", peers)
+	}
+
+	// Without ETags If-Match is ignored
+	server.API.SetETags(false)
+	updated, err = client.UpdatePeering(ctx, peering.ID, &sdk.UpdatePeeringRequest{
+		Peers:   sdk.StringsOrNull([]string{"ne-3"}),
+		IfMatch: read.ETag,
+	})
+	if err != nil || updated.ETag != "" {
+		t.Errorf("UpdatePeering without ETags returned %+v, %v", updated, err)
+	}
+}
+
+func TestConsistencyDelay(t *testing.T) {
+	ctx := context.Background()
+	delay := 200 * time.Millisecond
+	server, client := newTestClient(t, &fakeapi.Options{ConsistencyDelay: delay})
+
+	created, err := client.CreateNetworkElement(ctx, &sdk.CreateNetworkElementRequest{Name: "service", MappedSubnets: []string{"10.0.0.0/24"}})
+	if err != nil {
+		t.Fatalf("CreateNetworkElement: %s", err)
+	}
+	if created.DNSName == "" {
+		t.Errorf("CreateNetworkElement returned no dns_name")
+	}
+	if _, err := client.GetNetworkElement(ctx, created.ID); !sdk.IsNotFound(err) {
+		t.Errorf("GetNetworkElement right after the creation returned %v", err)
+	}
+
+	time.Sleep(delay)
+	if _, err := client.GetNetworkElement(ctx, created.ID); err != nil {
+		t.Fatalf("GetNetworkElement after the delay: %s", err)
+	}
+
+	if _, err := client.UpdateNetworkElement(ctx, created.ID, &sdk.UpdateNetworkElementRequest{Description: sdk.String("changed")}); err != nil {
+		t.Fatalf("UpdateNetworkElement: %s", err)
+	}
+	element, err := client.GetNetworkElement(ctx, created.ID)
+	if err != nil || element.Description != "" {
+		t.Errorf("GetNetworkElement right after the update returned %+v, %v", element, err)
+	}
+	time.Sleep(delay)
+	element, err = client.GetNetworkElement(ctx, created.ID)
+	if err != nil || element.Description != "changed" {
+		t.Errorf("GetNetworkElement after the delay returned %+v, %v", element, err)
+	}
+
+	// Out of band changes show up at once
+	server.API.DeleteObject("network_elements", created.ID)
+	if _, err := client.GetNetworkElement(ctx, created.ID); !sdk.IsNotFound(err) {
+		t.Errorf("GetNetworkElement after an out of band deletion returned %v", err)
+	}
+}
+
+func TestNetworkElementSubresources(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestClient(t, nil)
+
+	created, err := client.CreateNetworkElement(ctx, &sdk.CreateNetworkElementRequest{Name: "service", MappedService: "app.internal"})
+	if err != nil {
+		t.Fatalf("CreateNetworkElement: %s", err)
+	}
+	id := created.ID
+
+	element, err := client.SetNetworkElementAlias(ctx, id, "app.example.com")
+	if err != nil || len(element.Aliases) != 1 {
+		t.Fatalf("SetNetworkElementAlias returned %+v, %v", element, err)
+	}
+	element, err = client.DeleteNetworkElementAlias(ctx, id, "app.example.com")
+	if err != nil || len(element.Aliases) != 0 {
+		t.Fatalf("DeleteNetworkElementAlias returned %+v, %v", element, err)
+	}
+
+	_, err = client.SetNetworkElementMappedDomains(ctx, id, "example.com", &sdk.MappedDomain{MappedDomain: "example.internal", EnterpriseDNS: true})
+	if err != nil {
+		t.Fatalf("SetNetworkElementMappedDomains: %s", err)
+	}
+	domain, err := client.GetMappedDomain(ctx, id, "example.com")
+	if err != nil || domain.MappedDomain != "example.internal" || !domain.EnterpriseDNS {
+		t.Fatalf("GetMappedDomain returned %+v, %v", domain, err)
+	}
+	if err := client.DeleteNetworkElementMappedDomains(ctx, id, "example.com"); err != nil {
+		t.Fatalf("DeleteNetworkElementMappedDomains: %s", err)
+	}
+	if _, err := client.GetMappedDomain(ctx, id, "example.com"); !sdk.IsNotFound(err) {
+		t.Errorf("GetMappedDomain of a deleted mapped domain returned %v", err)
+	}
+
+	_, err = client.SetNetworkElementMappedHosts(ctx, id, "host", &sdk.MappedHost{MappedHost: "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("SetNetworkElementMappedHosts: %s", err)
+	}
+	if host, err := client.GetMappedHost(ctx, id, "host"); err != nil || host.MappedHost != "10.0.0.1" {
+		t.Fatalf("GetMappedHost returned %+v, %v", host, err)
+	}
+
+	endpoint := "/v1/network_elements/" + id + "/tags"
+	if err := client.UpdateTags(ctx, endpoint, map[string]string{"env": "test"}); err != nil {
+		t.Fatalf("UpdateTags: %s", err)
+	}
+	tags, err := client.GetTags(ctx, endpoint)
+	if err != nil || len(tags) != 1 || tags["env"] != "test" {
+		t.Errorf("GetTags returned %v, %v", tags, err)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestClient(t, nil)
+
+	created, err := client.CreateGroup(ctx, &sdk.CreateGroupRequest{Name: "admins"})
+	if err != nil {
+		t.Fatalf("CreateGroup: %s", err)
+	}
+	if _, err := client.CreateGroup(ctx, &sdk.CreateGroupRequest{Name: "users"}); err != nil {
+		t.Fatalf("CreateGroup: %s", err)
+	}
+
+	if _, err := client.AddGroupUsers(ctx, created.ID, []string{"usr-1", "usr-2"}); err != nil {
+		t.Fatalf("AddGroupUsers: %s", err)
+	}
+	if _, err := client.RemoveGroupUsers(ctx, created.ID, []string{"usr-1"}); err != nil {
+		t.Fatalf("RemoveGroupUsers: %s", err)
+	}
+	if _, err := client.SetGroupRoles(ctx, created.ID, []string{"admin"}); err != nil {
+		t.Fatalf("SetGroupRoles: %s", err)
+	}
+
+	groups, err := client.GetGroups(ctx, "admins")
+	if err != nil || len(groups) != 1 {
+		t.Fatalf("GetGroups returned %+v, %v", groups, err)
+	}
+	if users := groups[0].Users; len(users) != 1 || users[0] != "usr-2" {
+		t.Errorf("unexpected users %v", users)
+	}
+	if roles := groups[0].Roles; len(roles) != 1 || roles[0] != "admin" {
+		t.Errorf("unexpected roles %v", roles)
+	}
+
+	if groups, err := client.GetGroups(ctx, ""); err != nil || len(groups) != 2 {
+		t.Errorf("GetGroups without a name returned %+v, %v", groups, err)
+	}
+}
+
+func TestBuiltinProtocolGroups(t *testing.T) {
+	ctx := context.Background()
+	_, client := newTestClient(t, nil)
+
+	groups, err := client.GetProtocolGroups(ctx)
+	if err != nil || len(groups) == 0 {
+		t.Fatalf("GetProtocolGroups returned %+v, %v", groups, err)
+	}
+
+	group := groups[0]
+	if !group.ReadOnly {
+		t.Fatalf("the protocol group %s is not read only", group.Name)
+	}
+	if err := client.DeleteProtocolGroup(ctx, group.ID); !hasStatus(err, http.StatusForbidden) {
+		t.Errorf("DeleteProtocolGroup of a builtin group returned %v", err)
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	ctx := context.Background()
+	server, client := newTestClient(t, nil)
+
+	metaport, err := client.CreateMetaPort(ctx, &sdk.CreateMetaPortRequest{Name: "metaport", Enabled: true})
+	if err != nil {
+		t.Fatalf("CreateMetaPort: %s", err)
+	}
+
+	server.API.FailNext("PATCH", "/v1/metaports/"+metaport.ID, http.StatusBadRequest, "Metaport is busy. Try again later.")
+	request := &sdk.UpdateMetaPortRequest{Enabled: sdk.Bool(false)}
+	_, err = client.UpdateMetaPort(ctx, metaport.ID, request)
+	if err == nil || !strings.Contains(err.Error(), "is busy. Try again later.") {
+		t.Errorf("UpdateMetaPort returned %v", err)
+	}
+	if _, err := client.UpdateMetaPort(ctx, metaport.ID, request); err != nil {
+		t.Errorf("UpdateMetaPort after the fault: %s", err)
+	}
+
+	secret, err := client.GenerateMetaPortOTAC(ctx, metaport.ID)
+	if err != nil || len(secret) < 5 {
+		t.Errorf("GenerateMetaPortOTAC returned %q, %v", secret, err)
+	}
+}
+
+func hasStatus(err error, statusCode int) bool {
+	apiError, ok := err.(*sdk.ApiError)
+	return ok && apiError.StatusCode == statusCode
+}
@@ -0,0 +1,72 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// tokenRequest is the body of a request to /v1/oauth/token.
+type tokenRequest struct {
+	GrantType    string `json:"grant_type"`
+	Scope        string `json:"scope"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (a *API) serveToken(w http.ResponseWriter, r *http.Request, body []byte) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method_not_allowed", r.Method+" is not allowed on "+r.URL.Path)
+		return
+	}
+
+	var request tokenRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "The request body is not valid json: "+err.Error())
+		return
+	}
+	if request.Scope != "org:"+a.options.Org {
+		writeError(w, http.StatusUnauthorized, "invalid_scope", "Unknown org in scope "+request.Scope)
+		return
+	}
+
+	now := time.Now()
+	switch request.GrantType {
+	case "client_credentials":
+		if request.ClientID != a.options.APIKey || request.ClientSecret != a.options.APISecret {
+			writeError(w, http.StatusUnauthorized, "invalid_client", "Invalid client credentials")
+			return
+		}
+	case "refresh_token":
+		expiresAt, ok := a.refreshTokens[request.RefreshToken]
+		if !ok || now.After(expiresAt) {
+			writeError(w, http.StatusUnauthorized, "invalid_grant", "The refresh token is invalid or expired")
+			return
+		}
+		delete(a.refreshTokens, request.RefreshToken)
+	default:
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type", "Unsupported grant type "+request.GrantType)
+		return
+	}
+
+	accessToken := randomString(16)
+	refreshToken := randomString(16)
+	a.accessTokens[accessToken] = now.Add(a.options.TokenLifetime)
+	a.refreshTokens[refreshToken] = now.Add(refreshTokenLifetime)
+
+	writeJSON(w, map[string]interface{}{
+		"access_token":       accessToken,
+		"expires_in":         int64(a.options.TokenLifetime / time.Second),
+		"refresh_token":      refreshToken,
+		"refresh_expires_in": int64(refreshTokenLifetime / time.Second),
+		"token_type":         "bearer",
+	})
+}
+
+func (a *API) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	expiresAt, ok := a.accessTokens[token]
+	return ok && time.Now().Before(expiresAt)
+}
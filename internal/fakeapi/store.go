@@ -0,0 +1,481 @@
+package fakeapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// computedFields are set by the API, they are ignored in request bodies.
+var computedFields = []string{"id", "org_id", "created_at", "modified_at"}
+
+// listFlags are the query parameters of list requests that are not filters.
+var listFlags = map[string]bool{
+	"expand":     true,
+	"connection": true,
+	"cursor":     true,
+}
+
+// pageSize is the number of items in a page of the wrapped lists.
+const pageSize = 100
+
+// collection holds the objects of an endpoint, like /v1/policies.
+type collection struct {
+	name    string
+	spec    collectionSpec
+	objects map[string]*object
+	order   []string
+}
+
+// object keeps the versions of an object that are not visible to every read
+// yet. A nil version is a deleted object.
+type object struct {
+	versions []version
+	tags     map[string]string
+}
+
+type version struct {
+	at   time.Time
+	data map[string]interface{}
+}
+
+func newCollection(name string, spec collectionSpec) *collection {
+	return &collection{
+		name:    name,
+		spec:    spec,
+		objects: make(map[string]*object),
+	}
+}
+
+// latest returns the object as written last, nil if it was deleted.
+func (o *object) latest() map[string]interface{} {
+	return o.versions[len(o.versions)-1].data
+}
+
+// visible returns the object as seen by reads, delay after the writes.
+func (o *object) visible(delay time.Duration) map[string]interface{} {
+	cutoff := time.Now().Add(-delay)
+	for i := len(o.versions) - 1; i >= 0; i-- {
+		if !o.versions[i].at.After(cutoff) {
+			return o.versions[i].data
+		}
+	}
+	return nil
+}
+
+func (o *object) set(data map[string]interface{}, at time.Time, delay time.Duration) {
+	o.versions = append(o.versions, version{at: at, data: data})
+
+	// Drop the versions hidden by a newer visible one
+	cutoff := time.Now().Add(-delay)
+	for i := len(o.versions) - 1; i > 0; i-- {
+		if !o.versions[i].at.After(cutoff) {
+			o.versions = o.versions[i:]
+			break
+		}
+	}
+}
+
+// store saves a new version of the object with the key of data. Out of band
+// writes skip the consistency delay.
+func (a *API) store(c *collection, data map[string]interface{}, outOfBand bool) {
+	key := c.spec.key
+	if key == "" {
+		key = "id"
+	}
+	id, _ := data[key].(string)
+	a.storeVersion(c, id, data, outOfBand)
+}
+
+func (a *API) storeVersion(c *collection, id string, data map[string]interface{}, outOfBand bool) {
+	at := time.Now()
+	if outOfBand {
+		at = at.Add(-a.options.ConsistencyDelay)
+	}
+
+	o, ok := c.objects[id]
+	if !ok {
+		o = &object{tags: make(map[string]string)}
+		c.objects[id] = o
+		c.order = append(c.order, id)
+	}
+	o.set(data, at, a.options.ConsistencyDelay)
+}
+
+// current returns the latest version of an object, nil if there is none.
+func (c *collection) current(id string) map[string]interface{} {
+	o, ok := c.objects[id]
+	if !ok {
+		return nil
+	}
+	return o.latest()
+}
+
+func (a *API) list(w http.ResponseWriter, r *http.Request, c *collection) {
+	items := make([]interface{}, 0, len(c.order))
+	for _, id := range c.order {
+		data := c.objects[id].visible(a.options.ConsistencyDelay)
+		if data != nil && matchesFilters(data, r) {
+			items = append(items, withTags(c, c.objects[id], data))
+		}
+	}
+
+	// The wrapped lists are paginated, with a cursor to the next page. The
+	// others are returned whole.
+	nextCursor := ""
+	if c.spec.items {
+		offset := 0
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			var err error
+			offset, err = strconv.Atoi(cursor)
+			if err != nil || offset < 0 {
+				writeError(w, http.StatusBadRequest, "bad_request", "Invalid cursor")
+				return
+			}
+		}
+
+		end := offset + pageSize
+		if end < len(items) {
+			nextCursor = strconv.Itoa(end)
+		}
+		if end > len(items) {
+			end = len(items)
+		}
+		if offset > end {
+			offset = end
+		}
+		items = items[offset:end]
+	}
+
+	if c.spec.items {
+		page := map[string]interface{}{"items": items}
+		if nextCursor != "" {
+			page["next_cursor"] = nextCursor
+		}
+		writeJSON(w, page)
+		return
+	}
+	writeJSON(w, items)
+}
+
+// matchesFilters reports whether the fields of data equal the query
+// parameters of the list request r. Empty parameters match anything.
+func matchesFilters(data map[string]interface{}, r *http.Request) bool {
+	for name, values := range r.URL.Query() {
+		if listFlags[name] || len(values) == 0 || values[0] == "" {
+			continue
+		}
+		field, ok := data[name]
+		if !ok || fmt.Sprint(field) != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *API) get(w http.ResponseWriter, c *collection, id string) {
+	o, ok := c.objects[id]
+	if !ok || o.visible(a.options.ConsistencyDelay) == nil {
+		writeNotFound(w, c, id)
+		return
+	}
+	data := o.visible(a.options.ConsistencyDelay)
+	a.setETag(w, data)
+	writeJSON(w, withTags(c, o, data))
+}
+
+// withTags returns data with the tags of o when the objects of c are read
+// with their tags. The tags aren't part of the ETag, they are versioned apart.
+func withTags(c *collection, o *object, data map[string]interface{}) map[string]interface{} {
+	if !c.spec.tags {
+		return data
+	}
+
+	data = clone(data)
+	data["tags"] = sortedTags(o.tags)
+	return data
+}
+
+func (a *API) create(w http.ResponseWriter, c *collection, payload interface{}) {
+	body, ok := payload.(map[string]interface{})
+	if !ok {
+		writeError(w, http.StatusBadRequest, "bad_request", "The request body must be a json object")
+		return
+	}
+
+	data := clone(body)
+	stripComputed(c, data)
+	if details := validate(c, data); len(details) > 0 {
+		writeError(w, http.StatusBadRequest, "validation_error", "Invalid request", details...)
+		return
+	}
+
+	a.complete(c, data)
+	a.store(c, data, false)
+
+	a.setETag(w, data)
+	writeJSON(w, data)
+}
+
+// complete sets the defaults and the computed fields of a new object.
+func (a *API) complete(c *collection, data map[string]interface{}) {
+	for name, value := range c.spec.defaults {
+		if _, ok := data[name]; !ok {
+			data[name] = cloneValue(value)
+		}
+	}
+
+	now := timestamp()
+	if id, _ := data["id"].(string); id == "" {
+		data["id"] = a.newID(c.spec.prefix)
+	}
+	data["org_id"] = a.options.Org
+	data["created_at"] = now
+	data["modified_at"] = now
+	if c.spec.created != nil {
+		c.spec.created(a, data)
+	}
+}
+
+func (a *API) patch(w http.ResponseWriter, r *http.Request, c *collection, id string, payload interface{}) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), mergePatchContentType) {
+		writeError(w, http.StatusUnsupportedMediaType, "unsupported_media_type", "PATCH requests must be sent as "+mergePatchContentType)
+		return
+	}
+	body, ok := payload.(map[string]interface{})
+	if !ok {
+		writeError(w, http.StatusBadRequest, "bad_request", "The request body must be a json object")
+		return
+	}
+
+	current := c.current(id)
+	if current == nil {
+		writeNotFound(w, c, id)
+		return
+	}
+	if isBuiltin(current) {
+		writeError(w, http.StatusForbidden, "forbidden", "Builtin objects can't be changed")
+		return
+	}
+	if ifMatch := r.Header.Get("If-Match"); a.options.ETags && ifMatch != "" && ifMatch != etag(current) {
+		writeError(w, http.StatusPreconditionFailed, "precondition_failed", fmt.Sprintf("Object %s in %s was changed since version %s", id, c.name, ifMatch))
+		return
+	}
+
+	patch := clone(body)
+	stripComputed(c, patch)
+	updated := mergePatch(clone(current), patch).(map[string]interface{})
+	if details := validate(c, updated); len(details) > 0 {
+		writeError(w, http.StatusBadRequest, "validation_error", "Invalid request", details...)
+		return
+	}
+	updated["modified_at"] = timestamp()
+
+	a.storeVersion(c, id, updated, false)
+
+	a.setETag(w, updated)
+	writeJSON(w, updated)
+}
+
+func (a *API) delete(w http.ResponseWriter, c *collection, id string) {
+	current := c.current(id)
+	if current == nil {
+		writeNotFound(w, c, id)
+		return
+	}
+	if isBuiltin(current) {
+		writeError(w, http.StatusForbidden, "forbidden", "Builtin objects can't be deleted")
+		return
+	}
+
+	a.storeVersion(c, id, nil, false)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// update stores a new version of an object changed by a subresource, like an
+// alias of a network element, and returns it.
+func (a *API) update(c *collection, id string, data map[string]interface{}) map[string]interface{} {
+	data = clone(data)
+	data["modified_at"] = timestamp()
+	a.storeVersion(c, id, data, false)
+	return data
+}
+
+// Object returns the latest version of an object of collection, like
+// "network_elements", including writes not visible to reads yet.
+func (a *API) Object(collection, id string) (map[string]interface{}, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	data := a.collection(collection).current(id)
+	if data == nil {
+		return nil, false
+	}
+	return clone(data), true
+}
+
+// PutObject creates or replaces an object of collection out of band, like a
+// change made in the admin portal, and returns its id. The change shows up in
+// reads at once. New objects get an id unless data has one.
+func (a *API) PutObject(collection string, data map[string]interface{}) string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	c := a.collection(collection)
+	data = clone(data)
+	id, _ := data["id"].(string)
+
+	current := c.current(id)
+	if current == nil {
+		a.complete(c, data)
+	} else {
+		data["id"] = id
+		data["org_id"] = current["org_id"]
+		data["created_at"] = current["created_at"]
+		data["modified_at"] = timestamp()
+	}
+	a.store(c, data, true)
+
+	return data["id"].(string)
+}
+
+// DeleteObject deletes an object of collection out of band. It reports
+// whether the object existed.
+func (a *API) DeleteObject(collection, id string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	c := a.collection(collection)
+	if c.current(id) == nil {
+		return false
+	}
+	a.storeVersion(c, id, nil, true)
+
+	return true
+}
+
+func (a *API) collection(name string) *collection {
+	c, ok := a.collections[name]
+	if !ok {
+		panic("fakeapi: unknown collection " + name)
+	}
+	return c
+}
+
+func stripComputed(c *collection, data map[string]interface{}) {
+	for _, name := range computedFields {
+		delete(data, name)
+	}
+	for _, name := range c.spec.computed {
+		delete(data, name)
+	}
+}
+
+func validate(c *collection, data map[string]interface{}) []fieldError {
+	var details []fieldError
+	for _, name := range c.spec.required {
+		if value, ok := data[name]; !ok || value == nil || value == "" {
+			details = append(details, fieldError{Field: name, Message: "This field is required"})
+		}
+	}
+	return details
+}
+
+func isBuiltin(data map[string]interface{}) bool {
+	readOnly, _ := data["read_only"].(bool)
+	return readOnly
+}
+
+func writeNotFound(w http.ResponseWriter, c *collection, id string) {
+	writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("No object %s in %s", id, c.name))
+}
+
+// mergePatch applies a json merge patch, as defined by RFC 7386, to target.
+// Maps of target are changed in place.
+func mergePatch(target, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetMap, ok := target.(map[string]interface{})
+	if !ok {
+		targetMap = make(map[string]interface{})
+	}
+	for name, value := range patchMap {
+		if value == nil {
+			delete(targetMap, name)
+			continue
+		}
+		targetMap[name] = mergePatch(targetMap[name], value)
+	}
+
+	return targetMap
+}
+
+func clone(data map[string]interface{}) map[string]interface{} {
+	return cloneValue(data).(map[string]interface{})
+}
+
+func cloneValue(value interface{}) interface{} {
+	b, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	var copied interface{}
+	if err := json.Unmarshal(b, &copied); err != nil {
+		panic(err)
+	}
+	return copied
+}
+
+// stringList returns the strings of a json array field.
+func stringList(value interface{}) []string {
+	items, _ := value.([]interface{})
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+func sortedTags(tags map[string]string) []map[string]string {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]map[string]string, 0, len(tags))
+	for _, name := range names {
+		list = append(list, map[string]string{"name": name, "value": tags[name]})
+	}
+	return list
+}
+
+// setETag sets the ETag header of a response returning data, when ETags are
+// enabled.
+func (a *API) setETag(w http.ResponseWriter, data map[string]interface{}) {
+	if a.options.ETags {
+		w.Header().Set("ETag", etag(data))
+	}
+}
+
+// etag returns the version of an object, which changes on every write.
+func etag(data map[string]interface{}) string {
+	sum := sha256.Sum256([]byte(fmt.Sprint(data["id"], data["modified_at"])))
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,816 @@
+// Package importgen generates the Terraform configuration adopting the
+// objects of an existing org, for cmd/importgen.
+package importgen
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"terraform-provider-metanetworks/metanetworks"
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maxListLineLength is the length of the lists of the generated configuration
+// beyond which they are written one item per line.
+const maxListLineLength int = 100
+
+var (
+	// labelInvalidChars are the runs of characters replaced by an underscore
+	// in the labels derived from the names of the objects.
+	labelInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+	// hclIdentifier matches the map keys that need no quotes.
+	hclIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+)
+
+// importedObject is an object of the org adopted by a resource of the
+// generated configuration.
+type importedObject struct {
+	file         string
+	resourceType string
+	label        string
+	// importID is the ID of the object given to terraform import, like the
+	// composite ID of an alias.
+	importID string
+	data     *schema.ResourceData
+}
+
+// referencedObject is an object that can't be managed by a resource, like a
+// user, declared by a data source when the resources refer to it.
+type referencedObject struct {
+	file           string
+	dataSourceType string
+	label          string
+	// argument and value select the object in the data source.
+	argument string
+	value    string
+	used     bool
+}
+
+// importGenerator collects the objects of an org and writes the Terraform
+// configuration adopting them.
+type importGenerator struct {
+	provider   *schema.Provider
+	client     sdk.API
+	objects    []*importedObject
+	referenced []*referencedObject
+	// byID holds the objects that others refer to by ID.
+	byID           map[string]*importedObject
+	referencedByID map[string]*referencedObject
+	// labels holds the labels used by each resource and data source type.
+	labels map[string]map[string]bool
+}
+
+// Generate lists the objects of the org with client and returns the Terraform
+// configuration adopting them, by file name. Each object gets a resource,
+// preceded by the import block of Terraform 1.5 importing it, with the
+// arguments that importing and reading it sets. The IDs of other objects are
+// replaced by references to their resources, or to data sources for the users
+// and the built-in protocol groups.
+//
+// The network elements of the routing groups, peerings, Metaports and Metaport
+// clusters are written as the resources setting all of them, like
+// metanetworks_metaport_mapped_elements, never as attachments: the two
+// conflict, and the resources setting all the members keep the generated
+// configuration authoritative.
+func Generate(ctx context.Context, client sdk.API) (map[string][]byte, error) {
+	g := &importGenerator{
+		provider:       metanetworks.Provider(),
+		client:         client,
+		byID:           make(map[string]*importedObject),
+		referencedByID: make(map[string]*referencedObject),
+		labels:         make(map[string]map[string]bool),
+	}
+
+	// The network elements come first, so that the references to them are
+	// resolved.
+	for _, add := range []func(context.Context) error{
+		g.addUsers,
+		g.addNetworkElements,
+		g.addGroups,
+		g.addProtocolGroups,
+		g.addPolicies,
+		g.addRoutingGroups,
+		g.addPeerings,
+		g.addEgressRoutes,
+		g.addMetaports,
+		g.addMetaportClusters,
+		g.addPostureChecks,
+		g.addSwgContentCategories,
+		g.addSwgThreatCategories,
+		g.addSwgUrlFilteringRules,
+	} {
+		if err := add(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.render(), nil
+}
+
+func (g *importGenerator) addUsers(ctx context.Context) error {
+	users, err := g.client.GetUsers(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		g.addReferenced(user.ID, &referencedObject{
+			file:           "user.tf",
+			dataSourceType: "metanetworks_user",
+			label:          g.label("data.metanetworks_user", user.Email),
+			argument:       "email",
+			value:          user.Email,
+		})
+	}
+	return nil
+}
+
+func (g *importGenerator) addNetworkElements(ctx context.Context) error {
+	networkElements, err := g.client.ListNetworkElements(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range networkElements {
+		networkElement := &networkElements[i]
+		resourceType, ok := metanetworks.NetworkElementResource(networkElement)
+		if !ok {
+			log.Printf("[WARN] Skipping network element %s of unknown type", networkElement.ID)
+			continue
+		}
+
+		file := strings.TrimPrefix(resourceType, "metanetworks_") + ".tf"
+		parent, err := g.addResource(ctx, file, resourceType, networkElement.Name, networkElement.ID, nil)
+		if err != nil || parent == nil {
+			return err
+		}
+
+		// Only the devices, mapped services and native services have an
+		// alias resource
+		aliasType := resourceType + "_alias"
+		if _, ok := g.provider.ResourcesMap[aliasType]; ok {
+			for _, alias := range networkElement.Aliases {
+				if _, err := g.add(ctx, file, aliasType, parent.label+"_"+alias, compositeID(networkElement.ID, alias), nil); err != nil {
+					return err
+				}
+			}
+		}
+
+		if resourceType != "metanetworks_mapped_subnets" {
+			continue
+		}
+		for _, mappedDomain := range networkElement.MappedDomains {
+			if _, err := g.add(ctx, file, "metanetworks_mapped_subnets_mapped_domain", parent.label+"_"+mappedDomain.Name, compositeID(networkElement.ID, mappedDomain.Name), nil); err != nil {
+				return err
+			}
+		}
+		for _, mappedHost := range networkElement.MappedHosts {
+			if _, err := g.add(ctx, file, "metanetworks_mapped_subnets_mapped_host", parent.label+"_"+mappedHost.Name, compositeID(networkElement.ID, mappedHost.Name), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addGroups(ctx context.Context) error {
+	groups, err := g.client.GetGroups(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	for i := range groups {
+		group := &groups[i]
+		_, err := g.addResource(ctx, "group.tf", "metanetworks_group", group.Name, group.ID, func(d *schema.ResourceData) error {
+			// The users of the groups with an expression are computed
+			if group.Expression != "" {
+				return d.Set("users", nil)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addProtocolGroups(ctx context.Context) error {
+	protocolGroups, err := g.client.GetProtocolGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range protocolGroups {
+		protocolGroup := &protocolGroups[i]
+		// The built-in protocol groups can't be changed, they are read by name
+		if protocolGroup.ReadOnly {
+			g.addReferenced(protocolGroup.ID, &referencedObject{
+				file:           "protocol_group.tf",
+				dataSourceType: "metanetworks_protocol_group",
+				label:          g.label("data.metanetworks_protocol_group", protocolGroup.Name),
+				argument:       "name_regex",
+				value:          "^" + regexp.QuoteMeta(protocolGroup.Name) + "$",
+			})
+			continue
+		}
+
+		if _, err := g.addResource(ctx, "protocol_group.tf", "metanetworks_protocol_group", protocolGroup.Name, protocolGroup.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addPolicies(ctx context.Context) error {
+	policies, err := g.client.ListPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		if _, err := g.addResource(ctx, "policy.tf", "metanetworks_policy", policy.Name, policy.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addRoutingGroups(ctx context.Context) error {
+	routingGroups, err := g.client.ListRoutingGroups(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, routingGroup := range routingGroups {
+		object, err := g.addResource(ctx, "routing_group.tf", "metanetworks_routing_group", routingGroup.Name, routingGroup.ID, nil)
+		if err != nil {
+			return err
+		}
+		if err := g.addMemberList(ctx, object, "metanetworks_routing_group_mapped_elements", routingGroup.MappedElements); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addPeerings(ctx context.Context) error {
+	peerings, err := g.client.ListPeerings(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, peering := range peerings {
+		object, err := g.addResource(ctx, "peering.tf", "metanetworks_peering", peering.Name, peering.ID, nil)
+		if err != nil {
+			return err
+		}
+		if err := g.addMemberList(ctx, object, "metanetworks_peering_peers", peering.Peers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addEgressRoutes(ctx context.Context) error {
+	egressRoutes, err := g.client.ListEgressRoutes(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, egressRoute := range egressRoutes {
+		if _, err := g.addResource(ctx, "egress_route.tf", "metanetworks_egress_route", egressRoute.Name, egressRoute.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addMetaports(ctx context.Context) error {
+	metaports, err := g.client.ListMetaPorts(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, metaport := range metaports {
+		object, err := g.addResource(ctx, "metaport.tf", "metanetworks_metaport", metaport.Name, metaport.ID, nil)
+		if err != nil {
+			return err
+		}
+		if err := g.addMemberList(ctx, object, "metanetworks_metaport_mapped_elements", metaport.MappedElements); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addMetaportClusters(ctx context.Context) error {
+	metaportClusters, err := g.client.ListMetaPortClusters(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, metaportCluster := range metaportClusters {
+		object, err := g.addResource(ctx, "metaport_cluster.tf", "metanetworks_metaport_cluster", metaportCluster.Name, metaportCluster.ID, nil)
+		if err != nil {
+			return err
+		}
+		if err := g.addMemberList(ctx, object, "metanetworks_metaport_cluster_mapped_elements", metaportCluster.MappedElements); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addPostureChecks(ctx context.Context) error {
+	postureChecks, err := g.client.ListPostureChecks(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, postureCheck := range postureChecks {
+		if _, err := g.addResource(ctx, "posture_check.tf", "metanetworks_posture_check", postureCheck.Name, postureCheck.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addSwgContentCategories(ctx context.Context) error {
+	swgContentCategories, err := g.client.ListSwgContentCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, contentCategories := range swgContentCategories {
+		if _, err := g.addResource(ctx, "swg_content_categories.tf", "metanetworks_swg_content_categories", contentCategories.Name, contentCategories.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addSwgThreatCategories(ctx context.Context) error {
+	swgThreatCategories, err := g.client.ListSwgThreatCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, threatCategories := range swgThreatCategories {
+		if _, err := g.addResource(ctx, "swg_threat_categories.tf", "metanetworks_swg_threat_categories", threatCategories.Name, threatCategories.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *importGenerator) addSwgUrlFilteringRules(ctx context.Context) error {
+	swgUrlFilteringRules, err := g.client.ListSwgUrlFilteringRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, urlFilteringRules := range swgUrlFilteringRules {
+		if _, err := g.addResource(ctx, "swg_url_filtering_rules.tf", "metanetworks_swg_url_filtering_rules", urlFilteringRules.Name, urlFilteringRules.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addMemberList adds the resource of resourceType setting all the network
+// elements of the parent object, in the file of the parent. Its ID is the one
+// of the parent. Parents without network elements get none.
+func (g *importGenerator) addMemberList(ctx context.Context, parent *importedObject, resourceType string, networkElementIDs []string) error {
+	if parent == nil || len(networkElementIDs) == 0 {
+		return nil
+	}
+
+	_, err := g.add(ctx, parent.file, resourceType, parent.label, parent.importID, nil)
+	return err
+}
+
+// addResource adds an object that others may refer to by its ID, importID.
+func (g *importGenerator) addResource(ctx context.Context, file, resourceType, name, importID string, adjust func(d *schema.ResourceData) error) (*importedObject, error) {
+	object, err := g.add(ctx, file, resourceType, name, importID, adjust)
+	if err != nil || object == nil {
+		return nil, err
+	}
+
+	g.byID[importID] = object
+	return object, nil
+}
+
+// add adds an object adopted by a resource of resourceType labeled after
+// name. Its arguments are set like terraform import does, by importing
+// importID then reading the resource, and adjust, if any, changes them after.
+// It returns nil when the object is gone since it was listed.
+func (g *importGenerator) add(ctx context.Context, file, resourceType, name, importID string, adjust func(d *schema.ResourceData) error) (*importedObject, error) {
+	r := g.provider.ResourcesMap[resourceType]
+	d := r.Data(nil)
+	d.SetId(importID)
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		imported, err := r.Importer.StateContext(ctx, d, g.client)
+		if err != nil {
+			return nil, fmt.Errorf("Could not import the %s of %s: %s", resourceType, importID, err)
+		}
+		d = imported[0]
+	}
+	if diags := r.ReadContext(ctx, d, g.client); diags.HasError() {
+		return nil, fmt.Errorf("Could not read the %s of %s: %s", resourceType, importID, diagsError(diags))
+	}
+	if d.Id() == "" {
+		log.Printf("[WARN] Skipping the %s of %s, it's gone", resourceType, importID)
+		return nil, nil
+	}
+	if adjust != nil {
+		if err := adjust(d); err != nil {
+			return nil, fmt.Errorf("Could not generate the %s of %s: %s", resourceType, importID, err)
+		}
+	}
+
+	object := &importedObject{
+		file:         file,
+		resourceType: resourceType,
+		label:        g.label(resourceType, name),
+		importID:     importID,
+		data:         d,
+	}
+	g.objects = append(g.objects, object)
+	return object, nil
+}
+
+// addReferenced adds an object declared by a data source if a resource refers
+// to it.
+func (g *importGenerator) addReferenced(id string, object *referencedObject) {
+	g.referenced = append(g.referenced, object)
+	g.referencedByID[id] = object
+}
+
+// label returns a unique label among those of kind, a resource or data
+// source type, derived from name.
+func (g *importGenerator) label(kind, name string) string {
+	label := strings.Trim(labelInvalidChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" {
+		label = "unnamed"
+	}
+	if label[0] >= '0' && label[0] <= '9' {
+		label = "_" + label
+	}
+
+	used, ok := g.labels[kind]
+	if !ok {
+		used = make(map[string]bool)
+		g.labels[kind] = used
+	}
+	unique := label
+	for n := 2; used[unique]; n++ {
+		unique = fmt.Sprintf("%s_%d", label, n)
+	}
+	used[unique] = true
+
+	return unique
+}
+
+// reference returns the expression of the ID of the object id, if it is
+// declared in the generated configuration.
+func (g *importGenerator) reference(id string) (string, bool) {
+	if object, ok := g.byID[id]; ok {
+		return object.resourceType + "." + object.label + ".id", true
+	}
+	if object, ok := g.referencedByID[id]; ok {
+		object.used = true
+		return "data." + object.dataSourceType + "." + object.label + ".id", true
+	}
+	return "", false
+}
+
+// render returns the generated files. The data sources of the referenced
+// objects come first in their file.
+func (g *importGenerator) render() map[string][]byte {
+	files := make(map[string]*bytes.Buffer)
+	buffer := func(file string) *bytes.Buffer {
+		b, ok := files[file]
+		if !ok {
+			b = &bytes.Buffer{}
+			files[file] = b
+		} else {
+			b.WriteString("\n")
+		}
+		return b
+	}
+
+	// The resources are rendered first, to know which objects they refer to
+	resources := make(map[string]*bytes.Buffer)
+	for _, object := range g.objects {
+		b, ok := resources[object.file]
+		if !ok {
+			b = &bytes.Buffer{}
+			resources[object.file] = b
+		} else {
+			b.WriteString("\n")
+		}
+		g.writeResource(b, object)
+	}
+
+	for _, object := range g.referenced {
+		if !object.used {
+			continue
+		}
+		b := buffer(object.file)
+		fmt.Fprintf(b, "data %q %q {\n", object.dataSourceType, object.label)
+		fmt.Fprintf(b, "  %s = %s\n", object.argument, hclString(object.value))
+		b.WriteString("}\n")
+	}
+	for file, resource := range resources {
+		buffer(file).Write(resource.Bytes())
+	}
+
+	rendered := make(map[string][]byte, len(files))
+	for file, b := range files {
+		rendered[file] = b.Bytes()
+	}
+	return rendered
+}
+
+// writeResource writes the import block and the resource of object.
+func (g *importGenerator) writeResource(b *bytes.Buffer, object *importedObject) {
+	b.WriteString("import {\n")
+	fmt.Fprintf(b, "  to = %s.%s\n", object.resourceType, object.label)
+	fmt.Fprintf(b, "  id = %s\n", hclString(object.importID))
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(b, "resource %q %q {\n", object.resourceType, object.label)
+	g.writeBody(b, "  ", g.provider.ResourcesMap[object.resourceType].Schema, object.data.Get)
+	b.WriteString("}\n")
+}
+
+// writeBody writes the arguments of a block with schema, whose values are
+// returned by get. The arguments left to their default are omitted. The
+// arguments on one line come first, aligned like terraform fmt does, then the
+// ones spanning lines and the nested blocks, each after an empty line.
+func (g *importGenerator) writeBody(b *bytes.Buffer, indent string, schemaMap map[string]*schema.Schema, get func(string) interface{}) {
+	keys := make([]string, 0, len(schemaMap))
+	for key := range schemaMap {
+		keys = append(keys, key)
+	}
+	// The name first, then in alphabetical order
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i] == "name" || keys[j] == "name" {
+			return keys[i] == "name"
+		}
+		return keys[i] < keys[j]
+	})
+
+	type argument struct {
+		key   string
+		value string
+	}
+	var lines, multiline []argument
+	var blocks []string
+	for _, key := range keys {
+		s := schemaMap[key]
+		if key == "id" || !(s.Required || s.Optional) {
+			continue
+		}
+		value := get(key)
+		if !s.Required && isDefaultValue(s, value) {
+			continue
+		}
+
+		if _, ok := s.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, key)
+			continue
+		}
+		expression := g.expression(key, s, value, indent)
+		if strings.Contains(expression, "\n") {
+			multiline = append(multiline, argument{key, expression})
+		} else {
+			lines = append(lines, argument{key, expression})
+		}
+	}
+
+	width := 0
+	for _, line := range lines {
+		if len(line.key) > width {
+			width = len(line.key)
+		}
+	}
+	for _, line := range lines {
+		fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, line.key, line.value)
+	}
+	empty := len(lines) == 0
+	for _, arg := range multiline {
+		if !empty {
+			b.WriteString("\n")
+		}
+		empty = false
+		fmt.Fprintf(b, "%s%s = %s\n", indent, arg.key, arg.value)
+	}
+	for _, key := range blocks {
+		var items []interface{}
+		switch value := get(key).(type) {
+		case []interface{}:
+			items = value
+		case *schema.Set:
+			items = value.List()
+		}
+		elem := schemaMap[key].Elem.(*schema.Resource)
+		for _, item := range items {
+			values, _ := item.(map[string]interface{})
+			if !empty {
+				b.WriteString("\n")
+			}
+			empty = false
+			fmt.Fprintf(b, "%s%s {\n", indent, key)
+			g.writeBody(b, indent+"  ", elem.Schema, func(key string) interface{} { return values[key] })
+			fmt.Fprintf(b, "%s}\n", indent)
+		}
+	}
+}
+
+// expression returns the HCL expression of the value of the argument key with
+// schema s, written at indent.
+func (g *importGenerator) expression(key string, s *schema.Schema, value interface{}, indent string) string {
+	references := isReferenceKey(key)
+
+	switch s.Type {
+	case schema.TypeList, schema.TypeSet:
+		var items []interface{}
+		switch value := value.(type) {
+		case []interface{}:
+			items = value
+		case *schema.Set:
+			items = value.List()
+		}
+		expressions := make([]string, 0, len(items))
+		for _, item := range items {
+			expressions = append(expressions, g.primitiveExpression(item, references))
+		}
+		if s.Type == schema.TypeSet {
+			sort.Strings(expressions)
+		}
+
+		line := "[" + strings.Join(expressions, ", ") + "]"
+		if len(indent)+len(line) <= maxListLineLength {
+			return line
+		}
+		return "[\n" + indent + "  " + strings.Join(expressions, ",\n"+indent+"  ") + ",\n" + indent + "]"
+	case schema.TypeMap:
+		values, _ := value.(map[string]interface{})
+		keys := make([]string, 0, len(values))
+		width := 0
+		for key := range values {
+			keys = append(keys, key)
+			if n := len(hclKey(key)); n > width {
+				width = n
+			}
+		}
+		sort.Strings(keys)
+
+		var b strings.Builder
+		b.WriteString("{\n")
+		for _, key := range keys {
+			fmt.Fprintf(&b, "%s  %-*s = %s\n", indent, width, hclKey(key), g.primitiveExpression(values[key], false))
+		}
+		b.WriteString(indent + "}")
+		return b.String()
+	default:
+		return g.primitiveExpression(value, references)
+	}
+}
+
+// primitiveExpression returns the HCL expression of a string, number or bool.
+// With references, the strings holding the ID of an object declared in the
+// configuration are replaced by a reference to it.
+func (g *importGenerator) primitiveExpression(value interface{}, references bool) string {
+	switch value := value.(type) {
+	case string:
+		if reference, ok := g.reference(value); ok && references {
+			return reference
+		}
+		return hclString(value)
+	case bool:
+		return strconv.FormatBool(value)
+	case int:
+		return strconv.Itoa(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return hclString(fmt.Sprint(value))
+	}
+}
+
+// referenceKeys are the arguments holding the IDs of other objects, besides
+// the ones ending with _id.
+var referenceKeys = map[string]bool{
+	"destinations":                 true,
+	"exempt_sources":               true,
+	"forbidden_content_categories": true,
+	"mapped_elements":              true,
+	"peers":                        true,
+	"protocol_groups":              true,
+	"sources":                      true,
+	"threat_category":              true,
+	"users":                        true,
+	"via":                          true,
+}
+
+// isReferenceKey reports whether the argument key holds IDs, which may be
+// replaced by references. The other strings, like descriptions and tags, are
+// kept as they are even when they happen to match an ID.
+func isReferenceKey(key string) bool {
+	return referenceKeys[key] || strings.HasSuffix(key, "_id") || strings.HasSuffix(key, "_ids")
+}
+
+// isDefaultValue reports whether value is the default of an optional
+// argument with schema s, or its zero value.
+func isDefaultValue(s *schema.Schema, value interface{}) bool {
+	if s.Default != nil {
+		return fmt.Sprint(value) == fmt.Sprint(s.Default)
+	}
+
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case bool:
+		return !value
+	case int:
+		return value == 0
+	case float64:
+		return value == 0
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	case *schema.Set:
+		return value.Len() == 0
+	default:
+		return false
+	}
+}
+
+// compositeID returns the ID of the resources of the children of an object,
+// like an alias, given to terraform import.
+func compositeID(parentID, child string) string {
+	return fmt.Sprintf("%s_%s", parentID, child)
+}
+
+// diagsError returns the summaries of the errors of diags.
+func diagsError(diags diag.Diagnostics) string {
+	var summaries []string
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			summaries = append(summaries, d.Summary)
+		}
+	}
+	return strings.Join(summaries, ", ")
+}
+
+// hclKey returns the key of a map, quoted unless it is an identifier.
+func hclKey(key string) string {
+	if hclIdentifier.MatchString(key) {
+		return key
+	}
+	return hclString(key)
+}
+
+// hclString returns the quoted HCL string of s. The template sequences ${
+// and %{ are escaped, so that s is taken literally.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
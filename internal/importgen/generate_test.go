@@ -0,0 +1,211 @@
+package importgen
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"terraform-provider-metanetworks/internal/fakeapi"
+	"terraform-provider-metanetworks/sdk"
+)
+
+// TestGenerate generates the configuration of an org holding a device with an
+// alias and tags, mapped subnets with a mapped domain in a Metaport, and a
+// policy referring to them, a user and a built-in protocol group. The
+// description and the tags matching IDs are kept as they are.
+func TestGenerate(t *testing.T) {
+	ctx := context.Background()
+	server := fakeapi.NewServer(nil)
+	defer server.Close()
+
+	client, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, &sdk.ClientOptions{
+		BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	user, err := client.CreateUser(ctx, &sdk.CreateUserRequest{Email: "jane@example.com", GivenName: "Jane", FamilyName: "Doe"})
+	if err != nil {
+		t.Fatalf("CreateUser: %s", err)
+	}
+	enabled := true
+	device, err := client.CreateNetworkElement(ctx, &sdk.CreateNetworkElementRequest{Name: "Laptop 1", OwnerID: user.ID, Platform: "macOS", Enabled: &enabled})
+	if err != nil {
+		t.Fatalf("CreateNetworkElement: %s", err)
+	}
+	if _, err := client.SetNetworkElementAlias(ctx, device.ID, "laptop.internal"); err != nil {
+		t.Fatalf("SetNetworkElementAlias: %s", err)
+	}
+	if err := client.SetNetworkElementTags(ctx, device.ID, map[string]string{"team": "blue", "cost center": "${x}", "owner": user.ID}); err != nil {
+		t.Fatalf("SetNetworkElementTags: %s", err)
+	}
+	subnets, err := client.CreateNetworkElement(ctx, &sdk.CreateNetworkElementRequest{Name: "office", MappedSubnets: []string{"10.0.0.0/24"}, Enabled: &enabled})
+	if err != nil {
+		t.Fatalf("CreateNetworkElement: %s", err)
+	}
+	if _, err := client.SetNetworkElementMappedDomains(ctx, subnets.ID, "corp.internal", &sdk.MappedDomain{MappedDomain: "corp.local", Name: "corp.internal"}); err != nil {
+		t.Fatalf("SetNetworkElementMappedDomains: %s", err)
+	}
+	metaport, err := client.CreateMetaPort(ctx, &sdk.CreateMetaPortRequest{Name: "Office", Enabled: true, AllowSupport: &enabled, MappedElements: []string{subnets.ID}})
+	if err != nil {
+		t.Fatalf("CreateMetaPort: %s", err)
+	}
+	protocolGroups, err := client.GetProtocolGroups(ctx)
+	if err != nil {
+		t.Fatalf("GetProtocolGroups: %s", err)
+	}
+	policy, err := client.CreatePolicy(ctx, &sdk.CreatePolicyRequest{
+		Name:           "web",
+		Description:    subnets.ID,
+		Enabled:        true,
+		Sources:        []string{user.ID},
+		Destinations:   []string{subnets.ID},
+		ProtocolGroups: []string{protocolGroups[0].ID},
+	})
+	if err != nil {
+		t.Fatalf("CreatePolicy: %s", err)
+	}
+
+	files, err := Generate(ctx, client)
+	if err != nil {
+		t.Fatalf("Generate: %s", err)
+	}
+
+	want := map[string]string{
+		"device.tf": fmt.Sprintf(`import {
+  to = metanetworks_device.laptop_1
+  id = %q
+}
+
+resource "metanetworks_device" "laptop_1" {
+  name     = "Laptop 1"
+  owner_id = data.metanetworks_user.jane_example_com.id
+  platform = "macOS"
+
+  tags = {
+    "cost center" = "$${x}"
+    owner         = %q
+    team          = "blue"
+  }
+}
+
+import {
+  to = metanetworks_device_alias.laptop_1_laptop_internal
+  id = "%s_laptop.internal"
+}
+
+resource "metanetworks_device_alias" "laptop_1_laptop_internal" {
+  alias     = "laptop.internal"
+  device_id = metanetworks_device.laptop_1.id
+}
+`, device.ID, user.ID, device.ID),
+		"mapped_subnets.tf": fmt.Sprintf(`import {
+  to = metanetworks_mapped_subnets.office
+  id = %q
+}
+
+resource "metanetworks_mapped_subnets" "office" {
+  name           = "office"
+  mapped_subnets = ["10.0.0.0/24"]
+}
+
+import {
+  to = metanetworks_mapped_subnets_mapped_domain.office_corp_internal
+  id = "%s_corp.internal"
+}
+
+resource "metanetworks_mapped_subnets_mapped_domain" "office_corp_internal" {
+  name              = "corp.internal"
+  mapped_domain     = "corp.local"
+  mapped_subnets_id = metanetworks_mapped_subnets.office.id
+}
+`, subnets.ID, subnets.ID),
+		"metaport.tf": fmt.Sprintf(`import {
+  to = metanetworks_metaport.office
+  id = %q
+}
+
+resource "metanetworks_metaport" "office" {
+  name = "Office"
+}
+
+import {
+  to = metanetworks_metaport_mapped_elements.office
+  id = %q
+}
+
+resource "metanetworks_metaport_mapped_elements" "office" {
+  mapped_elements = [metanetworks_mapped_subnets.office.id]
+  metaport_id     = metanetworks_metaport.office.id
+}
+`, metaport.ID, metaport.ID),
+		"policy.tf": fmt.Sprintf(`import {
+  to = metanetworks_policy.web
+  id = %q
+}
+
+resource "metanetworks_policy" "web" {
+  name            = "web"
+  description     = %q
+  destinations    = [metanetworks_mapped_subnets.office.id]
+  protocol_groups = [data.metanetworks_protocol_group.%s.id]
+  sources         = [data.metanetworks_user.jane_example_com.id]
+}
+`, policy.ID, subnets.ID, labelInvalidChars.ReplaceAllString(strings.ToLower(protocolGroups[0].Name), "_")),
+		"protocol_group.tf": fmt.Sprintf(`data "metanetworks_protocol_group" %q {
+  name_regex = "^%s$"
+}
+`, labelInvalidChars.ReplaceAllString(strings.ToLower(protocolGroups[0].Name), "_"), protocolGroups[0].Name),
+		"user.tf": `data "metanetworks_user" "jane_example_com" {
+  email = "jane@example.com"
+}
+`,
+	}
+
+	for name, content := range want {
+		if got := string(files[name]); got != content {
+			t.Errorf("%s:\n%s\nwant:\n%s", name, got, content)
+		}
+	}
+	for name := range files {
+		if _, ok := want[name]; !ok {
+			t.Errorf("unexpected file %s:\n%s", name, files[name])
+		}
+	}
+}
+
+func TestHCLString(t *testing.T) {
+	for _, test := range []struct {
+		s, want string
+	}{
+		{"plain", `"plain"`},
+		{`say "hi" \o/`, `"say \"hi\" \\o/"`},
+		{"two\nlines\t", `"two\nlines\t"`},
+		{"bell\a", `"bell\u0007"`},
+		{"${var.x} and %{if} but $ and %", `"$${var.x} and %%{if} but $ and %"`},
+	} {
+		if got := hclString(test.s); got != test.want {
+			t.Errorf("hclString(%q) = %s, want %s", test.s, got, test.want)
+		}
+	}
+}
+
+func TestImportGeneratorLabel(t *testing.T) {
+	g := &importGenerator{labels: make(map[string]map[string]bool)}
+	for _, test := range []struct {
+		kind, name, want string
+	}{
+		{"metanetworks_group", "Sales Team", "sales_team"},
+		{"metanetworks_group", "sales-team", "sales_team_2"},
+		{"metanetworks_group", "sales_team", "sales_team_3"},
+		{"metanetworks_policy", "Sales Team", "sales_team"},
+		{"metanetworks_group", "10.0.0.0/8", "_10_0_0_0_8"},
+		{"metanetworks_group", "***", "unnamed"},
+	} {
+		if got := g.label(test.kind, test.name); got != test.want {
+			t.Errorf("label(%q, %q) = %q, want %q", test.kind, test.name, got, test.want)
+		}
+	}
+}
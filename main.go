@@ -6,7 +6,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
+// version is set by the release builds, see .goreleaser.yml
+var version string = "dev"
+
 func main() {
+	metanetworks.Version = version
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: metanetworks.Provider})
 }
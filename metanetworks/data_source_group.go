@@ -3,6 +3,8 @@ package metanetworks
 import (
 	"context"
 
+	"terraform-provider-metanetworks/sdk"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -64,15 +66,15 @@ func dataSourceGroup() *schema.Resource {
 }
 
 func dataSourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Client)
+	client := m.(sdk.API)
 
 	// // Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
 	name := d.Get("name").(string)
 
-	var group []Group
-	group, err := client.GetGroups(name)
+	var group []sdk.Group
+	group, err := client.GetGroups(ctx, name)
 	if err != nil {
 		return diag.FromErr(err)
 	}
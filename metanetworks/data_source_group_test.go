@@ -0,0 +1,44 @@
+package metanetworks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceGroup_basic(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_group.test"
+	dataSourceName := "data.metanetworks_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_group", testAccGetGroup),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGroupConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "description", resourceName, "description"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "org_id", resourceName, "org_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGroupConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "metanetworks_group" "test" {
+  name        = %[1]q
+  description = "data source"
+}
+
+data "metanetworks_group" "test" {
+  name = metanetworks_group.test.name
+}
+`, rName)
+}
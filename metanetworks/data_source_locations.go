@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"time"
 
+	"terraform-provider-metanetworks/sdk"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -64,13 +66,13 @@ func dataSourceLocations() *schema.Resource {
 }
 
 func dataSourceLocationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Client)
+	client := m.(sdk.API)
 
 	// // Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	var locations []Location
-	locations, err := client.GetLocations()
+	var locations []sdk.Location
+	locations, err := client.GetLocations(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -84,7 +86,7 @@ func dataSourceLocationsRead(ctx context.Context, d *schema.ResourceData, m inte
 	return diags
 }
 
-func locationsToResource(d *schema.ResourceData, m *[]Location) error {
+func locationsToResource(d *schema.ResourceData, m *[]sdk.Location) error {
 	err := d.Set("locations", flattenLocations(*m))
 	if err != nil {
 		return err
@@ -93,7 +95,7 @@ func locationsToResource(d *schema.ResourceData, m *[]Location) error {
 	return nil
 }
 
-func flattenLocations(in []Location) []map[string]interface{} {
+func flattenLocations(in []sdk.Location) []map[string]interface{} {
 	var out = make([]map[string]interface{}, len(in), len(in))
 	for i, v := range in {
 		m := make(map[string]interface{})
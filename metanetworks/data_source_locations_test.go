@@ -0,0 +1,31 @@
+package metanetworks
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceLocations_basic(t *testing.T) {
+	dataSourceName := "data.metanetworks_locations.all"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceLocationsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "locations.0.name"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "locations.0.country"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "locations.0.latitude"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "locations.0.longitude"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceLocationsConfig = `
+data "metanetworks_locations" "all" {}
+`
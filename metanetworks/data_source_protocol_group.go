@@ -4,6 +4,8 @@ import (
 	"context"
 	"regexp"
 
+	"terraform-provider-metanetworks/sdk"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -87,15 +89,15 @@ func dataSourceProtocolGroup() *schema.Resource {
 }
 
 func dataSourceProtocolGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Client)
+	client := m.(sdk.API)
 
 	// // Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	var protocolGroups []ProtocolGroup
-	var filteredProtocolGroups []ProtocolGroup
+	var protocolGroups []sdk.ProtocolGroup
+	var filteredProtocolGroups []sdk.ProtocolGroup
 
-	protocolGroups, err := client.GetProtocolGroups()
+	protocolGroups, err := client.GetProtocolGroups(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
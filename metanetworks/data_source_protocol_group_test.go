@@ -0,0 +1,100 @@
+package metanetworks
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceProtocolGroup_basic(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_protocol_group.test"
+	dataSourceName := "data.metanetworks_protocol_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_protocol_group", testAccGetProtocolGroup),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProtocolGroupConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttr(dataSourceName, "protocols.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "protocols.0.proto", "tcp"),
+					resource.TestCheckResourceAttr(dataSourceName, "protocols.0.from_port", "8080"),
+					resource.TestCheckResourceAttr(dataSourceName, "read_only", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceProtocolGroup_builtin(t *testing.T) {
+	dataSourceName := "data.metanetworks_protocol_group.https"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProtocolGroupBuiltinConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "name", "HTTPS"),
+					resource.TestCheckResourceAttr(dataSourceName, "read_only", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceProtocolGroup_noMatch(t *testing.T) {
+	rName := testAccRandomName(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDataSourceProtocolGroupNoMatchConfig(rName),
+				ExpectError: regexp.MustCompile("Your query returned no results"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceProtocolGroupConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "metanetworks_protocol_group" "test" {
+  name = %[1]q
+
+  protocols {
+    proto     = "tcp"
+    from_port = 8080
+    to_port   = 8080
+  }
+}
+
+data "metanetworks_protocol_group" "test" {
+  name_regex = "^${metanetworks_protocol_group.test.name}$"
+}
+`, rName)
+}
+
+func testAccDataSourceProtocolGroupNoMatchConfig(rName string) string {
+	return fmt.Sprintf(`
+data "metanetworks_protocol_group" "test" {
+  name_regex = "^%[1]s$"
+}
+`, rName)
+}
+
+const testAccDataSourceProtocolGroupBuiltinConfig = `
+data "metanetworks_protocol_group" "https" {
+  name_regex = "^HTTPS$"
+}
+`
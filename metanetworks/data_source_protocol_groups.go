@@ -3,6 +3,8 @@ package metanetworks
 import (
 	"context"
 
+	"terraform-provider-metanetworks/sdk"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -25,13 +27,13 @@ func dataSourceProtocolGroups() *schema.Resource {
 }
 
 func dataSourceProtocolGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Client)
+	client := m.(sdk.API)
 
 	// // Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	var protocolGroups []ProtocolGroup
-	protocolGroups, err := client.GetProtocolGroups()
+	var protocolGroups []sdk.ProtocolGroup
+	protocolGroups, err := client.GetProtocolGroups(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
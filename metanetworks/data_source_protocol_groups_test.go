@@ -0,0 +1,29 @@
+package metanetworks
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceProtocolGroups_basic(t *testing.T) {
+	dataSourceName := "data.metanetworks_protocol_groups.all"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProtocolGroupsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "protocol_groups.0.name"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "protocol_groups.0.protocols.0.proto"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProtocolGroupsConfig = `
+data "metanetworks_protocol_groups" "all" {}
+`
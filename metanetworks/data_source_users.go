@@ -3,6 +3,8 @@ package metanetworks
 import (
 	"context"
 
+	"terraform-provider-metanetworks/sdk"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -104,15 +106,15 @@ func dataSourceUser() *schema.Resource {
 }
 
 func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*Client)
+	client := m.(sdk.API)
 
 	// // Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
 	email := d.Get("email").(string)
 
-	var user []User
-	user, err := client.GetUsers(email)
+	var user []sdk.User
+	user, err := client.GetUsers(ctx, email)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,36 @@
+package metanetworks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceUser_basic(t *testing.T) {
+	email := testAccUserEmail(t)
+	dataSourceName := "data.metanetworks_user.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceUserConfig(email),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "email", email),
+					resource.TestCheckResourceAttrSet(dataSourceName, "org_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceUserConfig(email string) string {
+	return fmt.Sprintf(`
+data "metanetworks_user" "test" {
+  email = %[1]q
+}
+`, email)
+}
@@ -0,0 +1,63 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// compositeID returns the ID of a resource relating a child, like a network
+// element or an alias, to its parent object: their IDs or names joined by an
+// underscore, like "mp-123_ne-456".
+func compositeID(parentID, child string) string {
+	return fmt.Sprintf("%s_%s", parentID, child)
+}
+
+// parseCompositeID splits an ID made by compositeID. The IDs of the API
+// don't contain underscores, the child is everything after the first one.
+// format describes the ID in the errors, like "<metaport_id>_<network_element_id>".
+func parseCompositeID(id, format string) (string, string, error) {
+	parts := strings.SplitN(id, "_", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid ID %q, expected %s", id, format)
+	}
+	return parts[0], parts[1], nil
+}
+
+// importCompositeID returns the importer of a resource with a composite ID.
+// It sets the parentKey and childKey attributes from the ID, once check
+// confirmed that the child belongs to the parent.
+func importCompositeID(parentKey, childKey string, check func(ctx context.Context, client sdk.API, parentID, child string) error) schema.StateContextFunc {
+	format := fmt.Sprintf("<%s>_<%s>", parentKey, childKey)
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		parentID, child, err := parseCompositeID(d.Id(), format)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := check(ctx, m.(sdk.API), parentID, child); err != nil {
+			return nil, err
+		}
+
+		d.Set(parentKey, parentID)
+		d.Set(childKey, child)
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// checkAlias checks that the network element networkElementID has alias.
+func checkAlias(ctx context.Context, client sdk.API, networkElementID, alias string) error {
+	networkElement, err := client.GetNetworkElement(ctx, networkElementID)
+	if err != nil {
+		return err
+	}
+
+	if !containsString(networkElement.Aliases, alias) {
+		return fmt.Errorf("Network element %s has no alias %q", networkElementID, alias)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package metanetworks
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// httpLogSubsystem is the tflog subsystem of the API client. Its level can
+	// be set apart with TF_LOG_PROVIDER_METANETWORKS_HTTP.
+	httpLogSubsystem string = "http"
+)
+
+// httpLogContextKey marks the contexts holding the logger of the HTTP
+// subsystem, added by withHTTPLogging.
+type httpLogContextKey struct{}
+
+// httpLogger logs the API requests of the client in the tflog subsystem of
+// the API client. The contexts of the resource and data source operations
+// get the subsystem logger once per operation from withHTTPLogging. The other
+// ones, like the context of the OAuth requests made when configuring the
+// provider, log to fallback instead.
+type httpLogger struct {
+	fallback context.Context
+}
+
+// newHTTPLogger returns an httpLogger whose fallback logs at the level of
+// TF_LOG, so that logging never fails.
+func newHTTPLogger() *httpLogger {
+	level := hclog.LevelFromString(os.Getenv("TF_LOG"))
+	if level == hclog.NoLevel {
+		level = hclog.Off
+	}
+	ctx := tfsdklog.NewRootProviderLogger(context.Background(), tfsdklog.WithLevel(level))
+
+	return &httpLogger{fallback: newHTTPLogContext(ctx)}
+}
+
+func (l *httpLogger) Debug(ctx context.Context, msg string, fields ...interface{}) {
+	tflog.SubsystemDebug(l.logContext(ctx), httpLogSubsystem, msg, fields...)
+}
+
+func (l *httpLogger) Trace(ctx context.Context, msg string, fields ...interface{}) {
+	tflog.SubsystemTrace(l.logContext(ctx), httpLogSubsystem, msg, fields...)
+}
+
+func (l *httpLogger) logContext(ctx context.Context) context.Context {
+	if ctx.Value(httpLogContextKey{}) != nil {
+		return ctx
+	}
+	return l.fallback
+}
+
+// newHTTPLogContext returns ctx with the logger of the HTTP subsystem, under
+// the provider logger of ctx. Terraform passes a provider logger in the context
+// of every operation, from the tf5server of terraform-plugin-go.
+func newHTTPLogContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, httpLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_METANETWORKS", httpLogSubsystem))
+	return context.WithValue(ctx, httpLogContextKey{}, true)
+}
+
+// withHTTPLogging adds the logger of the HTTP subsystem to the context of the
+// operations of the resources and data sources of provider. Terraform passes
+// a new provider logger in the context of every operation.
+func withHTTPLogging(provider *schema.Provider) {
+	for _, r := range provider.ResourcesMap {
+		resourceWithHTTPLogging(r)
+	}
+	for _, r := range provider.DataSourcesMap {
+		resourceWithHTTPLogging(r)
+	}
+}
+
+func resourceWithHTTPLogging(r *schema.Resource) {
+	r.CreateContext = operationWithHTTPLogging(r.CreateContext)
+	r.ReadContext = operationWithHTTPLogging(r.ReadContext)
+	r.UpdateContext = operationWithHTTPLogging(r.UpdateContext)
+	r.DeleteContext = operationWithHTTPLogging(r.DeleteContext)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importState := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			return importState(newHTTPLogContext(ctx), d, m)
+		}
+	}
+}
+
+func operationWithHTTPLogging(operation func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if operation == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return operation(newHTTPLogContext(ctx), d, m)
+	}
+}
+
+// sensitiveAttributes returns the names of the attributes marked sensitive in
+// the schemas of the provider, its resources and data sources.
+func sensitiveAttributes(provider *schema.Provider) []string {
+	var names []string
+	names = appendSensitiveAttributes(names, provider.Schema)
+	for _, r := range provider.ResourcesMap {
+		names = appendSensitiveAttributes(names, r.Schema)
+	}
+	for _, r := range provider.DataSourcesMap {
+		names = appendSensitiveAttributes(names, r.Schema)
+	}
+	return names
+}
+
+func appendSensitiveAttributes(names []string, schemaMap map[string]*schema.Schema) []string {
+	for name, s := range schemaMap {
+		if s.Sensitive {
+			names = append(names, name)
+		}
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			names = appendSensitiveAttributes(names, elem.Schema)
+		}
+	}
+	return names
+}
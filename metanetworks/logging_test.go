@@ -0,0 +1,59 @@
+package metanetworks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestWithHTTPLogging(t *testing.T) {
+	var contexts []context.Context
+	operation := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		contexts = append(contexts, ctx)
+		return nil
+	}
+	resource := &schema.Resource{
+		CreateContext: operation,
+		ReadContext:   operation,
+		UpdateContext: operation,
+		DeleteContext: operation,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				contexts = append(contexts, ctx)
+				return nil, nil
+			},
+		},
+	}
+	dataSource := &schema.Resource{ReadContext: operation}
+	withHTTPLogging(&schema.Provider{
+		ResourcesMap:   map[string]*schema.Resource{"metanetworks_test": resource},
+		DataSourcesMap: map[string]*schema.Resource{"metanetworks_test": dataSource},
+	})
+
+	// Terraform passes a provider logger to every operation
+	ctx := tfsdklog.NewRootProviderLogger(context.Background(), tfsdklog.WithLevel(hclog.Off))
+	resource.CreateContext(ctx, nil, nil)
+	resource.ReadContext(ctx, nil, nil)
+	resource.UpdateContext(ctx, nil, nil)
+	resource.DeleteContext(ctx, nil, nil)
+	resource.Importer.StateContext(ctx, nil, nil)
+	dataSource.ReadContext(ctx, nil, nil)
+
+	logger := newHTTPLogger()
+	if len(contexts) != 6 {
+		t.Fatalf("%d operations were called, want 6", len(contexts))
+	}
+	for i, ctx := range contexts {
+		if logger.logContext(ctx) != ctx {
+			t.Errorf("the context of operation %d has no HTTP logger", i)
+		}
+	}
+	if logger.logContext(ctx) != logger.fallback {
+		t.Errorf("the fallback logger isn't used outside of the operations")
+	}
+	logger.Debug(contexts[0], "API response")
+}
@@ -0,0 +1,140 @@
+package metanetworks
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// memberListResource is a resource owning the whole member list of a parent
+// object, like the mapped elements of a Metaport. Unlike the attachment
+// resources, which add a single member, it removes the members added outside
+// of Terraform. Its ID is the ID of the parent.
+type memberListResource struct {
+	// parentKey and listKey are the attributes holding the ID of the parent
+	// and the IDs of its members.
+	parentKey string
+	listKey   string
+	// description, parentDescription and listDescription document the
+	// resource and its attributes.
+	description       string
+	parentDescription string
+	listDescription   string
+
+	access func(client sdk.API) *memberListAPI
+}
+
+func resourceMemberList(r *memberListResource) *schema.Resource {
+	return &schema.Resource{
+		Description: r.description,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of this resource, which is the ID of its parent.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			r.parentKey: {
+				Description: r.parentDescription,
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			r.listKey: {
+				Description: r.listDescription + " Members added outside of Terraform are removed on the next apply, an empty or missing list removes all the members.",
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+			},
+		},
+		CreateContext: r.create,
+		ReadContext:   r.read,
+		UpdateContext: r.update,
+		DeleteContext: r.delete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+	}
+}
+
+func (r *memberListResource) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	parentID := d.Get(r.parentKey).(string)
+
+	err := r.set(ctx, m.(sdk.API), parentID, d, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(parentID)
+
+	return r.read(ctx, d, m)
+}
+
+func (r *memberListResource) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	access := r.access(m.(sdk.API))
+
+	current, err := access.get(ctx, d.Id())
+	if err != nil {
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing the %s of %s %q because it's gone", access.list, access.parent, d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.Set(r.parentKey, d.Id())
+	d.Set(r.listKey, current.members)
+
+	return nil
+}
+
+func (r *memberListResource) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange(r.listKey) {
+		err := r.set(ctx, m.(sdk.API), d.Id(), d, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return r.read(ctx, d, m)
+}
+
+func (r *memberListResource) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	access := r.access(m.(sdk.API))
+
+	metanetworksMutexKV.Lock(d.Id())
+	defer metanetworksMutexKV.Unlock(d.Id())
+
+	err := access.setMembers(ctx, d.Id(), nil, d.Timeout(schema.TimeoutDelete))
+	if err != nil && !sdk.IsNotFound(err) {
+		return diag.Errorf("Error removing the %s of %s %s: %s", access.list, access.parent, d.Id(), err)
+	}
+
+	return nil
+}
+
+// set replaces the members of parentID with those of the configuration and
+// waits for the reads to show them.
+func (r *memberListResource) set(ctx context.Context, client sdk.API, parentID string, d *schema.ResourceData, timeout time.Duration) error {
+	access := r.access(client)
+	members := resourceTypeSetToStringSlice(d.Get(r.listKey).(*schema.Set))
+
+	metanetworksMutexKV.Lock(parentID)
+	defer metanetworksMutexKV.Unlock(parentID)
+
+	err := access.setMembers(ctx, parentID, members, timeout)
+	if err != nil {
+		return err
+	}
+	return access.waitMembers(ctx, parentID, members, timeout)
+}
@@ -0,0 +1,164 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccMemberListResources tests each resource setting all the members of a
+// parent: members changed out of band show as drift and are reverted by the
+// next apply, the resource is imported by the ID of its parent, and deleting
+// it removes all the members of the parent.
+func TestAccMemberListResources(t *testing.T) {
+	for _, test := range []struct {
+		resourceType, parentType, parentKey, listKey string
+		access                                       func(client sdk.API) *memberListAPI
+	}{
+		{"metanetworks_metaport_mapped_elements", "metanetworks_metaport", "metaport_id", "mapped_elements", func(client sdk.API) *memberListAPI {
+			return metaportMappedElements(client)
+		}},
+		{"metanetworks_metaport_cluster_mapped_elements", "metanetworks_metaport_cluster", "metaport_cluster_id", "mapped_elements", func(client sdk.API) *memberListAPI {
+			return metaportClusterMappedElements(client)
+		}},
+		{"metanetworks_peering_peers", "metanetworks_peering", "peering_id", "peers", func(client sdk.API) *memberListAPI {
+			return peeringPeers(client)
+		}},
+		{"metanetworks_routing_group_mapped_elements", "metanetworks_routing_group", "routing_group_id", "mapped_elements", func(client sdk.API) *memberListAPI {
+			return routingGroupMappedElements(client)
+		}},
+	} {
+		test := test
+		t.Run(test.resourceType, func(t *testing.T) {
+			testAccMemberList(t, test.resourceType, test.parentType, test.parentKey, test.listKey, test.access)
+		})
+	}
+}
+
+func testAccMemberList(t *testing.T, resourceType, parentType, parentKey, listKey string, access func(client sdk.API) *memberListAPI) {
+	var parentID, memberID, extraID string
+	rName := testAccRandomName(t)
+	resourceName := resourceType + ".test"
+	parentName := parentType + ".test"
+	members := func(client sdk.API, ctx context.Context, id string) ([]string, error) {
+		list, err := access(client).get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return list.members, nil
+	}
+	parentConfig := testAccMappedSubnetsElementsConfig(rName, 3) + fmt.Sprintf(`
+resource %[1]q "test" {
+  name = %[2]q
+}
+`, parentType, rName)
+	config := func(count int) string {
+		ids := make([]string, count)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("metanetworks_mapped_subnets.test[%d].id", i)
+		}
+		return parentConfig + fmt.Sprintf(`
+resource %[1]q "test" {
+  %[2]s = %[3]s.test.id
+  %[4]s = [%[5]s]
+}
+`, resourceType, parentKey, parentType, listKey, strings.Join(ids, ", "))
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: testAccCheckDestroy(parentType, func(client sdk.API, ctx context.Context, id string) error {
+			_, err := members(client, ctx, id)
+			return err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: config(2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(resourceName, members, 2),
+					testAccCheckStoreID(parentName, &parentID),
+					testAccCheckStoreID("metanetworks_mapped_subnets.test.0", &memberID),
+					testAccCheckStoreID("metanetworks_mapped_subnets.test.2", &extraID),
+					resource.TestCheckResourceAttrPair(resourceName, "id", parentName, "id"),
+					resource.TestCheckResourceAttrPair(resourceName, parentKey, parentName, "id"),
+					resource.TestCheckResourceAttr(resourceName, listKey+".#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, listKey+".*", "metanetworks_mapped_subnets.test.0", "id"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, listKey+".*", "metanetworks_mapped_subnets.test.1", "id"),
+				),
+			},
+			// A member added out of band is drift, removed by the next apply
+			{
+				PreConfig: func() {
+					if err := access(testAccClient()).addMember(context.Background(), parentID, extraID, testAccDestroyTimeout); err != nil {
+						t.Fatalf("adding %s out of band: %s", extraID, err)
+					}
+				},
+				Config:             config(2),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(resourceName, members, 2),
+					resource.TestCheckResourceAttr(resourceName, listKey+".#", "2"),
+				),
+			},
+			// A member removed out of band is drift, added back by the next
+			// apply
+			{
+				PreConfig: func() {
+					if err := access(testAccClient()).removeMember(context.Background(), parentID, memberID, testAccDestroyTimeout); err != nil {
+						t.Fatalf("removing %s out of band: %s", memberID, err)
+					}
+				},
+				Config:             config(2),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(resourceName, members, 2),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, listKey+".*", "metanetworks_mapped_subnets.test.0", "id"),
+				),
+			},
+			// The resource is imported by the ID of its parent
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, err := testAccPrimary(s, parentName)
+					if err != nil {
+						return "", err
+					}
+					return rs.ID, nil
+				},
+				ImportStateVerify: true,
+			},
+			{
+				Config: config(0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(resourceName, members, 0),
+					resource.TestCheckResourceAttr(resourceName, listKey+".#", "0"),
+				),
+			},
+			// Deleting the resource removes all the members of its parent
+			{
+				Config: config(2),
+				Check:  testAccCheckMembers(resourceName, members, 2),
+			},
+			{
+				Config: parentConfig,
+				Check:  testAccCheckMembers(parentName, members, 0),
+			},
+		},
+	})
+}
@@ -0,0 +1,189 @@
+package metanetworks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// errMembersChanged is returned when a member list was changed by someone
+// else while it was being updated.
+var errMembersChanged = errors.New("changed concurrently")
+
+// memberList is the member list of a parent object, like the mapped elements
+// of a Metaport, and the version of the parent it was read from.
+type memberList struct {
+	members    []string
+	etag       string
+	modifiedAt string
+}
+
+// memberListAPI reads and replaces the member lists of a type of parent
+// object. The attachment resources use it to add or remove a single member,
+// the member list resources to replace the whole list.
+type memberListAPI struct {
+	// parent and list name the parent type and its list in errors.
+	parent string
+	list   string
+
+	get func(ctx context.Context, parentID string) (*memberList, error)
+	// set replaces the members of the parent. When ifMatch isn't empty it
+	// fails with a precondition error if the parent was changed since it was
+	// read with this ETag.
+	set func(ctx context.Context, parentID string, members []string, ifMatch string) error
+}
+
+// addMember adds member to the list of parentID. It fails if member is
+// already in the list.
+func (a *memberListAPI) addMember(ctx context.Context, parentID, member string, timeout time.Duration) error {
+	retry := false
+	return a.update(ctx, parentID, timeout, func(members []string) ([]string, error) {
+		defer func() { retry = true }()
+		if containsString(members, member) {
+			if retry {
+				// Added by an attempt whose response didn't show it
+				return nil, nil
+			}
+			return nil, fmt.Errorf("That network element is already mapped to this %s", a.parent)
+		}
+		return append(members, member), nil
+	})
+}
+
+// removeMember removes member from the list of parentID, if it is there.
+func (a *memberListAPI) removeMember(ctx context.Context, parentID, member string, timeout time.Duration) error {
+	return a.update(ctx, parentID, timeout, func(members []string) ([]string, error) {
+		for i := 0; i < len(members); i++ {
+			if members[i] == member {
+				return append(members[:i:i], members[i+1:]...), nil
+			}
+		}
+		return nil, nil
+	})
+}
+
+// setMembers replaces the list of parentID with members, in any order, with
+// a single write when it differs.
+func (a *memberListAPI) setMembers(ctx context.Context, parentID string, members []string, timeout time.Duration) error {
+	return a.update(ctx, parentID, timeout, func(current []string) ([]string, error) {
+		if sameStrings(current, members) {
+			return nil, nil
+		}
+		return append([]string{}, members...), nil
+	})
+}
+
+// waitMembers waits for the reads of parentID to return members, as the API
+// may take a while to show a write.
+func (a *memberListAPI) waitMembers(ctx context.Context, parentID string, members []string, timeout time.Duration) error {
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		current, err := a.get(ctx, parentID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if !sameStrings(current.members, members) {
+			return resource.RetryableError(fmt.Errorf("The %s of %s %s are %v, expected %v", a.list, a.parent, parentID, current.members, members))
+		}
+		return nil
+	})
+}
+
+// update replaces the list of parentID with the one returned by change, with
+// a read-modify-write. change returns nil when there is nothing to change.
+//
+// The metanetworksMutexKV lock only serializes the writes of this process,
+// so the write is made conditional on the version read, to not lose the
+// changes of other Terraform runs or of the admin portal. When the API
+// returns an ETag the write is sent with If-Match. Otherwise the parent is
+// read again right before writing and its modified_at compared, which narrows
+// the window for a lost update without closing it: a change landing between
+// that read and the write is overwritten, as the resource docs say. A
+// conflict restarts the read-modify-write, until timeout.
+func (a *memberListAPI) update(ctx context.Context, parentID string, timeout time.Duration, change func([]string) ([]string, error)) error {
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		current, err := a.get(ctx, parentID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		members, err := change(append([]string(nil), current.members...))
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if members == nil {
+			return nil
+		}
+
+		if current.etag == "" {
+			latest, err := a.get(ctx, parentID)
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+			if latest.modifiedAt != current.modifiedAt {
+				return resource.RetryableError(errMembersChanged)
+			}
+		}
+
+		err = a.set(ctx, parentID, members, current.etag)
+		if err != nil {
+			if sdk.IsPreconditionFailed(err) {
+				return resource.RetryableError(errMembersChanged)
+			}
+			if sdk.IsBusy(err) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+
+	if errors.Is(err, errMembersChanged) {
+		return fmt.Errorf("The %s of %s %s kept being changed concurrently, gave up updating them after %s", a.list, a.parent, parentID, timeout)
+	}
+	return err
+}
+
+// checkMember checks that member is in the list of parentID, for the
+// importers of the attachment resources.
+func (a *memberListAPI) checkMember(ctx context.Context, parentID, member string) error {
+	current, err := a.get(ctx, parentID)
+	if err != nil {
+		return err
+	}
+	if !containsString(current.members, member) {
+		return fmt.Errorf("%s is not in the %s of %s %s", member, a.list, a.parent, parentID)
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// sameStrings reports whether a and b hold the same strings, in any order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		if counts[s] == 0 {
+			return false
+		}
+		counts[s]--
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+package metanetworks
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-metanetworks/internal/fakeapi"
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestMemberListConflicts changes the mapped elements of a Metaport out of
+// band while they are being updated, with and without ETags.
+func TestMemberListConflicts(t *testing.T) {
+	for _, etags := range []bool{true, false} {
+		etags := etags
+		name := "modified_at"
+		if etags {
+			name = "etags"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			server := fakeapi.NewServer(&fakeapi.Options{ETags: etags})
+			defer server.Close()
+
+			client, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, &sdk.ClientOptions{
+				BaseURL: server.URL,
+			})
+			if err != nil {
+				t.Fatalf("NewClient: %s", err)
+			}
+			metaport, err := client.CreateMetaPort(ctx, &sdk.CreateMetaPortRequest{Name: "metaport", MappedElements: []string{"ne-1"}})
+			if err != nil {
+				t.Fatalf("CreateMetaPort: %s", err)
+			}
+
+			// Another writer adds an element after each read, the first
+			// time only, then every time
+			changes := 0
+			access := metaportMappedElements(client)
+			get := access.get
+			access.get = func(ctx context.Context, metaportID string) (*memberList, error) {
+				list, err := get(ctx, metaportID)
+				if changes == 0 || changes > 1 {
+					changes++
+					current, _ := server.API.Object("metaports", metaportID)
+					current["mapped_elements"] = append(current["mapped_elements"].([]interface{}), "ne-other")
+					server.API.PutObject("metaports", current)
+				}
+				return list, err
+			}
+
+			if err := access.addMember(ctx, metaport.ID, "ne-2", time.Minute); err != nil {
+				t.Fatalf("addMember: %s", err)
+			}
+			updated, err := client.GetMetaPort(ctx, metaport.ID)
+			if err != nil {
+				t.Fatalf("GetMetaPort: %s", err)
+			}
+			if want := []string{"ne-1", "ne-other", "ne-2"}; !sameStrings(updated.MappedElements, want) {
+				t.Errorf("mapped elements %v, want %v", updated.MappedElements, want)
+			}
+
+			changes = 2
+			err = access.removeMember(ctx, metaport.ID, "ne-1", 2*time.Second)
+			if err == nil || !strings.Contains(err.Error(), "kept being changed concurrently") {
+				t.Errorf("removeMember under constant changes returned %v", err)
+			}
+		})
+	}
+}
+
+// TestMemberListBusy retries the write of a member list while the API says
+// the parent is busy.
+func TestMemberListBusy(t *testing.T) {
+	ctx := context.Background()
+	server := fakeapi.NewServer(nil)
+	defer server.Close()
+
+	transport := &countingTransport{requests: make(map[string]int)}
+	client, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, &sdk.ClientOptions{
+		BaseURL:   server.URL,
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	metaport, err := client.CreateMetaPort(ctx, &sdk.CreateMetaPortRequest{Name: "metaport"})
+	if err != nil {
+		t.Fatalf("CreateMetaPort: %s", err)
+	}
+
+	server.API.FailNext(http.MethodPatch, "/v1/metaports/"+metaport.ID, http.StatusBadRequest, "Metaport is busy. Try again later.")
+	transport.requests = make(map[string]int)
+	if err := metaportMappedElements(client).addMember(ctx, metaport.ID, "ne-1", time.Minute); err != nil {
+		t.Fatalf("addMember: %s", err)
+	}
+	if transport.requests[http.MethodPatch] != 2 {
+		t.Errorf("addMember sent %d PATCH requests, want 2", transport.requests[http.MethodPatch])
+	}
+
+	server.API.FailNext(http.MethodPatch, "/v1/metaports/"+metaport.ID, http.StatusBadRequest, "Invalid mapped elements")
+	if err := metaportMappedElements(client).addMember(ctx, metaport.ID, "ne-2", time.Minute); err == nil {
+		t.Errorf("addMember succeeded after a validation error")
+	}
+}
+
+// countingTransport counts the requests sent by method.
+type countingTransport struct {
+	requests map[string]int
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.requests[r.Method]++
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// TestSetMembers replaces the mapped elements of a Metaport with a single
+// write, and none when they are unchanged.
+func TestSetMembers(t *testing.T) {
+	ctx := context.Background()
+	server := fakeapi.NewServer(nil)
+	defer server.Close()
+
+	transport := &countingTransport{requests: make(map[string]int)}
+	client, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, &sdk.ClientOptions{
+		BaseURL:   server.URL,
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	metaport, err := client.CreateMetaPort(ctx, &sdk.CreateMetaPortRequest{Name: "metaport", MappedElements: []string{"ne-1", "ne-2"}})
+	if err != nil {
+		t.Fatalf("CreateMetaPort: %s", err)
+	}
+
+	access := metaportMappedElements(client)
+	for _, test := range []struct {
+		members []string
+		patches int
+	}{
+		{[]string{"ne-3", "ne-2"}, 1},
+		{[]string{"ne-2", "ne-3"}, 0},
+		{nil, 1},
+	} {
+		transport.requests = make(map[string]int)
+		if err := access.setMembers(ctx, metaport.ID, test.members, time.Minute); err != nil {
+			t.Fatalf("setMembers(%v): %s", test.members, err)
+		}
+		if transport.requests[http.MethodPatch] != test.patches {
+			t.Errorf("setMembers(%v) sent %d PATCH requests, want %d", test.members, transport.requests[http.MethodPatch], test.patches)
+		}
+
+		updated, err := client.GetMetaPort(ctx, metaport.ID)
+		if err != nil {
+			t.Fatalf("GetMetaPort: %s", err)
+		}
+		if !sameStrings(updated.MappedElements, test.members) {
+			t.Errorf("mapped elements %v, want %v", updated.MappedElements, test.members)
+		}
+	}
+}
+
+// TestParentUpdateKeepsMembers renames a routing group and a peering whose
+// members were added out of band, and checks the members are kept.
+func TestParentUpdateKeepsMembers(t *testing.T) {
+	ctx := context.Background()
+	server := fakeapi.NewServer(&fakeapi.Options{})
+	defer server.Close()
+
+	client, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, &sdk.ClientOptions{
+		BaseURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	routingGroup, err := client.CreateRoutingGroup(ctx, &sdk.CreateRoutingGroupRequest{Name: "routing-group"})
+	if err != nil {
+		t.Fatalf("CreateRoutingGroup: %s", err)
+	}
+	peering, err := client.CreatePeering(ctx, &sdk.CreatePeeringRequest{Name: "peering"})
+	if err != nil {
+		t.Fatalf("CreatePeering: %s", err)
+	}
+
+	for _, test := range []struct {
+		collection string
+		id         string
+		listKey    string
+		resource   *schema.Resource
+	}{
+		{"routing_groups", routingGroup.ID, "mapped_elements_ids", resourceRoutingGroup()},
+		{"peerings", peering.ID, "peers", resourcePeering()},
+	} {
+		t.Run(test.collection, func(t *testing.T) {
+			// The state was read before the member was added
+			d := schema.TestResourceDataRaw(t, test.resource.Schema, map[string]interface{}{"name": "renamed"})
+			d.SetId(test.id)
+
+			current, _ := server.API.Object(test.collection, test.id)
+			current[test.listKey] = []interface{}{"ne-other"}
+			server.API.PutObject(test.collection, current)
+
+			if diags := test.resource.UpdateContext(ctx, d, client); diags.HasError() {
+				t.Fatalf("update: %v", diags)
+			}
+
+			updated, _ := server.API.Object(test.collection, test.id)
+			if updated["name"] != "renamed" {
+				t.Errorf("name %v, want renamed", updated["name"])
+			}
+			members, _ := updated[test.listKey].([]interface{})
+			if len(members) != 1 || members[0] != "ne-other" {
+				t.Errorf("%s %v, want [ne-other]", test.listKey, updated[test.listKey])
+			}
+			if got := d.Get(test.listKey).(*schema.Set).List(); len(got) != 1 || got[0] != "ne-other" {
+				t.Errorf("state %s %v, want [ne-other]", test.listKey, got)
+			}
+		})
+	}
+}
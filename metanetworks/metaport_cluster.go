@@ -1,80 +1,22 @@
 package metanetworks
 
 import (
-	"errors"
-	"log"
-	"reflect"
+	"context"
 	"time"
 
+	"terraform-provider-metanetworks/sdk"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 const (
-	metaportClustersEndpoint string = "/v1/metaport_clusters"
+	metaportClusterAttachmentCreateTimeout time.Duration = 5 * time.Minute
 )
 
-// Metaport Cluster ...
-type MetaportCluster struct {
-	CreatedAt      string   `json:"created_at,omitempty" meta_api:"read_only"`
-	Description    string   `json:"description"`
-	ID             string   `json:"id,omitempty" meta_api:"read_only"`
-	MappedElements []string `json:"mapped_elements,omitempty"`
-	Metaports      []string `json:"metaports,omitempty"`
-	ModifiedAt     string   `json:"modified_at,omitempty" meta_api:"read_only"`
-	Name           string   `json:"name"`
-}
-
-func (c *Client) GetMetaPortCluster(metaportClusterID string) (*MetaportCluster, error) {
-	var metaportCluster MetaportCluster
-	err := c.Read(metaportClustersEndpoint+"/"+metaportClusterID+"?expand=true", &metaportCluster)
-	if err != nil {
-		return nil, err
-	}
-
-	log.Printf("Returning Metaport Cluster from Get: %s", metaportCluster.ID)
-	return &metaportCluster, nil
-}
-
-func (c *Client) UpdateMetaPortCluster(metaportClusterID string, metaportCluster *MetaportCluster) (*MetaportCluster, error) {
-	resp, err := c.Update(metaportClustersEndpoint+"/"+metaportClusterID, *metaportCluster)
-	if err != nil {
-		return nil, err
-	}
-	updatedMetaportCluster, _ := resp.(*MetaportCluster)
-
-	log.Printf("Returning Metaport Cluster from Update: %s", updatedMetaportCluster.ID)
-	return updatedMetaportCluster, nil
-}
-
-func (c *Client) CreateMetaPortCluster(metaportCluster *MetaportCluster) (*MetaportCluster, error) {
-	resp, err := c.Create(metaportClustersEndpoint, *metaportCluster)
-	if err != nil {
-		return nil, err
-	}
-
-	createdMetaportCluster, ok := resp.(*MetaportCluster)
-	if !ok {
-		log.Printf("Returned Type is " + reflect.TypeOf(resp).Kind().String())
-		return nil, errors.New("Object returned from API was not a Metaport Cluster Pointer")
-	}
-
-	log.Printf("Returning Metaport Cluster from Create: %s", createdMetaportCluster.ID)
-	return createdMetaportCluster, nil
-}
-
-func (c *Client) DeleteMetaPortCluster(metaportClusterID string) error {
-	err := c.Delete(metaportClustersEndpoint + "/" + metaportClusterID)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func StatusMetaportClusterAttachmentCreate(client *Client, metaportClusterID string, elementID string) resource.StateRefreshFunc {
+func StatusMetaportClusterAttachmentCreate(ctx context.Context, client sdk.MetaPortClustersAPI, metaportClusterID string, elementID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		var metaportCluster *MetaportCluster
-		metaportCluster, err := client.GetMetaPortCluster(metaportClusterID)
+		var metaportCluster *sdk.MetaportCluster
+		metaportCluster, err := client.GetMetaPortCluster(ctx, metaportClusterID)
 		if err != nil {
 			return 0, "", err
 		}
@@ -88,20 +30,16 @@ func StatusMetaportClusterAttachmentCreate(client *Client, metaportClusterID str
 	}
 }
 
-func WaitMetaportClusterAttachmentCreate(client *Client, metaportClusterID string, elementID string) (*Client, error) {
+func WaitMetaportClusterAttachmentCreate(ctx context.Context, client sdk.MetaPortClustersAPI, metaportClusterID string, elementID string, timeout time.Duration) error {
 	createStateConf := &resource.StateChangeConf{
 		Pending:    []string{"Pending"},
 		Target:     []string{"Completed"},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		MinTimeout: 5 * time.Second,
 		Delay:      3 * time.Second,
-		Refresh:    StatusMetaportClusterAttachmentCreate(client, metaportClusterID, elementID),
-	}
-
-	_, err := createStateConf.WaitForState()
-	if err != nil {
-		return nil, err
+		Refresh:    StatusMetaportClusterAttachmentCreate(ctx, client, metaportClusterID, elementID),
 	}
 
-	return client, err
+	_, err := createStateConf.WaitForStateContext(ctx)
+	return err
 }
@@ -1,163 +1,135 @@
 package metanetworks
 
 import (
-	"encoding/json"
+	"context"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"terraform-provider-metanetworks/sdk"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/pkg/errors"
 )
 
 const (
-	networkElementsEndpoint string = "/v1/network_elements"
+	networkElementCreateTimeout      time.Duration = 30 * time.Second
+	networkElementAliasCreateTimeout time.Duration = 5 * time.Minute
 )
 
-// NetworkElement ...
-type NetworkElement struct {
-	Aliases       []string       `json:"aliases,omitempty" meta_api:"read_only"`
-	CreatedAt     string         `json:"created_at,omitempty" meta_api:"read_only"`
-	Description   string         `json:"description"`
-	DNSName       string         `json:"dns_name,omitempty" meta_api:"read_only"`
-	Enabled       *bool          `json:"enabled,omitempty"`
-	ExpiresAt     string         `json:"expires_at,omitempty" meta_api:"read_only"`
-	ID            string         `json:"id,omitempty" meta_api:"read_only"`
-	MappedService string         `json:"mapped_service,omitempty"`
-	MappedSubnets []string       `json:"mapped_subnets,omitempty"`
-	ModifiedAt    string         `json:"modified_at,omitempty" meta_api:"read_only"`
-	Name          string         `json:"name"`
-	NetID         int64          `json:"net_id,omitempty" meta_api:"read_only"`
-	OrgID         string         `json:"org_id,omitempty" meta_api:"read_only"`
-	OwnerID       string         `json:"owner_id,omitempty"`
-	Platform      string         `json:"platform,omitempty"`
-	Type          string         `json:"type,omitempty" meta_api:"read_only"`
-	MappedDomains []MappedDomain `json:"mapped_domains,omitempty"`
-	MappedHosts   []MappedHost   `json:"mapped_hosts,omitempty"`
-}
-
-// GetNetworkElement ...
-func (c *Client) GetNetworkElement(elementID string) (*NetworkElement, error) {
-	var networkElement NetworkElement
-	err := c.Read(networkElementsEndpoint+"/"+elementID+"?expand=true", &networkElement)
-	if err != nil {
-		return nil, err
-	}
+// The types of network elements, each managed by its own resource. The API
+// returns a type with the network elements, but its values aren't documented,
+// so the type is told from the fields set by each resource instead: a Mapped
+// Service has a mapped_service, a Mapped Subnet has mapped_subnets, a Device
+// has a platform and an owner_id, and a Native Service has none of them. The
+// names of the types are only used in the messages.
+const (
+	networkElementTypeDevice        string = "Device"
+	networkElementTypeMappedService string = "Mapped Service"
+	networkElementTypeMappedSubnets string = "Mapped Subnet"
+	networkElementTypeNativeService string = "Native Service"
+)
 
-	log.Printf("Returning Network Element from Get: %s", networkElement.MappedDomains)
-	return &networkElement, nil
+// networkElementResources are the resources managing each type of network
+// element.
+var networkElementResources = map[string]string{
+	networkElementTypeDevice:        "metanetworks_device",
+	networkElementTypeMappedService: "metanetworks_mapped_service",
+	networkElementTypeMappedSubnets: "metanetworks_mapped_subnets",
+	networkElementTypeNativeService: "metanetworks_native_service",
 }
 
-// UpdateNetworkElement ...
-func (c *Client) UpdateNetworkElement(networkElementID string, networkElement *NetworkElement) (*NetworkElement, error) {
-	resp, err := c.Update(networkElementsEndpoint+"/"+networkElementID, *networkElement)
-	if err != nil {
-		return nil, err
+// networkElementTypes returns the types whose fields networkElement has, a
+// single one unless it has the fields of several types, which none of the
+// resources would create.
+//
+// The value of the platform isn't checked: the devices of the platforms added
+// to the API after this version of the provider are devices still.
+func networkElementTypes(networkElement *sdk.NetworkElement) []string {
+	var types []string
+	if networkElement.MappedService != "" {
+		types = append(types, networkElementTypeMappedService)
 	}
-	updatedNetworkElement, _ := resp.(*NetworkElement)
-
-	log.Printf("Returning Network Element from Update: %s", updatedNetworkElement.ID)
-	return updatedNetworkElement, nil
-}
-
-// CreateNetworkElement ...
-func (c *Client) CreateNetworkElement(networkElement *NetworkElement) (*NetworkElement, error) {
-	resp, err := c.Create(networkElementsEndpoint, *networkElement)
-	if err != nil {
-		return nil, err
+	if len(networkElement.MappedSubnets) > 0 {
+		types = append(types, networkElementTypeMappedSubnets)
 	}
-
-	createdNetworkElement, ok := resp.(*NetworkElement)
-	if !ok {
-		return nil, errors.New("Object returned from API was not a Network Element Pointer")
+	if networkElement.Platform != "" || networkElement.OwnerID != "" {
+		types = append(types, networkElementTypeDevice)
 	}
-
-	log.Printf("Returning Network Element from Create: %s", createdNetworkElement.ID)
-	return createdNetworkElement, nil
-}
-
-// DeleteNetworkElement ...
-func (c *Client) DeleteNetworkElement(networkElementID string) error {
-	err := c.Delete(networkElementsEndpoint + "/" + networkElementID)
-	if err != nil {
-		return err
+	if len(types) == 0 {
+		types = append(types, networkElementTypeNativeService)
 	}
-
-	return nil
+	return types
 }
 
-// SetNetworkElementAlias ...
-func (c *Client) SetNetworkElementAlias(networkElementID string, alias string) (*NetworkElement, error) {
-	resp, err := c.Request(networkElementsEndpoint+"/"+networkElementID+"/aliases/"+alias, "PUT", nil, "")
-	if err != nil {
-		return nil, err
-	}
-	var networkElement NetworkElement
-	err = json.Unmarshal(resp, &networkElement)
-	if err != nil {
-		return nil, err
+// networkElementType returns the type of networkElement, and false with a
+// warning when it has the fields of several types rather than picking one.
+func networkElementType(networkElement *sdk.NetworkElement) (string, bool) {
+	types := networkElementTypes(networkElement)
+	if len(types) > 1 {
+		log.Printf("[WARN] The type of network element %s is unknown, it has the fields of a %s", networkElement.ID, strings.Join(types, " and of a "))
+		return "", false
 	}
-
-	return &networkElement, nil
+	return types[0], true
 }
 
-// DeleteNetworkElementAlias ...
-func (c *Client) DeleteNetworkElementAlias(networkElementID string, alias string) (*NetworkElement, error) {
-	resp, err := c.Request(networkElementsEndpoint+"/"+networkElementID+"/aliases/"+alias, "DELETE", nil, "")
-	if err != nil {
-		return nil, err
-	}
-	var networkElement NetworkElement
-	err = json.Unmarshal(resp, &networkElement)
-	if err != nil {
-		return nil, err
+// NetworkElementResource returns the type of the resource managing
+// networkElement, and false when the type of networkElement is unknown.
+func NetworkElementResource(networkElement *sdk.NetworkElement) (string, bool) {
+	elementType, ok := networkElementType(networkElement)
+	if !ok {
+		return "", false
 	}
-	return &networkElement, nil
+	return networkElementResources[elementType], true
 }
 
-// SetNetworkElementMappedDomains ...
-func (c *Client) SetNetworkElementMappedDomains(networkElementID string, name string, mappedDomain *MappedDomain) (*MappedDomain, error) {
-	resp, err := c.SetMappedDomain(networkElementsEndpoint+"/"+networkElementID+"/mapped_domains/"+name, *mappedDomain)
-	if err != nil {
-		return nil, err
+// checkNetworkElementType checks that networkElement is of the type managed by
+// the resource importing it. A network element of unknown type is imported
+// unchecked.
+func checkNetworkElementType(networkElement *sdk.NetworkElement, want string) error {
+	got, ok := networkElementType(networkElement)
+	if !ok || got == want {
+		return nil
 	}
 
-	log.Printf("Returning Network Element Mapped Domains from Create: %s", resp.Name)
-	return resp, nil
+	return fmt.Errorf("Network element %s is a %s, not a %s: import it into a %s resource instead", networkElement.ID, got, want, networkElementResources[got])
 }
 
-// DeleteNetworkElementMappedDomains ...
-func (c *Client) DeleteNetworkElementMappedDomains(networkElementID string, name string) error {
-	err := c.Delete(networkElementsEndpoint + "/" + networkElementID + "/mapped_domains/" + name)
-	if err != nil {
-		return err
+// checkNetworkElementTypeUnchanged checks that networkElement is still of the
+// type managed by the resource reading it. Its type may be changed outside of
+// Terraform, the resource would then manage its fields as if it was not. A
+// network element of unknown type is read unchecked.
+func checkNetworkElementTypeUnchanged(networkElement *sdk.NetworkElement, want string) error {
+	got, ok := networkElementType(networkElement)
+	if !ok || got == want {
+		return nil
 	}
 
-	return nil
+	return fmt.Errorf("Network element %s is now a %s, not a %s, its type was changed outside of Terraform: remove it from the state with `terraform state rm` and import it into a %s resource", networkElement.ID, got, want, networkElementResources[got])
 }
 
-func (c *Client) SetNetworkElementMappedHosts(networkElementID string, name string, mappedHost *MappedHost) (*MappedHost, error) {
-	resp, err := c.SetMappedHost(networkElementsEndpoint+"/"+networkElementID+"/mapped_hosts/"+name, *mappedHost)
-	if err != nil {
-		return nil, err
-	}
+// importNetworkElement returns the importer of the resource managing the
+// network elements of type want, which rejects the other network elements.
+func importNetworkElement(want string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		client := m.(sdk.API)
 
-	log.Printf("Returning Network Element Mapped Hosts from Create: %s", resp.Name)
-	return resp, nil
-}
+		networkElement, err := client.GetNetworkElement(ctx, d.Id())
+		if err != nil {
+			return nil, err
+		}
+		if err := checkNetworkElementType(networkElement, want); err != nil {
+			return nil, err
+		}
 
-// DeleteNetworkElementMappedDomains ...
-func (c *Client) DeleteNetworkElementMappedHosts(networkElementID string, name string) error {
-	err := c.Delete(networkElementsEndpoint + "/" + networkElementID + "/mapped_hosts/" + name)
-	if err != nil {
-		return err
+		return []*schema.ResourceData{d}, nil
 	}
-
-	return nil
 }
 
-// SetNetworkElementTags ...
-func (c *Client) SetNetworkElementTags(d *schema.ResourceData) error {
+// setNetworkElementTags sends the tags of the resource to the API when they
+// changed.
+func setNetworkElementTags(ctx context.Context, client sdk.NetworkElementsAPI, d *schema.ResourceData) error {
 	if d.HasChange("tags") {
 		tagsMapInterface := d.Get("tags").(map[string]interface{})
 		tagsMapString := make(map[string]string)
@@ -165,8 +137,7 @@ func (c *Client) SetNetworkElementTags(d *schema.ResourceData) error {
 			tagsMapString[key] = value.(string)
 		}
 
-		id := d.Id()
-		err := c.UpdateTags(networkElementsEndpoint+"/"+id+"/tags", tagsMapString)
+		err := client.SetNetworkElementTags(ctx, d.Id(), tagsMapString)
 		if err != nil {
 			return err
 		}
@@ -175,10 +146,27 @@ func (c *Client) SetNetworkElementTags(d *schema.ResourceData) error {
 	return nil
 }
 
-func StatusNetworkElementCreate(client *Client, networkElementID string) resource.StateRefreshFunc {
+// readNetworkElementTags sets the tags of the resource to the ones of
+// networkElement in the API, so that tags changed outside of Terraform show
+// up in the plan. They are read apart only when the API didn't return them
+// with the network element.
+func readNetworkElementTags(ctx context.Context, client sdk.NetworkElementsAPI, d *schema.ResourceData, networkElement *sdk.NetworkElement) error {
+	tags := map[string]string(networkElement.Tags)
+	if tags == nil {
+		var err error
+		tags, err = client.GetNetworkElementTags(ctx, d.Id())
+		if err != nil {
+			return err
+		}
+	}
+
+	return d.Set("tags", tags)
+}
+
+func StatusNetworkElementCreate(ctx context.Context, client sdk.NetworkElementsAPI, networkElementID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		var metaport *MetaPort
-		_, err := client.GetNetworkElement(networkElementID)
+		var metaport *sdk.MetaPort
+		_, err := client.GetNetworkElement(sdk.WithFreshReads(ctx), networkElementID)
 		if err != nil {
 			return metaport, "Pending", nil
 		}
@@ -186,28 +174,24 @@ func StatusNetworkElementCreate(client *Client, networkElementID string) resourc
 	}
 }
 
-func WaitNetworkElementCreate(client *Client, networkElementID string) (*Client, error) {
+func WaitNetworkElementCreate(ctx context.Context, client sdk.NetworkElementsAPI, networkElementID string, timeout time.Duration) error {
 	createStateConf := &resource.StateChangeConf{
 		Pending:    []string{"Pending"},
 		Target:     []string{"Completed"},
-		Timeout:    30 * time.Second,
+		Timeout:    timeout,
 		MinTimeout: 5 * time.Second,
 		Delay:      2 * time.Second,
-		Refresh:    StatusNetworkElementCreate(client, networkElementID),
+		Refresh:    StatusNetworkElementCreate(ctx, client, networkElementID),
 	}
 
-	_, err := createStateConf.WaitForState()
-	if err != nil {
-		return nil, err
-	}
-
-	return client, err
+	_, err := createStateConf.WaitForStateContext(ctx)
+	return err
 }
 
-func StatusNetworkElementAliasCreate(client *Client, networkElementID string, alias string) resource.StateRefreshFunc {
+func StatusNetworkElementAliasCreate(ctx context.Context, client sdk.NetworkElementsAPI, networkElementID string, alias string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		var networkElement *NetworkElement
-		networkElement, err := client.GetNetworkElement(networkElementID)
+		var networkElement *sdk.NetworkElement
+		networkElement, err := client.GetNetworkElement(sdk.WithFreshReads(ctx), networkElementID)
 		if err != nil {
 			return 0, "", err
 		}
@@ -222,20 +206,16 @@ func StatusNetworkElementAliasCreate(client *Client, networkElementID string, al
 	}
 }
 
-func WaitNetworkElementAliasCreate(client *Client, networkElementID string, alias string) (*Client, error) {
+func WaitNetworkElementAliasCreate(ctx context.Context, client sdk.NetworkElementsAPI, networkElementID string, alias string, timeout time.Duration) error {
 	createStateConf := &resource.StateChangeConf{
 		Pending:    []string{"Pending"},
 		Target:     []string{"Completed"},
-		Timeout:    5 * time.Minute,
+		Timeout:    timeout,
 		MinTimeout: 5 * time.Second,
 		Delay:      3 * time.Second,
-		Refresh:    StatusNetworkElementAliasCreate(client, networkElementID, alias),
-	}
-
-	_, err := createStateConf.WaitForState()
-	if err != nil {
-		return nil, err
+		Refresh:    StatusNetworkElementAliasCreate(ctx, client, networkElementID, alias),
 	}
 
-	return client, err
+	_, err := createStateConf.WaitForStateContext(ctx)
+	return err
 }
@@ -0,0 +1,184 @@
+package metanetworks
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"terraform-provider-metanetworks/internal/fakeapi"
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccNetworkElement_importWrongType imports each type of network element
+// into a resource managing another type.
+func TestAccNetworkElement_importWrongType(t *testing.T) {
+	email := testAccUserEmail(t)
+	rName := testAccRandomName(t)
+	config := testAccDeviceConfig(rName+"-device", email, "first", true, "blue") +
+		testAccMappedServiceConfig(rName+"-service", "first", "app.internal", "blue") +
+		testAccMappedSubnetsConfig(rName+"-subnets", "first", `["10.70.0.0/24"]`, "blue") +
+		testAccNativeServiceConfig(rName+"-native", "first", true, "blue")
+
+	steps := []resource.TestStep{{Config: config}}
+	for _, step := range []struct {
+		into, from, message string
+	}{
+		{"metanetworks_device.test", "metanetworks_mapped_subnets.test", `is a Mapped Subnet, not a Device: import it into a metanetworks_mapped_subnets resource instead`},
+		{"metanetworks_mapped_service.test", "metanetworks_native_service.test", `is a Native Service, not a Mapped Service: import it into a metanetworks_native_service resource instead`},
+		{"metanetworks_mapped_subnets.test", "metanetworks_device.test", `is a Device, not a Mapped Subnet: import it into a metanetworks_device resource instead`},
+		{"metanetworks_native_service.test", "metanetworks_mapped_service.test", `is a Mapped Service, not a Native Service: import it into a metanetworks_mapped_service resource instead`},
+	} {
+		from := step.from
+		steps = append(steps, resource.TestStep{
+			ResourceName: step.into,
+			ImportState:  true,
+			ImportStateIdFunc: func(s *terraform.State) (string, error) {
+				rs, err := testAccPrimary(s, from)
+				if err != nil {
+					return "", err
+				}
+				return rs.ID, nil
+			},
+			ExpectError: regexp.MustCompile(step.message),
+		})
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckDestroy("metanetworks_device", testAccGetNetworkElement),
+			testAccCheckDestroy("metanetworks_mapped_service", testAccGetNetworkElement),
+			testAccCheckDestroy("metanetworks_mapped_subnets", testAccGetNetworkElement),
+			testAccCheckDestroy("metanetworks_native_service", testAccGetNetworkElement),
+		),
+		Steps: steps,
+	})
+}
+
+// TestAccNetworkElement_typeChanged changes the fields of the network element
+// of a mapped service out of band, which only the fake API allows.
+func TestAccNetworkElement_typeChanged(t *testing.T) {
+	if testAccFakeAPI == nil {
+		t.Skip("The type of a network element can only be changed in the fake API")
+	}
+
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_mapped_service.test"
+	config := testAccMappedServiceConfig(rName, "first", "app.internal", "blue")
+
+	setFields := func(mappedService string, mappedSubnets ...interface{}) {
+		networkElement, _ := testAccFakeAPI.Object("network_elements", id)
+		networkElement["mapped_service"] = mappedService
+		networkElement["mapped_subnets"] = mappedSubnets
+		testAccFakeAPI.PutObject("network_elements", networkElement)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_mapped_service", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  testAccCheckStoreID(resourceName, &id),
+			},
+			{
+				PreConfig:   func() { setFields("", "10.70.0.0/24") },
+				Config:      config,
+				ExpectError: regexp.MustCompile("is now a Mapped Subnet, not a Mapped Service, its type was changed outside of Terraform"),
+			},
+			// With the fields of both types, the type is unknown and not
+			// checked
+			{
+				PreConfig: func() { setFields("app.internal", "10.70.0.0/24") },
+				Config:    config,
+				Check:     resource.TestCheckResourceAttr(resourceName, "mapped_service", "app.internal"),
+			},
+			{
+				PreConfig: func() { setFields("app.internal") },
+				Config:    config,
+				Check:     resource.TestCheckResourceAttr(resourceName, "mapped_service", "app.internal"),
+			},
+		},
+	})
+}
+
+func TestNetworkElementType(t *testing.T) {
+	for _, test := range []struct {
+		name           string
+		networkElement sdk.NetworkElement
+		want           string
+	}{
+		{"mapped service", sdk.NetworkElement{MappedService: "app.internal"}, networkElementTypeMappedService},
+		{"mapped subnets", sdk.NetworkElement{MappedSubnets: []string{"10.0.0.0/24"}}, networkElementTypeMappedSubnets},
+		{"device", sdk.NetworkElement{OwnerID: "usr-1", Platform: "Linux"}, networkElementTypeDevice},
+		{"device of a new platform", sdk.NetworkElement{OwnerID: "usr-1", Platform: "Plan 9"}, networkElementTypeDevice},
+		{"native service", sdk.NetworkElement{}, networkElementTypeNativeService},
+		{"type returned by the API", sdk.NetworkElement{Type: "Mapped Subnet"}, networkElementTypeNativeService},
+		{"fields of several types", sdk.NetworkElement{MappedService: "app.internal", MappedSubnets: []string{"10.0.0.0/24"}}, ""},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := networkElementType(&test.networkElement)
+			if got != test.want || ok != (test.want != "") {
+				t.Errorf("networkElementType = %q, %t, want %q", got, ok, test.want)
+			}
+		})
+	}
+}
+
+// TestReadNetworkElementTags reads the tags of a device from the network
+// element when the API returns them with it, and apart otherwise.
+func TestReadNetworkElementTags(t *testing.T) {
+	ctx := context.Background()
+	server := fakeapi.NewServer(nil)
+	defer server.Close()
+
+	transport := &countingTransport{requests: make(map[string]int)}
+	client, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, &sdk.ClientOptions{
+		BaseURL:   server.URL,
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	device, err := client.CreateNetworkElement(ctx, &sdk.CreateNetworkElementRequest{Name: "device", OwnerID: "usr-1", Platform: "Linux"})
+	if err != nil {
+		t.Fatalf("CreateNetworkElement: %s", err)
+	}
+	if err := client.SetNetworkElementTags(ctx, device.ID, map[string]string{"team": "blue"}); err != nil {
+		t.Fatalf("SetNetworkElementTags: %s", err)
+	}
+
+	r := resourceDevice()
+	d := r.Data(nil)
+	d.SetId(device.ID)
+	transport.requests = make(map[string]int)
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if transport.requests["GET"] != 1 {
+		t.Errorf("read sent %d GET requests, want 1", transport.requests["GET"])
+	}
+	if tags := d.Get("tags").(map[string]interface{}); len(tags) != 1 || tags["team"] != "blue" {
+		t.Errorf("tags %v, want team = blue", tags)
+	}
+
+	// Without the tags in the network element, they are read apart
+	d = r.Data(nil)
+	d.SetId(device.ID)
+	transport.requests = make(map[string]int)
+	if err := readNetworkElementTags(ctx, client, d, &sdk.NetworkElement{ID: device.ID}); err != nil {
+		t.Fatalf("readNetworkElementTags: %s", err)
+	}
+	if transport.requests["GET"] != 1 {
+		t.Errorf("readNetworkElementTags sent %d GET requests, want 1", transport.requests["GET"])
+	}
+	if tags := d.Get("tags").(map[string]interface{}); len(tags) != 1 || tags["team"] != "blue" {
+		t.Errorf("tags %v, want team = blue", tags)
+	}
+}
@@ -1,42 +1,21 @@
 package metanetworks
 
 import (
-	"errors"
-	"fmt"
-	"log"
-	"reflect"
+	"context"
 	"strconv"
 	"time"
 
+	"terraform-provider-metanetworks/sdk"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
-	protocolGroupsEndpoint string = "/v1/protocol_groups"
+	protocolGroupCreateTimeout time.Duration = 30 * time.Second
 )
 
-// ProtocolGroup ...
-type ProtocolGroup struct {
-	Description string     `json:"description"`
-	Name        string     `json:"name"`
-	Protocols   []Protocol `json:"protocols,omitempty"`
-	CreatedAt   string     `json:"created_at,omitempty"`
-	ID          string     `json:"id,omitempty"`
-	ModifiedAt  string     `json:"modified_at,omitempty"`
-	OrgID       string     `json:"org_id,omitempty"`
-	ReadOnly    bool       `json:"read_only,omitempty"`
-}
-
-// Protocol ...
-type Protocol struct {
-	FromPort int64  `json:"from_port" type:"integer"`
-	Port     int64  `json:"port" type:"integer"`
-	Protocol string `json:"proto"`
-	ToPort   int64  `json:"to_port" type:"integer"`
-}
-
-func flattenProtocolGroup(pg ProtocolGroup) map[string]interface{} {
+func flattenProtocolGroup(pg sdk.ProtocolGroup) map[string]interface{} {
 	out := make(map[string]interface{})
 	protocols := make([]map[string]interface{}, len(pg.Protocols), len(pg.Protocols))
 
@@ -59,7 +38,7 @@ func flattenProtocolGroup(pg ProtocolGroup) map[string]interface{} {
 	return out
 }
 
-func flattenProtocolGroups(pg []ProtocolGroup) []map[string]interface{} {
+func flattenProtocolGroups(pg []sdk.ProtocolGroup) []map[string]interface{} {
 	var out = make([]map[string]interface{}, len(pg), len(pg))
 	for i, v := range pg {
 		out[i] = flattenProtocolGroup(v)
@@ -67,7 +46,7 @@ func flattenProtocolGroups(pg []ProtocolGroup) []map[string]interface{} {
 	return out
 }
 
-func ProtocolGroupToResource(d *schema.ResourceData, m *ProtocolGroup) error {
+func ProtocolGroupToResource(d *schema.ResourceData, m *sdk.ProtocolGroup) error {
 	flattenedPG := flattenProtocolGroup(*m)
 
 	for key, val := range flattenedPG {
@@ -83,7 +62,7 @@ func ProtocolGroupToResource(d *schema.ResourceData, m *ProtocolGroup) error {
 }
 
 // protocolGroupToResource ...
-func ProtocolGroupsToResource(d *schema.ResourceData, m *[]ProtocolGroup) error {
+func ProtocolGroupsToResource(d *schema.ResourceData, m *[]sdk.ProtocolGroup) error {
 	err := d.Set("protocol_groups", flattenProtocolGroups(*m))
 
 	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
@@ -91,76 +70,10 @@ func ProtocolGroupsToResource(d *schema.ResourceData, m *[]ProtocolGroup) error
 	return err
 }
 
-func (c *Client) GetProtocolGroups() ([]ProtocolGroup, error) {
-	var protocolGroups []ProtocolGroup
-	err := c.Read(protocolGroupsEndpoint, &protocolGroups)
-	if err != nil {
-
-		return nil, err
-	}
-
-	if len(protocolGroups) == 0 {
-		return nil, fmt.Errorf("Protocol Groups Not found")
-	}
-
-	return protocolGroups, nil
-}
-
-// GetProtocolGroup ...
-func (c *Client) GetProtocolGroup(protocolGroupID string) (*ProtocolGroup, error) {
-	var protocolGroup ProtocolGroup
-	err := c.Read(protocolGroupsEndpoint+"/"+protocolGroupID, &protocolGroup)
-	if err != nil {
-		return nil, err
-	}
-
-	log.Printf("Returning ProtocolGroup from Get: %s", protocolGroup)
-	return &protocolGroup, nil
-}
-
-// UpdateProtocolGroup ...
-func (c *Client) UpdateProtocolGroup(protocolGroupID string, protocolGroup *ProtocolGroup) (*ProtocolGroup, error) {
-	resp, err := c.Update(protocolGroupsEndpoint+"/"+protocolGroupID, *protocolGroup)
-	if err != nil {
-		return nil, err
-	}
-	updatedProtocolGroup, _ := resp.(*ProtocolGroup)
-
-	log.Printf("Returning ProtocolGroup from Update: %s", updatedProtocolGroup.ID)
-	return updatedProtocolGroup, nil
-}
-
-// CreateProtocolGroup ...
-func (c *Client) CreateProtocolGroup(protocolGroup *ProtocolGroup) (*ProtocolGroup, error) {
-	resp, err := c.Create(protocolGroupsEndpoint, *protocolGroup)
-	if err != nil {
-		return nil, err
-	}
-
-	createdProtocolGroup, ok := resp.(*ProtocolGroup)
-	if !ok {
-		log.Printf("Returned Type is " + reflect.TypeOf(resp).Kind().String())
-		return nil, errors.New("Object returned from API was not a ProtocolGroup Pointer")
-	}
-
-	log.Printf("Returning ProtocolGroup from Create: %s", createdProtocolGroup.ID)
-	return createdProtocolGroup, nil
-}
-
-// DeleteProtocolGroup ...
-func (c *Client) DeleteProtocolGroup(protocolGroupID string) error {
-	err := c.Delete(protocolGroupsEndpoint + "/" + protocolGroupID)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func StatusProtocolGroupCreate(client *Client, ProtocolGroupID string) resource.StateRefreshFunc {
+func StatusProtocolGroupCreate(ctx context.Context, client sdk.ProtocolGroupsAPI, ProtocolGroupID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		var metaport *MetaPort
-		_, err := client.GetProtocolGroup(ProtocolGroupID)
+		var metaport *sdk.MetaPort
+		_, err := client.GetProtocolGroup(ctx, ProtocolGroupID)
 		if err != nil {
 			return metaport, "Pending", nil
 		}
@@ -168,20 +81,16 @@ func StatusProtocolGroupCreate(client *Client, ProtocolGroupID string) resource.
 	}
 }
 
-func WaitProtocolGroupCreate(client *Client, ProtocolGroupID string) (*Client, error) {
+func WaitProtocolGroupCreate(ctx context.Context, client sdk.ProtocolGroupsAPI, ProtocolGroupID string, timeout time.Duration) error {
 	createStateConf := &resource.StateChangeConf{
 		Pending:    []string{"Pending"},
 		Target:     []string{"Completed"},
-		Timeout:    30 * time.Second,
+		Timeout:    timeout,
 		MinTimeout: 5 * time.Second,
 		Delay:      2 * time.Second,
-		Refresh:    StatusProtocolGroupCreate(client, ProtocolGroupID),
-	}
-
-	_, err := createStateConf.WaitForState()
-	if err != nil {
-		return nil, err
+		Refresh:    StatusProtocolGroupCreate(ctx, client, ProtocolGroupID),
 	}
 
-	return client, err
+	_, err := createStateConf.WaitForStateContext(ctx)
+	return err
 }
@@ -2,13 +2,30 @@ package metanetworks
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"terraform-provider-metanetworks/sdk"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/mutexkv"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// Version is the version of the provider, sent in the User-Agent header of
+// the API requests. Release builds set it from main.
+var Version = "dev"
+
 // Provider returns a terraform.ResourceProvider.
 func Provider() *schema.Provider {
+	return newProvider(nil)
+}
+
+// newProvider returns the provider, sending the API requests through
+// transport unless it's nil.
+func newProvider(transport http.RoundTripper) *schema.Provider {
 
 	// The actual provider
 	provider := &schema.Provider{
@@ -32,6 +49,114 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_ORG", nil),
 				Optional:    true,
 			},
+			"credential_process": {
+				Description: "Command printing the credentials as a json object with keys `api_key`, `api_secret` and optionally `org`, for example to fetch them from a vault. The command is run without a shell, and again whenever the token can no longer be refreshed. Can be specified with the `METANETWORKS_CREDENTIAL_PROCESS` environment variable.",
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_CREDENTIAL_PROCESS", nil),
+				Optional:    true,
+			},
+			"profile": {
+				Description: "Name of the profile to read from the credentials file. Can be specified with the `METANETWORKS_PROFILE` environment variable. Defaults to `default`.",
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_PROFILE", sdk.DefaultProfile),
+				Optional:    true,
+			},
+			"credentials_file": {
+				Description: "Path to the credentials file. Can be specified with the `METANETWORKS_CREDENTIALS_FILE` environment variable. Defaults to `$HOME/.metanetworks/credentials.json`.",
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_CREDENTIALS_FILE", nil),
+				Optional:    true,
+			},
+			"endpoint": {
+				Description: "The base URL of the Meta Networks API. Can be specified with the `METANETWORKS_ENDPOINT` environment variable. Defaults to `https://api.nsof.io`.",
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_ENDPOINT", sdk.DefaultBaseURL),
+				Optional:    true,
+			},
+			"oauth_endpoint": {
+				Description: "The URL of the OAuth token endpoint. Can be specified with the `METANETWORKS_OAUTH_ENDPOINT` environment variable. Defaults to `<endpoint>/v1/oauth/token`.",
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_OAUTH_ENDPOINT", nil),
+				Optional:    true,
+			},
+			"proxy_url": {
+				Description: "URL of the HTTP(S) proxy used to reach the API. Can be specified with the `METANETWORKS_PROXY_URL` environment variable. Defaults to the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_PROXY_URL", nil),
+				Optional:    true,
+			},
+			"ca_bundle_file": {
+				Description: "Path to a PEM file of additional certificate authorities to trust. Can be specified with the `METANETWORKS_CA_BUNDLE_FILE` environment variable.",
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_CA_BUNDLE_FILE", nil),
+				Optional:    true,
+			},
+			"client_certificate_file": {
+				Description: "Path to a PEM client certificate for mutual TLS. Requires `client_key_file`. Can be specified with the `METANETWORKS_CLIENT_CERTIFICATE_FILE` environment variable.",
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_CLIENT_CERTIFICATE_FILE", nil),
+				Optional:    true,
+			},
+			"client_key_file": {
+				Description: "Path to the PEM private key of `client_certificate_file`. Can be specified with the `METANETWORKS_CLIENT_KEY_FILE` environment variable.",
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_CLIENT_KEY_FILE", nil),
+				Optional:    true,
+			},
+			"request_timeout": {
+				Description: "Timeout in seconds of a single API request. Can be specified with the `METANETWORKS_REQUEST_TIMEOUT` environment variable. Defaults to `60`.",
+				Type:        schema.TypeInt,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_REQUEST_TIMEOUT", sdk.DefaultRequestTimeout),
+				Optional:    true,
+			},
+			"max_retries": {
+				Description: "Maximum number of times a failed API request is retried. Requests are retried on `429` and `5xx` responses and on connection errors, non-idempotent requests only when they never reached the API. Can be specified with the `METANETWORKS_MAX_RETRIES` environment variable. Defaults to `4`.",
+				Type:        schema.TypeInt,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_MAX_RETRIES", sdk.DefaultMaxRetries),
+				Optional:    true,
+			},
+			"max_retry_wait": {
+				Description: "Maximum number of seconds to wait between two retries. If the API asks to wait longer with a `Retry-After` header, the request is not retried. Can be specified with the `METANETWORKS_MAX_RETRY_WAIT` environment variable. Defaults to `30`.",
+				Type:        schema.TypeInt,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_MAX_RETRY_WAIT", sdk.DefaultMaxRetryWait),
+				Optional:    true,
+			},
+			"max_list_items": {
+				Description: "Maximum number of items read from a list, like the users or groups of the organization. The lists the API returns in pages are read page by page, reading a longer list fails. Can be specified with the `METANETWORKS_MAX_LIST_ITEMS` environment variable. Defaults to `50000`.",
+				Type:        schema.TypeInt,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_MAX_LIST_ITEMS", sdk.DefaultMaxListItems),
+				Optional:    true,
+			},
+			"rate_limit": {
+				Description: "Maximum number of API requests per second, shared by all resources. Can be specified with the `METANETWORKS_RATE_LIMIT` environment variable. Defaults to `0`, no limit.",
+				Type:        schema.TypeFloat,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_RATE_LIMIT", 0),
+				Optional:    true,
+			},
+			"rate_limit_burst": {
+				Description: "Number of API requests that can be sent at once above `rate_limit`. Can be specified with the `METANETWORKS_RATE_LIMIT_BURST` environment variable. Defaults to `1`.",
+				Type:        schema.TypeInt,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_RATE_LIMIT_BURST", 1),
+				Optional:    true,
+			},
+			"max_concurrent_requests": {
+				Description: "Maximum number of API requests in flight at the same time. Can be specified with the `METANETWORKS_MAX_CONCURRENT_REQUESTS` environment variable. Defaults to `0`, no limit.",
+				Type:        schema.TypeInt,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_MAX_CONCURRENT_REQUESTS", 0),
+				Optional:    true,
+			},
+			"user_agent_suffix": {
+				Description: "Text appended to the User-Agent header of the API requests, for example to identify a pipeline. Can be specified with the `METANETWORKS_USER_AGENT_SUFFIX` environment variable.",
+				Type:        schema.TypeString,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_USER_AGENT_SUFFIX", nil),
+				Optional:    true,
+			},
+			"read_cache_ttl": {
+				Description: "Number of seconds the network elements read from the API are cached, shared by the resources refreshing them. Can be specified with the `METANETWORKS_READ_CACHE_TTL` environment variable. Defaults to `0`, no cache.",
+				Type:        schema.TypeInt,
+				DefaultFunc: schema.EnvDefaultFunc("METANETWORKS_READ_CACHE_TTL", 0),
+				Optional:    true,
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"metanetworks_group":           dataSourceGroup(),
@@ -41,35 +166,41 @@ func Provider() *schema.Provider {
 			"metanetworks_protocol_group":  dataSourceProtocolGroup(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"metanetworks_egress_route":                 resourceEgressRoute(),
-			"metanetworks_group":                        resourceGroup(),
-			"metanetworks_device_alias":                 resourceDeviceAlias(),
-			"metanetworks_device":                       resourceDevice(),
-			"metanetworks_mapped_service_alias":         resourceMappedServiceAlias(),
-			"metanetworks_mapped_service":               resourceMappedService(),
-			"metanetworks_mapped_subnets_mapped_domain": resourceMappedSubnetsMappedDomain(),
-			"metanetworks_mapped_subnets_mapped_host":   resourceMappedSubnetsMappedHost(),
-			"metanetworks_mapped_subnets":               resourceMappedSubnets(),
-			"metanetworks_metaport_attachment":          resourceMetaportAttachment(),
-			"metanetworks_metaport_otac":                resourceMetaportOTAC(),
-			"metanetworks_metaport":                     resourceMetaport(),
-			"metanetworks_metaport_cluster":             resourceMetaportCluster(),
-			"metanetworks_metaport_cluster_attachment":  resourceMetaportClusterAttachment(),
-			"metanetworks_native_service_alias":         resourceNativeServiceAlias(),
-			"metanetworks_native_service":               resourceNativeService(),
-			"metanetworks_peering_attachment":           resourcePeeringAttachment(),
-			"metanetworks_peering":                      resourcePeering(),
-			"metanetworks_policy":                       resourcePolicy(),
-			"metanetworks_protocol_group":               resourceProtocolGroup(),
-			"metanetworks_routing_group_attachment":     resourceRoutingGroupAttachment(),
-			"metanetworks_routing_group":                resourceRoutingGroup(),
-			"metanetworks_posture_check":                resourcePostureCheck(),
-			"metanetworks_swg_content_categories":       resourceSwgContentCategories(),
-			"metanetworks_swg_threat_categories":        resourceSwgThreatCategories(),
-			"metanetworks_swg_url_filtering_rules":      resourceSwgUrlFilteringRules(),
+			"metanetworks_egress_route":                     resourceEgressRoute(),
+			"metanetworks_group":                            resourceGroup(),
+			"metanetworks_device_alias":                     resourceDeviceAlias(),
+			"metanetworks_device":                           resourceDevice(),
+			"metanetworks_mapped_service_alias":             resourceMappedServiceAlias(),
+			"metanetworks_mapped_service":                   resourceMappedService(),
+			"metanetworks_mapped_subnets_mapped_domain":     resourceMappedSubnetsMappedDomain(),
+			"metanetworks_mapped_subnets_mapped_host":       resourceMappedSubnetsMappedHost(),
+			"metanetworks_mapped_subnets":                   resourceMappedSubnets(),
+			"metanetworks_metaport_attachment":              resourceMetaportAttachment(),
+			"metanetworks_metaport_mapped_elements":         resourceMetaportMappedElements(),
+			"metanetworks_metaport_otac":                    resourceMetaportOTAC(),
+			"metanetworks_metaport":                         resourceMetaport(),
+			"metanetworks_metaport_cluster":                 resourceMetaportCluster(),
+			"metanetworks_metaport_cluster_attachment":      resourceMetaportClusterAttachment(),
+			"metanetworks_metaport_cluster_mapped_elements": resourceMetaportClusterMappedElements(),
+			"metanetworks_native_service_alias":             resourceNativeServiceAlias(),
+			"metanetworks_native_service":                   resourceNativeService(),
+			"metanetworks_peering_attachment":               resourcePeeringAttachment(),
+			"metanetworks_peering":                          resourcePeering(),
+			"metanetworks_peering_peers":                    resourcePeeringPeers(),
+			"metanetworks_policy":                           resourcePolicy(),
+			"metanetworks_protocol_group":                   resourceProtocolGroup(),
+			"metanetworks_routing_group_attachment":         resourceRoutingGroupAttachment(),
+			"metanetworks_routing_group":                    resourceRoutingGroup(),
+			"metanetworks_routing_group_mapped_elements":    resourceRoutingGroupMappedElements(),
+			"metanetworks_posture_check":                    resourcePostureCheck(),
+			"metanetworks_swg_content_categories":           resourceSwgContentCategories(),
+			"metanetworks_swg_threat_categories":            resourceSwgThreatCategories(),
+			"metanetworks_swg_url_filtering_rules":          resourceSwgUrlFilteringRules(),
 		},
 	}
 
+	withHTTPLogging(provider)
+
 	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
@@ -77,41 +208,91 @@ func Provider() *schema.Provider {
 			// We can therefore assume that if it's missing it's 0.10 or 0.11
 			terraformVersion = "0.11+compatible"
 		}
-		return providerConfigure(d, terraformVersion)
+		client, err := providerConfigure(d, terraformVersion, transport)
+		if err != nil {
+			return nil, err
+		}
+
+		// Keep the values of sensitive attributes out of the logs
+		client.AddRedactedFields(sensitiveAttributes(provider)...)
+
+		return client, nil
 	}
 
 	return provider
 }
 
-func providerConfigure(d *schema.ResourceData, terraformVersion string) (interface{}, error) {
+// providerConfigure returns the client of the API for the provider
+// configuration. Resources use it through the interfaces of sdk.API.
+func providerConfigure(d *schema.ResourceData, terraformVersion string, transport http.RoundTripper) (*sdk.Client, error) {
 	apiKey, haveAPIKey := d.GetOk("api_key")
 	apiSecret, haveAPISecret := d.GetOk("api_secret")
 	org, haveOrg := d.GetOk("org")
 
-	var client *Client
+	options := &sdk.ClientOptions{
+		BaseURL:        d.Get("endpoint").(string),
+		OAuthURL:       d.Get("oauth_endpoint").(string),
+		ProxyURL:       d.Get("proxy_url").(string),
+		CABundleFile:   d.Get("ca_bundle_file").(string),
+		ClientCertFile: d.Get("client_certificate_file").(string),
+		ClientKeyFile:  d.Get("client_key_file").(string),
+		RequestTimeout: time.Duration(d.Get("request_timeout").(int)) * time.Second,
+
+		RateLimit:             d.Get("rate_limit").(float64),
+		RateBurst:             d.Get("rate_limit_burst").(int),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+
+		ReadCacheTTL: time.Duration(d.Get("read_cache_ttl").(int)) * time.Second,
+
+		UserAgent: userAgent(terraformVersion, d.Get("user_agent_suffix").(string)),
+		Transport: transport,
+		Logger:    newHTTPLogger(),
+	}
+
+	var client *sdk.Client
 	var err error
 
+	credentialProcess, haveCredentialProcess := d.GetOk("credential_process")
+
+	switch {
+	// The org may complete the credentials printed by the credential process
+	case haveCredentialProcess && !haveAPIKey && !haveAPISecret:
+		client, err = sdk.NewClientFromProcess(credentialProcess.(string), org.(string), options)
 	// If one is set
-	if haveAPIKey || haveAPISecret || haveOrg {
+	case haveAPIKey || haveAPISecret || haveOrg:
 		// but not all are set
 		if !(haveAPIKey && haveAPISecret && haveOrg) {
-			return nil, errors.New("Please provide an api_key, api_secret and org. Alternatively provide a configuration file and none of these parameters")
-		}
-		{
-			client, err = NewClient(apiKey.(string), apiSecret.(string), org.(string))
+			return nil, errors.New("Please provide an api_key, api_secret and org. Alternatively provide a credential_process or a configuration file and none of these parameters")
 		}
-	} else {
-		client, err = NewClientFromConfig()
+		client, err = sdk.NewClient(apiKey.(string), apiSecret.(string), org.(string), options)
+	default:
+		client, err = sdk.NewClientFromConfig(d.Get("credentials_file").(string), d.Get("profile").(string), options)
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	client.terraformVersion = terraformVersion
+	client.MaxRetries = d.Get("max_retries").(int)
+	client.MaxRetryWait = time.Duration(d.Get("max_retry_wait").(int)) * time.Second
+	client.MaxListItems = d.Get("max_list_items").(int)
 
 	return client, nil
 }
 
+// userAgent returns the User-Agent header of the API requests, naming the
+// versions of the provider, Terraform and Go, followed by suffix.
+func userAgent(terraformVersion, suffix string) string {
+	userAgent := fmt.Sprintf("terraform-provider-metanetworks/%s terraform/%s go/%s", Version, terraformVersion, strings.TrimPrefix(runtime.Version(), "go"))
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
+// defaultTimeout bounds the operations that do not wait for the API to settle,
+// like the default of the plugin SDK.
+const defaultTimeout = 20 * time.Minute
+
 // This is a global MutexKV for use within this plugin.
 var metanetworksMutexKV = mutexkv.NewMutexKV()
@@ -0,0 +1,431 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"terraform-provider-metanetworks/internal/cassette"
+	"terraform-provider-metanetworks/internal/fakeapi"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const (
+	// testAccDestroyTimeout is how long the destroy checks wait for deleted
+	// objects to be gone from the API, which is eventually consistent.
+	testAccDestroyTimeout time.Duration = time.Minute
+
+	// testAccFakeUserEmail is the email of the user created in the fake API.
+	testAccFakeUserEmail string = "tf-acc-test@example.com"
+)
+
+var (
+	testAccProvider          *schema.Provider
+	testAccProviderFactories map[string]func() (*schema.Provider, error)
+
+	// testAccFakeAPI is the fake API the acceptance tests run against when no
+	// endpoint is set, nil otherwise.
+	testAccFakeAPI *fakeapi.API
+
+	// testAccCassette records the API requests of the acceptance tests, or
+	// replays them, when METANETWORKS_CASSETTE is set, nil otherwise.
+	testAccCassette *cassette.Recorder
+)
+
+func init() {
+	testAccProvider = Provider()
+	testAccProviderFactories = map[string]func() (*schema.Provider, error){
+		"metanetworks": func() (*schema.Provider, error) {
+			return testAccProvider, nil
+		},
+	}
+}
+
+// TestMain runs the acceptance tests against the fake API unless
+// METANETWORKS_ENDPOINT sets the API to test. METANETWORKS_CASSETTE records
+// the API requests into the cassette METANETWORKS_CASSETTE_FILE, or replays
+// them from it without any API. Cassettes are only recorded against a real
+// tenant: replaying requests recorded against the fake API would only test
+// the fake.
+func TestMain(m *testing.M) {
+	if os.Getenv(resource.TestEnvVar) == "" {
+		os.Exit(m.Run())
+	}
+
+	if name := os.Getenv("METANETWORKS_CASSETTE"); name != "" {
+		mode, err := cassette.ParseMode(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		path := os.Getenv("METANETWORKS_CASSETTE_FILE")
+		if path == "" {
+			fmt.Fprintln(os.Stderr, "METANETWORKS_CASSETTE_FILE must be set to the cassette to record to or replay from")
+			os.Exit(1)
+		}
+		if mode == cassette.ModeRecord && os.Getenv("METANETWORKS_ENDPOINT") == "" {
+			fmt.Fprintln(os.Stderr, "METANETWORKS_ENDPOINT must be set to record a cassette, they are recorded against a real tenant")
+			os.Exit(1)
+		}
+
+		testAccCassette, err = cassette.New(path, mode, nil)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		testAccProvider = newProvider(testAccCassette)
+	}
+
+	var server *fakeapi.Server
+	switch {
+	case testAccReplaying():
+		// The credentials are scrubbed from the cassette, any will do
+		if os.Getenv("METANETWORKS_API_KEY") == "" {
+			os.Setenv("METANETWORKS_API_KEY", fakeapi.DefaultAPIKey)
+			os.Setenv("METANETWORKS_API_SECRET", fakeapi.DefaultAPISecret)
+			os.Setenv("METANETWORKS_ORG", fakeapi.DefaultOrg)
+		}
+	case os.Getenv("METANETWORKS_ENDPOINT") == "":
+		server = fakeapi.NewServer(nil)
+		testAccFakeAPI = server.API
+
+		os.Setenv("METANETWORKS_ENDPOINT", server.URL)
+		os.Setenv("METANETWORKS_API_KEY", fakeapi.DefaultAPIKey)
+		os.Setenv("METANETWORKS_API_SECRET", fakeapi.DefaultAPISecret)
+		os.Setenv("METANETWORKS_ORG", fakeapi.DefaultOrg)
+	}
+
+	code := m.Run()
+	if server != nil {
+		server.Close()
+	}
+	if testAccCassette != nil {
+		if err := testAccCassette.Save(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	goVersion := strings.TrimPrefix(runtime.Version(), "go")
+	want := "terraform-provider-metanetworks/dev terraform/1.0.11 go/" + goVersion
+	if got := userAgent("1.0.11", ""); got != want {
+		t.Errorf("userAgent without suffix returned %q, want %q", got, want)
+	}
+	if got := userAgent("1.0.11", " ci-pipeline/42 "); got != want+" ci-pipeline/42" {
+		t.Errorf("userAgent with a suffix returned %q", got)
+	}
+}
+
+func testAccPreCheck(t *testing.T) {
+	if testAccFakeAPI != nil || testAccReplaying() {
+		return
+	}
+
+	for _, name := range []string{"METANETWORKS_API_KEY", "METANETWORKS_CREDENTIAL_PROCESS", "METANETWORKS_PROFILE", "METANETWORKS_CREDENTIALS_FILE"} {
+		if os.Getenv(name) != "" {
+			return
+		}
+	}
+	t.Fatal("METANETWORKS_API_KEY, METANETWORKS_CREDENTIAL_PROCESS, METANETWORKS_PROFILE or METANETWORKS_CREDENTIALS_FILE must be set for acceptance tests against METANETWORKS_ENDPOINT")
+}
+
+// testAccReplaying reports whether the API requests are replayed from a
+// cassette.
+func testAccReplaying() bool {
+	return testAccCassette != nil && testAccCassette.Mode() == cassette.ModeReplay
+}
+
+// testAccSkipReplay skips the tests whose API requests race with each other,
+// like attachments to the same object, as the order they were recorded in
+// can't be replayed.
+func testAccSkipReplay(t *testing.T) {
+	if testAccReplaying() {
+		t.Skip("Requests racing with each other can't be replayed from a cassette")
+	}
+}
+
+// testAccRandomName returns a unique name for the objects of a test. The
+// names are derived from the test name with cassettes, for the requests to
+// match when replayed.
+func testAccRandomName(t *testing.T) string {
+	if testAccCassette == nil {
+		return acctest.RandomWithPrefix("tf-acc-test")
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, t.Name())
+	return "tf-acc-test-" + name
+}
+
+// testAccClient returns the client of the provider configured by the last
+// step.
+func testAccClient() sdk.API {
+	return testAccProvider.Meta().(sdk.API)
+}
+
+// testAccGetFunc gets the object with id from the API.
+type testAccGetFunc func(client sdk.API, ctx context.Context, id string) error
+
+// testAccDeleteFunc deletes the object with id from the API, its signature
+// matches the Delete methods of the Client, like sdk.API.DeletePolicy.
+type testAccDeleteFunc func(client sdk.API, ctx context.Context, id string) error
+
+// testAccCheckExists checks that the object of the resource name exists in
+// the API.
+func testAccCheckExists(name string, get testAccGetFunc) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testAccPrimary(s, name)
+		if err != nil {
+			return err
+		}
+
+		if err := get(testAccClient(), context.Background(), rs.ID); err != nil {
+			return fmt.Errorf("%s %s does not exist: %s", name, rs.ID, err)
+		}
+		return nil
+	}
+}
+
+// testAccCheckDestroy checks that the objects of the resources of
+// resourceType are gone from the API.
+func testAccCheckDestroy(resourceType string, get testAccGetFunc) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		ctx := context.Background()
+		client := testAccClient()
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			err := resource.RetryContext(ctx, testAccDestroyTimeout, func() *resource.RetryError {
+				err := get(client, ctx, rs.Primary.ID)
+				if err == nil {
+					return resource.RetryableError(fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID))
+				}
+				if !sdk.IsNotFound(err) {
+					return resource.NonRetryableError(err)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// testAccCheckDisappears deletes the object of the resource name out of
+// band, so that the next plan recreates it.
+func testAccCheckDisappears(name string, del testAccDeleteFunc) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testAccPrimary(s, name)
+		if err != nil {
+			return err
+		}
+
+		return del(testAccClient(), context.Background(), rs.ID)
+	}
+}
+
+// testAccCheckNetworkElementTagsChanged replaces the tags of the network
+// element of the resource name out of band, so that the next plan sets them
+// back.
+func testAccCheckNetworkElementTagsChanged(name string, tags map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testAccPrimary(s, name)
+		if err != nil {
+			return err
+		}
+
+		return testAccClient().SetNetworkElementTags(context.Background(), rs.ID, tags)
+	}
+}
+
+// testAccCheckStoreID saves the id of the resource name into id.
+func testAccCheckStoreID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testAccPrimary(s, name)
+		if err != nil {
+			return err
+		}
+
+		*id = rs.ID
+		return nil
+	}
+}
+
+// testAccCheckIDChanged checks whether the resource name was replaced since
+// its id was stored into id.
+func testAccCheckIDChanged(name string, id *string, changed bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testAccPrimary(s, name)
+		if err != nil {
+			return err
+		}
+
+		if changed && rs.ID == *id {
+			return fmt.Errorf("%s was updated in place, expected a replacement", name)
+		}
+		if !changed && rs.ID != *id {
+			return fmt.Errorf("%s was replaced (%s to %s), expected an update in place", name, *id, rs.ID)
+		}
+		return nil
+	}
+}
+
+// testAccMembersFunc gets the members of the parent object with id from the
+// API, like the mapped elements of a Metaport.
+type testAccMembersFunc func(client sdk.API, ctx context.Context, id string) ([]string, error)
+
+// testAccCheckMembers checks that the parent object of the resource name has
+// count members in the API.
+func testAccCheckMembers(name string, members testAccMembersFunc, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testAccPrimary(s, name)
+		if err != nil {
+			return err
+		}
+
+		ids, err := members(testAccClient(), context.Background(), rs.ID)
+		if err != nil {
+			return err
+		}
+		if len(ids) != count {
+			return fmt.Errorf("%s %s has %d members %v, expected %d", name, rs.ID, len(ids), ids, count)
+		}
+		return nil
+	}
+}
+
+// testAccGetMember returns a testAccGetFunc for attachments with an id of the
+// form <parent id>_<member id>, which fails with a not found error when the
+// member is missing from the parent.
+func testAccGetMember(members testAccMembersFunc) testAccGetFunc {
+	return func(client sdk.API, ctx context.Context, id string) error {
+		parentID, memberID, err := testAccSplitID(id)
+		if err != nil {
+			return err
+		}
+
+		ids, err := members(client, ctx, parentID)
+		if err != nil {
+			return err
+		}
+		for _, i := range ids {
+			if i == memberID {
+				return nil
+			}
+		}
+		return testAccNotFound("%s is not a member of %s", memberID, parentID)
+	}
+}
+
+// testAccRemoveMember returns members without id.
+func testAccRemoveMember(members []string, id string) []string {
+	var kept []string
+	for _, member := range members {
+		if member != id {
+			kept = append(kept, member)
+		}
+	}
+	return kept
+}
+
+// testAccSplitID splits ids of the form <parent id>_<child>. Only the child,
+// like an alias, may hold underscores.
+func testAccSplitID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "_", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("unexpected id %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// testAccNotFound returns an error for which IsNotFound is true, for objects
+// that are not returned by the API on their own.
+func testAccNotFound(format string, a ...interface{}) error {
+	return &sdk.ApiError{
+		StatusCode: http.StatusNotFound,
+		Err:        fmt.Errorf(format, a...),
+	}
+}
+
+func testAccPrimary(s *terraform.State, name string) (*terraform.InstanceState, error) {
+	rs, ok := s.RootModule().Resources[name]
+	if !ok {
+		return nil, fmt.Errorf("Not found: %s", name)
+	}
+	if rs.Primary == nil || rs.Primary.ID == "" {
+		return nil, fmt.Errorf("No ID is set for %s", name)
+	}
+	return rs.Primary, nil
+}
+
+// testAccImportStateIDFunc returns the import ID of the resource name whose
+// ID is only its child part, made of the parentKey attribute and that ID.
+func testAccImportStateIDFunc(name, parentKey string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, err := testAccPrimary(s, name)
+		if err != nil {
+			return "", err
+		}
+		return compositeID(rs.Attributes[parentKey], rs.ID), nil
+	}
+}
+
+// testAccUserEmail returns the email of an existing user, set by
+// METANETWORKS_TEST_USER_EMAIL, as users can't be created by the provider. The
+// user is created in the fake API, and assumed to have been when replaying a
+// cassette.
+func testAccUserEmail(t *testing.T) string {
+	if os.Getenv(resource.TestEnvVar) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.TestEnvVar)
+	}
+	if email := os.Getenv("METANETWORKS_TEST_USER_EMAIL"); email != "" {
+		return email
+	}
+	if testAccReplaying() {
+		return testAccFakeUserEmail
+	}
+	if testAccFakeAPI == nil {
+		t.Skip("METANETWORKS_TEST_USER_EMAIL must be set to the email of a user")
+	}
+
+	email := testAccFakeUserEmail
+	testAccFakeAPI.PutObject("users", map[string]interface{}{
+		"id":          "usr-tf-acc-test",
+		"email":       email,
+		"given_name":  "Terraform",
+		"family_name": "Acceptance",
+		"enabled":     true,
+	})
+	return email
+}
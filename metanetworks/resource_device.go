@@ -1,6 +1,12 @@
 package metanetworks
 
 import (
+	"context"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -76,18 +82,18 @@ func resourceDevice() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourceDeviceCreate,
-		Read:   resourceDeviceRead,
-		Update: resourceDeviceUpdate,
-		Delete: resourceDeviceDelete,
+		CreateContext: resourceDeviceCreate,
+		ReadContext:   resourceDeviceRead,
+		UpdateContext: resourceDeviceUpdate,
+		DeleteContext: resourceDeviceDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importNetworkElement(networkElementTypeDevice),
 		},
 	}
 }
 
-func resourceDeviceCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceDeviceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -95,52 +101,64 @@ func resourceDeviceCreate(d *schema.ResourceData, m interface{}) error {
 	ownerID := d.Get("owner_id").(string)
 	platform := d.Get("platform").(string)
 
-	networkElement := NetworkElement{
+	networkElement := sdk.CreateNetworkElementRequest{
 		Name:        name,
 		Description: description,
 		Enabled:     &enabled,
 		OwnerID:     ownerID,
 		Platform:    platform,
 	}
-	var newDevice *NetworkElement
-	newDevice, err := client.CreateNetworkElement(&networkElement)
+	var newDevice *sdk.NetworkElement
+	newDevice, err := client.CreateNetworkElement(ctx, &networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(newDevice.ID)
 
 	err = deviceToResource(d, newDevice)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	err = client.SetNetworkElementTags(d)
+	err = setNetworkElementTags(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceDeviceRead(d, m)
+	return resourceDeviceRead(ctx, d, m)
 }
 
-func resourceDeviceRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	networkElement, err := client.GetNetworkElement(d.Id())
+	networkElement, err := client.GetNetworkElement(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Device %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
+	err = checkNetworkElementTypeUnchanged(networkElement, networkElementTypeDevice)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	err = deviceToResource(d, networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	err = readNetworkElementTags(ctx, client, d, networkElement)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceDeviceUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceDeviceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -148,43 +166,43 @@ func resourceDeviceUpdate(d *schema.ResourceData, m interface{}) error {
 	ownerID := d.Get("owner_id").(string)
 	platform := d.Get("platform").(string)
 
-	networkElement := NetworkElement{
-		Name:        name,
-		Description: description,
+	networkElement := sdk.UpdateNetworkElementRequest{
+		Name:        &name,
+		Description: &description,
 		Enabled:     &enabled,
-		OwnerID:     ownerID,
-		Platform:    platform,
+		OwnerID:     &ownerID,
+		Platform:    &platform,
 	}
-	var updatedDevice *NetworkElement
-	updatedDevice, err := client.UpdateNetworkElement(d.Id(), &networkElement)
+	var updatedDevice *sdk.NetworkElement
+	updatedDevice, err := client.UpdateNetworkElement(ctx, d.Id(), &networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = deviceToResource(d, updatedDevice)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	err = client.SetNetworkElementTags(d)
+	err = setNetworkElementTags(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceDeviceRead(d, m)
+	return resourceDeviceRead(ctx, d, m)
 }
 
-func resourceDeviceDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteNetworkElement(d.Id())
+	err := client.DeleteNetworkElement(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func deviceToResource(d *schema.ResourceData, m *NetworkElement) error {
+func deviceToResource(d *schema.ResourceData, m *sdk.NetworkElement) error {
 	d.Set("name", m.Name)
 	d.Set("description", m.Description)
 	d.Set("enabled", m.Enabled)
@@ -1,9 +1,12 @@
 package metanetworks
 
 import (
-	"errors"
-	"fmt"
+	"context"
+	"log"
 
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -28,60 +31,87 @@ func resourceDeviceAlias() *schema.Resource {
 				ForceNew:    true,
 			},
 		},
-		Create: resourceDeviceAliasCreate,
-		Read:   resourceDeviceAliasRead,
-		Delete: resourceDeviceAliasDelete,
+		CreateContext: resourceDeviceAliasCreate,
+		ReadContext:   resourceDeviceAliasRead,
+		DeleteContext: resourceDeviceAliasDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importCompositeID("device_id", "alias", checkAlias),
+		},
 	}
 }
 
-func resourceDeviceAliasCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceDeviceAliasCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	deviceID := d.Get("device_id").(string)
 	alias := d.Get("alias").(string)
 
-	var networkElement *NetworkElement
-	networkElement, err := client.GetNetworkElement(deviceID)
+	var networkElement *sdk.NetworkElement
+	networkElement, err := client.GetNetworkElement(ctx, deviceID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	for i := 0; i < len(networkElement.Aliases); i++ {
 		if networkElement.Aliases[i] == alias {
-			return errors.New("That is alias is already present on the Native Service")
+			return diag.Errorf("That is alias is already present on the Native Service")
 		}
 	}
 
-	_, err = client.SetNetworkElementAlias(deviceID, alias)
+	_, err = client.SetNetworkElementAlias(ctx, deviceID, alias)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	d.SetId(fmt.Sprintf("%s_%s", deviceID, alias))
+	d.SetId(compositeID(deviceID, alias))
 
-	return resourceDeviceAliasRead(d, m)
+	return resourceDeviceAliasRead(ctx, d, m)
 }
 
-func resourceDeviceAliasRead(d *schema.ResourceData, m interface{}) error {
+func resourceDeviceAliasRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
+
+	deviceID := d.Get("device_id").(string)
+	alias := d.Get("alias").(string)
+
+	var networkElement *sdk.NetworkElement
+	networkElement, err := client.GetNetworkElement(ctx, deviceID)
+	if err != nil {
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Device Alias %q because device %q no longer exists", d.Id(), deviceID)
+			d.SetId("")
+
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	for i := 0; i < len(networkElement.Aliases); i++ {
+		if networkElement.Aliases[i] == alias {
+			return nil
+		}
+	}
+	log.Printf("[WARN] Removing Device Alias %q because it's gone", d.Id())
+	d.SetId("")
 	return nil
 }
 
-func resourceDeviceAliasDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceDeviceAliasDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	deviceID := d.Get("device_id").(string)
 	alias := d.Get("alias").(string)
-	var networkElement *NetworkElement
-	networkElement, err := client.GetNetworkElement(deviceID)
+	var networkElement *sdk.NetworkElement
+	networkElement, err := client.GetNetworkElement(ctx, deviceID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	for i := 0; i < len(networkElement.Aliases); i++ {
 		if networkElement.Aliases[i] == alias {
-			_, err = client.DeleteNetworkElementAlias(deviceID, alias)
+			_, err = client.DeleteNetworkElementAlias(ctx, deviceID, alias)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
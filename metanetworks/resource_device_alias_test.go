@@ -0,0 +1,119 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDeviceAlias_basic(t *testing.T) {
+	var id string
+	email := testAccUserEmail(t)
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_device_alias.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_device_alias", testAccGetNetworkElementAlias),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceAliasConfig(rName, email, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElementAlias),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttrPair(resourceName, "device_id", "metanetworks_device.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "alias", "first."+rName+".example.com"),
+				),
+			},
+			{
+				Config: testAccDeviceAliasConfig(rName, email, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElementAlias),
+					testAccCheckIDChanged(resourceName, &id, true),
+					resource.TestCheckResourceAttr(resourceName, "alias", "second."+rName+".example.com"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccDeviceAlias_disappears(t *testing.T) {
+	email := testAccUserEmail(t)
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_device_alias.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_device_alias", testAccGetNetworkElementAlias),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceAliasConfig(rName, email, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElementAlias),
+					testAccCheckDisappears(resourceName, testAccDeleteNetworkElementAlias),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccGetNetworkElementAlias checks that the alias with an id of the form
+// <network element id>_<alias> is set on its network element.
+func testAccGetNetworkElementAlias(client sdk.API, ctx context.Context, id string) error {
+	elementID, alias, err := testAccSplitID(id)
+	if err != nil {
+		return err
+	}
+
+	networkElement, err := client.GetNetworkElement(ctx, elementID)
+	if err != nil {
+		return err
+	}
+	for _, a := range networkElement.Aliases {
+		if a == alias {
+			return nil
+		}
+	}
+	return testAccNotFound("%s is not an alias of %s", alias, elementID)
+}
+
+func testAccDeleteNetworkElementAlias(client sdk.API, ctx context.Context, id string) error {
+	elementID, alias, err := testAccSplitID(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.DeleteNetworkElementAlias(ctx, elementID, alias)
+	return err
+}
+
+func testAccDeviceAliasConfig(rName, email, prefix string) string {
+	return fmt.Sprintf(`
+data "metanetworks_user" "test" {
+  email = %[3]q
+}
+
+resource "metanetworks_device" "test" {
+  name     = %[1]q
+  owner_id = data.metanetworks_user.test.id
+  platform = "Linux"
+}
+
+resource "metanetworks_device_alias" "test" {
+  device_id = metanetworks_device.test.id
+  alias     = "%[2]s.%[1]s.example.com"
+}
+`, rName, prefix, email)
+}
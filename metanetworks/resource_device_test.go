@@ -0,0 +1,124 @@
+package metanetworks
+
+import (
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDevice_basic(t *testing.T) {
+	var id string
+	email := testAccUserEmail(t)
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_device.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_device", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceConfig(rName, email, "first", true, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "platform", "Linux"),
+					resource.TestCheckResourceAttrPair(resourceName, "owner_id", "data.metanetworks_user.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "blue"),
+					resource.TestCheckResourceAttrSet(resourceName, "dns_name"),
+				),
+			},
+			{
+				Config: testAccDeviceConfig(rName, email, "second", false, "green"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "green"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccDevice_tagsDrift(t *testing.T) {
+	email := testAccUserEmail(t)
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_device.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_device", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceConfig(rName, email, "first", true, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckNetworkElementTagsChanged(resourceName, map[string]string{"color": "red", "owner": "portal"}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccDeviceConfig(rName, email, "first", true, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "blue"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDevice_disappears(t *testing.T) {
+	email := testAccUserEmail(t)
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_device.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_device", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceConfig(rName, email, "first", true, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteNetworkElement),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccDeviceConfig(rName, email, description string, enabled bool, color string) string {
+	return fmt.Sprintf(`
+data "metanetworks_user" "test" {
+  email = %[2]q
+}
+
+resource "metanetworks_device" "test" {
+  name        = %[1]q
+  description = %[3]q
+  enabled     = %[4]t
+  owner_id    = data.metanetworks_user.test.id
+  platform    = "Linux"
+
+  tags = {
+    color = %[5]q
+  }
+}
+`, rName, email, description, enabled, color)
+}
@@ -1,6 +1,12 @@
 package metanetworks
 
 import (
+	"context"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -67,18 +73,18 @@ func resourceEgressRoute() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourceEgressRouteCreate,
-		Read:   resourceEgressRouteRead,
-		Update: resourceEgressRouteUpdate,
-		Delete: resourceEgressRouteDelete,
+		CreateContext: resourceEgressRouteCreate,
+		ReadContext:   resourceEgressRouteRead,
+		UpdateContext: resourceEgressRouteUpdate,
+		DeleteContext: resourceEgressRouteDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourceEgressRouteCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceEgressRouteCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	description := d.Get("description").(string)
 	destinations := resourceTypeSetToStringSlice(d.Get("destinations").(*schema.Set))
@@ -88,7 +94,7 @@ func resourceEgressRouteCreate(d *schema.ResourceData, m interface{}) error {
 	sources := resourceTypeSetToStringSlice(d.Get("sources").(*schema.Set))
 	via := d.Get("via").(string)
 
-	egressRoute := EgressRoute{
+	egressRoute := sdk.CreateEgressRouteRequest{
 		Description:   description,
 		Destinations:  destinations,
 		Enabled:       enabled,
@@ -98,41 +104,45 @@ func resourceEgressRouteCreate(d *schema.ResourceData, m interface{}) error {
 		Via:           via,
 	}
 
-	var newEgressRoute *EgressRoute
-	newEgressRoute, err := client.CreateEgressRoute(&egressRoute)
+	var newEgressRoute *sdk.EgressRoute
+	newEgressRoute, err := client.CreateEgressRoute(ctx, &egressRoute)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(newEgressRoute.ID)
 
 	err = egressRouteToResource(d, newEgressRoute)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceEgressRouteRead(d, m)
+	return resourceEgressRouteRead(ctx, d, m)
 }
 
-func resourceEgressRouteRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceEgressRouteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	egressRoute, err := client.GetEgressRoute(d.Id())
+	egressRoute, err := client.GetEgressRoute(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Egress Route %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = egressRouteToResource(d, egressRoute)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceEgressRouteUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceEgressRouteUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	description := d.Get("description").(string)
 	destinations := resourceTypeSetToStringSlice(d.Get("destinations").(*schema.Set))
@@ -142,44 +152,44 @@ func resourceEgressRouteUpdate(d *schema.ResourceData, m interface{}) error {
 	sources := resourceTypeSetToStringSlice(d.Get("sources").(*schema.Set))
 	via := d.Get("via").(string)
 
-	egressRoute := EgressRoute{
-		Description:   description,
-		Destinations:  destinations,
-		Enabled:       enabled,
-		ExemptSources: exemptSources,
-		Name:          name,
-		Sources:       sources,
-		Via:           via,
+	egressRoute := sdk.UpdateEgressRouteRequest{
+		Description:   &description,
+		Destinations:  sdk.StringsOrNull(destinations),
+		Enabled:       &enabled,
+		ExemptSources: sdk.StringsOrNull(exemptSources),
+		Name:          &name,
+		Sources:       sdk.StringsOrNull(sources),
+		Via:           &via,
 	}
 
-	var updatedEgressRoute *EgressRoute
-	updatedEgressRoute, err := client.UpdateEgressRoute(d.Id(), &egressRoute)
+	var updatedEgressRoute *sdk.EgressRoute
+	updatedEgressRoute, err := client.UpdateEgressRoute(ctx, d.Id(), &egressRoute)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(updatedEgressRoute.ID)
 
 	err = egressRouteToResource(d, updatedEgressRoute)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceEgressRouteRead(d, m)
+	return resourceEgressRouteRead(ctx, d, m)
 }
 
-func resourceEgressRouteDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceEgressRouteDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteEgressRoute(d.Id())
+	err := client.DeleteEgressRoute(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func egressRouteToResource(d *schema.ResourceData, m *EgressRoute) error {
+func egressRouteToResource(d *schema.ResourceData, m *sdk.EgressRoute) error {
 	d.Set("description", m.Description)
 	d.Set("destinations", m.Destinations)
 	d.Set("enabled", m.Enabled)
@@ -0,0 +1,97 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccEgressRoute_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_egress_route.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_egress_route", testAccGetEgressRoute),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEgressRouteConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetEgressRoute),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttrPair(resourceName, "via", "data.metanetworks_locations.all", "locations.0.name"),
+					resource.TestCheckResourceAttr(resourceName, "destinations.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "destinations.*", "example.com"),
+					resource.TestCheckResourceAttr(resourceName, "sources.#", "1"),
+				),
+			},
+			{
+				Config: testAccEgressRouteConfig(rName, "second", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccEgressRoute_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_egress_route.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_egress_route", testAccGetEgressRoute),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEgressRouteConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetEgressRoute),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteEgressRoute),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccGetEgressRoute(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetEgressRoute(ctx, id)
+	return err
+}
+
+func testAccEgressRouteConfig(rName, description string, enabled bool) string {
+	return fmt.Sprintf(`
+data "metanetworks_locations" "all" {}
+
+resource "metanetworks_group" "test" {
+  name = %[1]q
+}
+
+resource "metanetworks_egress_route" "test" {
+  name         = %[1]q
+  description  = %[2]q
+  enabled      = %[3]t
+  destinations = ["example.com"]
+  sources      = [metanetworks_group.test.id]
+  via          = data.metanetworks_locations.all.locations[0].name
+}
+`, rName, description, enabled)
+}
@@ -1,11 +1,17 @@
 package metanetworks
 
 import (
+	"context"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGroup() *schema.Resource {
-	return &schema.Resource{
+	return withStateUpgrades(&schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The ID of the group.",
@@ -60,125 +66,141 @@ func resourceGroup() *schema.Resource {
 				Optional:    true,
 			},
 		},
-		Create: resourceGroupCreate,
-		Read:   resourceGroupRead,
-		Update: resourceGroupUpdate,
-		Delete: resourceGroupDelete,
+		CreateContext: resourceGroupCreate,
+		ReadContext:   resourceGroupRead,
+		UpdateContext: resourceGroupUpdate,
+		DeleteContext: resourceGroupDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
+	},
+		stateUpgrade{upgrade: resourceGroupUpgradeV0},
+	)
+}
+
+// resourceGroupUpgradeV0 clears the created_at of version 0, which held the
+// modification time, for the next refresh to read it.
+func resourceGroupUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState != nil {
+		delete(rawState, "created_at")
 	}
+
+	return rawState, nil
 }
 
-func resourceGroupCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	expression := d.Get("expression").(string)
 
-	group := Group{
+	group := sdk.CreateGroupRequest{
 		Name:        name,
 		Description: description,
 		Expression:  expression,
 	}
 
-	var newGroup *Group
-	newGroup, err := client.CreateGroup(&group)
+	var newGroup *sdk.Group
+	newGroup, err := client.CreateGroup(ctx, &group)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(newGroup.ID)
 	err = groupToResource(d, newGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = setGroupRoles(d, client)
+	err = setGroupRoles(ctx, d, client)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = setGroupUsers(d, client)
+	err = setGroupUsers(ctx, d, client)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGroupRead(d, m)
+	return resourceGroupRead(ctx, d, m)
 }
 
-func resourceGroupRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	group, err := client.GetGroup(d.Id())
+	group, err := client.GetGroup(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Group %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = groupToResource(d, group)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	expression := d.Get("expression").(string)
 
-	group := Group{
-		Name:        name,
-		Description: description,
-		Expression:  expression,
+	group := sdk.UpdateGroupRequest{
+		Name:        &name,
+		Description: &description,
+		Expression:  sdk.StringOrNull(expression),
 	}
 
-	var updatedGroup *Group
-	updatedGroup, err := client.UpdateGroup(d.Id(), &group)
+	var updatedGroup *sdk.Group
+	updatedGroup, err := client.UpdateGroup(ctx, d.Id(), &group)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(updatedGroup.ID)
 
 	err = groupToResource(d, updatedGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = setGroupRoles(d, client)
+	err = setGroupRoles(ctx, d, client)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = setGroupUsers(d, client)
+	err = setGroupUsers(ctx, d, client)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGroupRead(d, m)
+	return resourceGroupRead(ctx, d, m)
 }
 
-func resourceGroupDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteGroup(d.Id())
+	err := client.DeleteGroup(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func setGroupRoles(d *schema.ResourceData, client *Client) error {
+func setGroupRoles(ctx context.Context, d *schema.ResourceData, client sdk.GroupsAPI) error {
 	if d.HasChange("roles") {
 		roles := resourceTypeSetToStringSlice(d.Get("roles").(*schema.Set))
-		group, err := client.SetGroupRoles(d.Id(), roles)
+		group, err := client.SetGroupRoles(ctx, d.Id(), roles)
 		if err != nil {
 			return err
 		}
@@ -189,7 +211,7 @@ func setGroupRoles(d *schema.ResourceData, client *Client) error {
 	return nil
 }
 
-func setGroupUsers(d *schema.ResourceData, client *Client) error {
+func setGroupUsers(ctx context.Context, d *schema.ResourceData, client sdk.GroupsAPI) error {
 	if d.HasChange("users") {
 		old, new := d.GetChange("users")
 		toAddSet := new.(*schema.Set).Difference(old.(*schema.Set))
@@ -198,16 +220,16 @@ func setGroupUsers(d *schema.ResourceData, client *Client) error {
 		toAdd := resourceTypeSetToStringSlice(toAddSet)
 		toRemove := resourceTypeSetToStringSlice(toRemoveSet)
 
-		var group *Group
+		var group *sdk.Group
 		var err error
 		if len(toAdd) > 0 {
-			group, err = client.AddGroupUsers(d.Id(), toAdd)
+			group, err = client.AddGroupUsers(ctx, d.Id(), toAdd)
 			if err != nil {
 				return err
 			}
 		}
 		if len(toRemove) > 0 {
-			group, err = client.RemoveGroupUsers(d.Id(), toAdd)
+			group, err = client.RemoveGroupUsers(ctx, d.Id(), toAdd)
 			if err != nil {
 				return err
 			}
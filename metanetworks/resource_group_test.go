@@ -0,0 +1,101 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGroup_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_group", testAccGetGroup),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupConfig(rName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetGroup),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "users.#", "0"),
+					resource.TestCheckResourceAttrSet(resourceName, "org_id"),
+				),
+			},
+			{
+				Config: testAccGroupConfig(rName, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccGroup_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_group", testAccGetGroup),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGroupConfig(rName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetGroup),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteGroup),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceGroupStateUpgradeV0(t *testing.T) {
+	rawState := map[string]interface{}{
+		"id":          "grp-1234",
+		"name":        "engineering",
+		"created_at":  "2021-06-02T10:00:00Z",
+		"modified_at": "2021-06-02T10:00:00Z",
+		"users":       []interface{}{"usr-1234"},
+	}
+
+	upgraded := testStateUpgrade(t, "metanetworks_group", 0, rawState)
+	if _, ok := upgraded["created_at"]; ok {
+		t.Errorf("created_at %v was kept", upgraded["created_at"])
+	}
+	if upgraded["modified_at"] != "2021-06-02T10:00:00Z" || upgraded["name"] != "engineering" {
+		t.Errorf("upgraded state %v lost attributes", upgraded)
+	}
+}
+
+func testAccGetGroup(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetGroup(ctx, id)
+	return err
+}
+
+func testAccGroupConfig(rName, description string) string {
+	return fmt.Sprintf(`
+resource "metanetworks_group" "test" {
+  name        = %[1]q
+  description = %[2]q
+}
+`, rName, description)
+}
@@ -1,8 +1,12 @@
 package metanetworks
 
 import (
-	"fmt"
+	"context"
+	"log"
 
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -67,112 +71,129 @@ func resourceMappedService() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourceMappedServiceCreate,
-		Read:   resourceMappedServiceRead,
-		Update: resourceMappedServiceUpdate,
-		Delete: resourceMappedServiceDelete,
+		CreateContext: resourceMappedServiceCreate,
+		ReadContext:   resourceMappedServiceRead,
+		UpdateContext: resourceMappedServiceUpdate,
+		DeleteContext: resourceMappedServiceDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(networkElementCreateTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importNetworkElement(networkElementTypeMappedService),
 		},
 	}
 }
 
-func resourceMappedServiceCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	mappedService := d.Get("mapped_service").(string)
 
-	networkElement := NetworkElement{
+	networkElement := sdk.CreateNetworkElementRequest{
 		Name:          name,
 		Description:   description,
 		MappedService: mappedService,
 	}
-	var newMappedService *NetworkElement
-	newMappedService, err := client.CreateNetworkElement(&networkElement)
+	var newMappedService *sdk.NetworkElement
+	newMappedService, err := client.CreateNetworkElement(ctx, &networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = WaitNetworkElementCreate(client, newMappedService.ID)
+	err = WaitNetworkElementCreate(ctx, client, newMappedService.ID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return fmt.Errorf("Error waiting for mapped service creation (%s) (%s)", newMappedService.ID, err)
+		return diag.Errorf("Error waiting for mapped service creation (%s) (%s)", newMappedService.ID, err)
 	}
 
 	d.SetId(newMappedService.ID)
 
 	err = mappedServiceToResource(d, newMappedService)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	err = client.SetNetworkElementTags(d)
+	err = setNetworkElementTags(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceMappedServiceRead(d, m)
+	return resourceMappedServiceRead(ctx, d, m)
 }
 
-func resourceMappedServiceRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	networkElement, err := client.GetNetworkElement(d.Id())
+	networkElement, err := client.GetNetworkElement(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Mapped Service %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
+	err = checkNetworkElementTypeUnchanged(networkElement, networkElementTypeMappedService)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	err = mappedServiceToResource(d, networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	err = readNetworkElementTags(ctx, client, d, networkElement)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceMappedServiceUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	mappedService := d.Get("mapped_service").(string)
 
-	networkElement := NetworkElement{
-		Name:          name,
-		Description:   description,
-		MappedService: mappedService,
+	networkElement := sdk.UpdateNetworkElementRequest{
+		Name:          &name,
+		Description:   &description,
+		MappedService: &mappedService,
 	}
-	var updatedMappedService *NetworkElement
-	updatedMappedService, err := client.UpdateNetworkElement(d.Id(), &networkElement)
+	var updatedMappedService *sdk.NetworkElement
+	updatedMappedService, err := client.UpdateNetworkElement(ctx, d.Id(), &networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = mappedServiceToResource(d, updatedMappedService)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	err = client.SetNetworkElementTags(d)
+	err = setNetworkElementTags(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceMappedServiceRead(d, m)
+	return resourceMappedServiceRead(ctx, d, m)
 }
 
-func resourceMappedServiceDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteNetworkElement(d.Id())
+	err := client.DeleteNetworkElement(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func mappedServiceToResource(d *schema.ResourceData, m *NetworkElement) error {
+func mappedServiceToResource(d *schema.ResourceData, m *sdk.NetworkElement) error {
 	d.Set("name", m.Name)
 	d.Set("description", m.Description)
 	d.Set("mapped_service", m.MappedService)
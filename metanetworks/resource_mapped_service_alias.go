@@ -1,10 +1,12 @@
 package metanetworks
 
 import (
-	"errors"
-	"fmt"
+	"context"
 	"log"
 
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -29,62 +31,69 @@ func resourceMappedServiceAlias() *schema.Resource {
 				ForceNew:    true,
 			},
 		},
-		Create: resourceMappedServiceAliasCreate,
-		Read:   resourceMappedServiceAliasRead,
-		Delete: resourceMappedServiceAliasDelete,
+		CreateContext: resourceMappedServiceAliasCreate,
+		ReadContext:   resourceMappedServiceAliasRead,
+		DeleteContext: resourceMappedServiceAliasDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importCompositeID("mapped_service_id", "alias", checkAlias),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(networkElementAliasCreateTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 	}
 }
 
-func resourceMappedServiceAliasCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedServiceAliasCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	mappedServiceID := d.Get("mapped_service_id").(string)
 	alias := d.Get("alias").(string)
 
-	var networkElement *NetworkElement
-	networkElement, err := client.GetNetworkElement(mappedServiceID)
+	var networkElement *sdk.NetworkElement
+	networkElement, err := client.GetNetworkElement(ctx, mappedServiceID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	for i := 0; i < len(networkElement.Aliases); i++ {
 		if networkElement.Aliases[i] == alias {
-			return errors.New("That is alias is already present on the Mapped Service")
+			return diag.Errorf("That is alias is already present on the Mapped Service")
 		}
 	}
 
-	_, err = client.SetNetworkElementAlias(mappedServiceID, alias)
+	_, err = client.SetNetworkElementAlias(ctx, mappedServiceID, alias)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = WaitNetworkElementAliasCreate(client, mappedServiceID, alias)
+	err = WaitNetworkElementAliasCreate(ctx, client, mappedServiceID, alias, d.Timeout(schema.TimeoutCreate))
 
 	if err != nil {
-		return fmt.Errorf("Error waiting for alias attachment creation (%s) (%s)", mappedServiceID, err)
+		return diag.Errorf("Error waiting for alias attachment creation (%s) (%s)", mappedServiceID, err)
 	}
 
-	d.SetId(fmt.Sprintf("%s_%s", mappedServiceID, alias))
+	d.SetId(compositeID(mappedServiceID, alias))
 
-	return resourceMappedServiceAliasRead(d, m)
+	return resourceMappedServiceAliasRead(ctx, d, m)
 }
 
-func resourceMappedServiceAliasRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedServiceAliasRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	mappedServiceID := d.Get("mapped_service_id").(string)
 	alias := d.Get("alias").(string)
 
-	var networkElement *NetworkElement
-	networkElement, err := client.GetNetworkElement(mappedServiceID)
+	var networkElement *sdk.NetworkElement
+	networkElement, err := client.GetNetworkElement(ctx, mappedServiceID)
 	if err != nil {
-		if apierr, ok := err.(*ApiError); ok && apierr.StatusCode == 404 {
+		if sdk.IsNotFound(err) {
 			log.Printf("[WARN] Removing Mapped Service Alias %q because mapped service %q no longer exists", d.Get("id").(string), mappedServiceID)
 			d.SetId("")
 
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	for i := 0; i < len(networkElement.Aliases); i++ {
@@ -97,22 +106,22 @@ func resourceMappedServiceAliasRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceMappedServiceAliasDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedServiceAliasDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	mappedServiceID := d.Get("mapped_service_id").(string)
 	alias := d.Get("alias").(string)
-	var networkElement *NetworkElement
-	networkElement, err := client.GetNetworkElement(mappedServiceID)
+	var networkElement *sdk.NetworkElement
+	networkElement, err := client.GetNetworkElement(ctx, mappedServiceID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	for i := 0; i < len(networkElement.Aliases); i++ {
 		if networkElement.Aliases[i] == alias {
-			_, err = client.DeleteNetworkElementAlias(mappedServiceID, alias)
+			_, err = client.DeleteNetworkElementAlias(ctx, mappedServiceID, alias)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -0,0 +1,113 @@
+package metanetworks
+
+import (
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMappedService_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_mapped_service.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_mapped_service", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMappedServiceConfig(rName, "first", "internal.example.com", "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "mapped_service", "internal.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "blue"),
+					resource.TestCheckResourceAttrSet(resourceName, "dns_name"),
+				),
+			},
+			{
+				Config: testAccMappedServiceConfig(rName, "second", "internal2.example.com", "green"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "mapped_service", "internal2.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "green"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccMappedService_tagsDrift(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_mapped_service.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_mapped_service", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMappedServiceConfig(rName, "first", "internal.example.com", "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckNetworkElementTagsChanged(resourceName, map[string]string{"color": "red", "owner": "portal"}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccMappedServiceConfig(rName, "first", "internal.example.com", "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "blue"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMappedService_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_mapped_service.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_mapped_service", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMappedServiceConfig(rName, "first", "internal.example.com", "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteNetworkElement),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccMappedServiceConfig(rName, description, mappedService, color string) string {
+	return fmt.Sprintf(`
+resource "metanetworks_mapped_service" "test" {
+  name           = %[1]q
+  description    = %[2]q
+  mapped_service = %[3]q
+
+  tags = {
+    color = %[4]q
+  }
+}
+`, rName, description, mappedService, color)
+}
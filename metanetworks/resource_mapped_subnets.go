@@ -1,8 +1,12 @@
 package metanetworks
 
 import (
-	"fmt"
+	"context"
+	"log"
 
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -86,112 +90,129 @@ func resourceMappedSubnets() *schema.Resource {
 				Required:    true,
 			},
 		},
-		Create: resourceMappedSubnetsCreate,
-		Read:   resourceMappedSubnetsRead,
-		Update: resourceMappedSubnetsUpdate,
-		Delete: resourceMappedSubnetsDelete,
+		CreateContext: resourceMappedSubnetsCreate,
+		ReadContext:   resourceMappedSubnetsRead,
+		UpdateContext: resourceMappedSubnetsUpdate,
+		DeleteContext: resourceMappedSubnetsDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(networkElementCreateTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importNetworkElement(networkElementTypeMappedSubnets),
 		},
 	}
 }
 
-func resourceMappedSubnetsCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedSubnetsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	mappedSubnets := resourceTypeSetToStringSlice(d.Get("mapped_subnets").(*schema.Set))
 
-	networkElement := NetworkElement{
+	networkElement := sdk.CreateNetworkElementRequest{
 		Name:          name,
 		Description:   description,
 		MappedSubnets: mappedSubnets,
 	}
-	var newMappedSubnets *NetworkElement
-	newMappedSubnets, err := client.CreateNetworkElement(&networkElement)
+	var newMappedSubnets *sdk.NetworkElement
+	newMappedSubnets, err := client.CreateNetworkElement(ctx, &networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = WaitNetworkElementCreate(client, newMappedSubnets.ID)
+	err = WaitNetworkElementCreate(ctx, client, newMappedSubnets.ID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return fmt.Errorf("Error waiting for mapped subnet creation (%s) (%s)", newMappedSubnets.ID, err)
+		return diag.Errorf("Error waiting for mapped subnet creation (%s) (%s)", newMappedSubnets.ID, err)
 	}
 
 	d.SetId(newMappedSubnets.ID)
 
 	err = mappedSubnetsToResource(d, newMappedSubnets)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	err = client.SetNetworkElementTags(d)
+	err = setNetworkElementTags(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceMappedSubnetsRead(d, m)
+	return resourceMappedSubnetsRead(ctx, d, m)
 }
 
-func resourceMappedSubnetsRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedSubnetsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	networkElement, err := client.GetNetworkElement(d.Id())
+	networkElement, err := client.GetNetworkElement(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Mapped Subnets %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
+	err = checkNetworkElementTypeUnchanged(networkElement, networkElementTypeMappedSubnets)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	err = mappedSubnetsToResource(d, networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	err = readNetworkElementTags(ctx, client, d, networkElement)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceMappedSubnetsUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedSubnetsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	mappedSubnets := resourceTypeSetToStringSlice(d.Get("mapped_subnets").(*schema.Set))
 
-	networkElement := NetworkElement{
-		Name:          name,
-		Description:   description,
-		MappedSubnets: mappedSubnets,
+	networkElement := sdk.UpdateNetworkElementRequest{
+		Name:          &name,
+		Description:   &description,
+		MappedSubnets: sdk.StringsOrNull(mappedSubnets),
 	}
-	var updatedMappedSubnets *NetworkElement
-	updatedMappedSubnets, err := client.UpdateNetworkElement(d.Id(), &networkElement)
+	var updatedMappedSubnets *sdk.NetworkElement
+	updatedMappedSubnets, err := client.UpdateNetworkElement(ctx, d.Id(), &networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = mappedSubnetsToResource(d, updatedMappedSubnets)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	err = client.SetNetworkElementTags(d)
+	err = setNetworkElementTags(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceMappedSubnetsRead(d, m)
+	return resourceMappedSubnetsRead(ctx, d, m)
 }
 
-func resourceMappedSubnetsDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedSubnetsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteNetworkElement(d.Id())
+	err := client.DeleteNetworkElement(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func mappedSubnetsToResource(d *schema.ResourceData, m *NetworkElement) error {
+func mappedSubnetsToResource(d *schema.ResourceData, m *sdk.NetworkElement) error {
 	d.Set("name", m.Name)
 	d.Set("description", m.Description)
 	d.Set("mapped_subnets", m.MappedSubnets)
@@ -210,7 +231,7 @@ func mappedSubnetsToResource(d *schema.ResourceData, m *NetworkElement) error {
 	return nil
 }
 
-func flattenMappedDomains(in []MappedDomain) []map[string]interface{} {
+func flattenMappedDomains(in []sdk.MappedDomain) []map[string]interface{} {
 	var out = make([]map[string]interface{}, len(in), len(in))
 	for i, v := range in {
 		m := make(map[string]interface{})
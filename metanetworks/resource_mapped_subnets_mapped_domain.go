@@ -1,6 +1,13 @@
 package metanetworks
 
 import (
+	"context"
+	"fmt"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -36,80 +43,107 @@ func resourceMappedSubnetsMappedDomain() *schema.Resource {
 				ForceNew:    true,
 			},
 		},
-		Create: resourceMappedSubnetsMappedDomainCreate,
-		Read:   resourceMappedSubnetsMappedDomainRead,
-		Update: resourceMappedSubnetsMappedDomainUpdate,
-		Delete: resourceMappedSubnetsMappedDomainDelete,
+		CreateContext: resourceMappedSubnetsMappedDomainCreate,
+		ReadContext:   resourceMappedSubnetsMappedDomainRead,
+		UpdateContext: resourceMappedSubnetsMappedDomainUpdate,
+		DeleteContext: resourceMappedSubnetsMappedDomainDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMappedSubnetsMappedDomainImport,
+		},
 	}
 }
 
-func resourceMappedSubnetsMappedDomainSet(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedSubnetsMappedDomainSet(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	mappedSubnetsID := d.Get("mapped_subnets_id").(string)
 	name := d.Get("name").(string)
 	domain := d.Get("mapped_domain").(string)
 	enterpriseDNS := d.Get("enterprise_dns").(bool)
 
-	mappedDomain := MappedDomain{
+	mappedDomain := sdk.MappedDomain{
 		MappedDomain:  domain,
 		EnterpriseDNS: enterpriseDNS,
 	}
-	_, err := client.SetNetworkElementMappedDomains(mappedSubnetsID, name, &mappedDomain)
+	_, err := client.SetNetworkElementMappedDomains(ctx, mappedSubnetsID, name, &mappedDomain)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(name)
 
-	return resourceMappedSubnetsMappedDomainRead(d, m)
+	return resourceMappedSubnetsMappedDomainRead(ctx, d, m)
 }
 
-func resourceMappedSubnetsMappedDomainCreate(d *schema.ResourceData, m interface{}) error {
-	return resourceMappedSubnetsMappedDomainSet(d, m)
+func resourceMappedSubnetsMappedDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceMappedSubnetsMappedDomainSet(ctx, d, m)
 }
 
-func resourceMappedSubnetsMappedDomainRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedSubnetsMappedDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	var mappedSubnetsID string
 	if v, ok := d.GetOk("mapped_subnets_id"); ok {
 		mappedSubnetsID = v.(string)
 	}
 
-	mappedDomain, err := client.GetMappedDomain(mappedSubnetsID, d.Id())
+	mappedDomain, err := client.GetMappedDomain(ctx, mappedSubnetsID, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Mapped Domain %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = mappedSubnetsMappedDomainToResource(d, mappedDomain)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceMappedSubnetsMappedDomainUpdate(d *schema.ResourceData, m interface{}) error {
-	return resourceMappedSubnetsMappedDomainSet(d, m)
+func resourceMappedSubnetsMappedDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceMappedSubnetsMappedDomainSet(ctx, d, m)
 }
 
-func resourceMappedSubnetsMappedDomainDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedSubnetsMappedDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	mappedSubnetsID := d.Get("mapped_subnets_id").(string)
 	name := d.Get("name").(string)
 
-	err := client.DeleteNetworkElementMappedDomains(mappedSubnetsID, name)
+	err := client.DeleteNetworkElementMappedDomains(ctx, mappedSubnetsID, name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func mappedSubnetsMappedDomainToResource(d *schema.ResourceData, m *MappedDomain) error {
+// resourceMappedSubnetsMappedDomainImport imports a mapped domain from the ID of
+// its mapped subnets and its name. The ID of the resource stays its name.
+func resourceMappedSubnetsMappedDomainImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(sdk.API)
+
+	mappedSubnetsID, name, err := parseCompositeID(d.Id(), "<mapped_subnets_id>_<name>")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.GetMappedDomain(ctx, mappedSubnetsID, name); err != nil {
+		return nil, fmt.Errorf("Could not read mapped domain %q of mapped subnets %s: %s", name, mappedSubnetsID, err)
+	}
+
+	d.Set("mapped_subnets_id", mappedSubnetsID)
+	d.Set("name", name)
+	d.SetId(name)
+	return []*schema.ResourceData{d}, nil
+}
+
+func mappedSubnetsMappedDomainToResource(d *schema.ResourceData, m *sdk.MappedDomain) error {
 	d.Set("name", m.Name)
 	d.Set("mapped_domain", m.MappedDomain)
 	d.Set("enterprise_dns", m.EnterpriseDNS)
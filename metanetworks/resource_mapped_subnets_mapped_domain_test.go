@@ -0,0 +1,152 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMappedSubnetsMappedDomain_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_mapped_subnets_mapped_domain.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckMappedSubnetsMappedDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMappedSubnetsMappedDomainConfig(rName, "first", "first.example.com", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMappedSubnetsMappedDomainExists(resourceName),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttrPair(resourceName, "mapped_subnets_id", "metanetworks_mapped_subnets.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "name", "first"),
+					resource.TestCheckResourceAttr(resourceName, "mapped_domain", "first.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "enterprise_dns", "false"),
+				),
+			},
+			{
+				Config: testAccMappedSubnetsMappedDomainConfig(rName, "first", "second.example.com", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMappedSubnetsMappedDomainExists(resourceName),
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "mapped_domain", "second.example.com"),
+					resource.TestCheckResourceAttr(resourceName, "enterprise_dns", "true"),
+				),
+			},
+			{
+				Config: testAccMappedSubnetsMappedDomainConfig(rName, "second", "second.example.com", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMappedSubnetsMappedDomainExists(resourceName),
+					testAccCheckIDChanged(resourceName, &id, true),
+					resource.TestCheckResourceAttr(resourceName, "name", "second"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportStateIDFunc(resourceName, "mapped_subnets_id"),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccMappedSubnetsMappedDomain_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_mapped_subnets_mapped_domain.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckMappedSubnetsMappedDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMappedSubnetsMappedDomainConfig(rName, "first", "first.example.com", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMappedSubnetsMappedDomainExists(resourceName),
+					testAccCheckMappedSubnetsMappedDomainDisappears(resourceName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccCheckMappedSubnetsMappedDomainExists checks that the mapped domain
+// exists in the API. Its id is only its name, so the mapped subnets come
+// from mapped_subnets_id.
+func testAccCheckMappedSubnetsMappedDomainExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testAccPrimary(s, name)
+		if err != nil {
+			return err
+		}
+
+		_, err = testAccClient().GetMappedDomain(context.Background(), rs.Attributes["mapped_subnets_id"], rs.ID)
+		if err != nil {
+			return fmt.Errorf("%s %s does not exist: %s", name, rs.ID, err)
+		}
+		return nil
+	}
+}
+
+func testAccCheckMappedSubnetsMappedDomainDestroy(s *terraform.State) error {
+	ctx := context.Background()
+	client := testAccClient()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "metanetworks_mapped_subnets_mapped_domain" {
+			continue
+		}
+
+		err := resource.RetryContext(ctx, testAccDestroyTimeout, func() *resource.RetryError {
+			_, err := client.GetMappedDomain(ctx, rs.Primary.Attributes["mapped_subnets_id"], rs.Primary.ID)
+			if err == nil {
+				return resource.RetryableError(fmt.Errorf("mapped domain %s still exists", rs.Primary.ID))
+			}
+			if !sdk.IsNotFound(err) {
+				return resource.NonRetryableError(err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func testAccCheckMappedSubnetsMappedDomainDisappears(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, err := testAccPrimary(s, name)
+		if err != nil {
+			return err
+		}
+
+		return testAccClient().DeleteNetworkElementMappedDomains(context.Background(), rs.Attributes["mapped_subnets_id"], rs.ID)
+	}
+}
+
+func testAccMappedSubnetsMappedDomainConfig(rName, name, mappedDomain string, enterpriseDNS bool) string {
+	return fmt.Sprintf(`
+resource "metanetworks_mapped_subnets" "test" {
+  name           = %[1]q
+  mapped_subnets = ["10.60.0.0/24"]
+}
+
+resource "metanetworks_mapped_subnets_mapped_domain" "test" {
+  mapped_subnets_id = metanetworks_mapped_subnets.test.id
+  name              = %[2]q
+  mapped_domain     = %[3]q
+  enterprise_dns    = %[4]t
+}
+`, rName, name, mappedDomain, enterpriseDNS)
+}
@@ -1,6 +1,13 @@
 package metanetworks
 
 import (
+	"context"
+	"fmt"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -36,80 +43,107 @@ func resourceMappedSubnetsMappedHost() *schema.Resource {
 				ForceNew:    true,
 			},
 		},
-		Create: resourceMappedSubnetsMappedHostCreate,
-		Read:   resourceMappedSubnetsMappedHostRead,
-		Update: resourceMappedSubnetsMappedHostUpdate,
-		Delete: resourceMappedSubnetsMappedHostDelete,
+		CreateContext: resourceMappedSubnetsMappedHostCreate,
+		ReadContext:   resourceMappedSubnetsMappedHostRead,
+		UpdateContext: resourceMappedSubnetsMappedHostUpdate,
+		DeleteContext: resourceMappedSubnetsMappedHostDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMappedSubnetsMappedHostImport,
+		},
 	}
 }
 
-func resourceMappedSubnetsMappedHostSet(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedSubnetsMappedHostSet(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	mappedSubnetsID := d.Get("mapped_subnets_id").(string)
 	name := d.Get("name").(string)
 	host := d.Get("mapped_host").(string)
 	ignoreBounds := d.Get("ignore_bounds").(bool)
 
-	mappedHost := MappedHost{
+	mappedHost := sdk.MappedHost{
 		MappedHost:   host,
 		IgnoreBounds: ignoreBounds,
 	}
-	_, err := client.SetNetworkElementMappedHosts(mappedSubnetsID, name, &mappedHost)
+	_, err := client.SetNetworkElementMappedHosts(ctx, mappedSubnetsID, name, &mappedHost)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(name)
 
-	return resourceMappedSubnetsMappedHostRead(d, m)
+	return resourceMappedSubnetsMappedHostRead(ctx, d, m)
 }
 
-func resourceMappedSubnetsMappedHostCreate(d *schema.ResourceData, m interface{}) error {
-	return resourceMappedSubnetsMappedHostSet(d, m)
+func resourceMappedSubnetsMappedHostCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceMappedSubnetsMappedHostSet(ctx, d, m)
 }
 
-func resourceMappedSubnetsMappedHostRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedSubnetsMappedHostRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	var mappedSubnetsID string
 	if v, ok := d.GetOk("mapped_subnets_id"); ok {
 		mappedSubnetsID = v.(string)
 	}
 
-	mappedHost, err := client.GetMappedHost(mappedSubnetsID, d.Id())
+	mappedHost, err := client.GetMappedHost(ctx, mappedSubnetsID, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Mapped Host %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = mappedSubnetsMappedHostToResource(d, mappedHost)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceMappedSubnetsMappedHostUpdate(d *schema.ResourceData, m interface{}) error {
-	return resourceMappedSubnetsMappedHostSet(d, m)
+func resourceMappedSubnetsMappedHostUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceMappedSubnetsMappedHostSet(ctx, d, m)
 }
 
-func resourceMappedSubnetsMappedHostDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMappedSubnetsMappedHostDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	mappedSubnetsID := d.Get("mapped_subnets_id").(string)
 	name := d.Get("name").(string)
 
-	err := client.DeleteNetworkElementMappedHosts(mappedSubnetsID, name)
+	err := client.DeleteNetworkElementMappedHosts(ctx, mappedSubnetsID, name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func mappedSubnetsMappedHostToResource(d *schema.ResourceData, m *MappedHost) error {
+// resourceMappedSubnetsMappedHostImport imports a mapped host from the ID of
+// its mapped subnets and its name. The ID of the resource stays its name.
+func resourceMappedSubnetsMappedHostImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(sdk.API)
+
+	mappedSubnetsID, name, err := parseCompositeID(d.Id(), "<mapped_subnets_id>_<name>")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := client.GetMappedHost(ctx, mappedSubnetsID, name); err != nil {
+		return nil, fmt.Errorf("Could not read mapped host %q of mapped subnets %s: %s", name, mappedSubnetsID, err)
+	}
+
+	d.Set("mapped_subnets_id", mappedSubnetsID)
+	d.Set("name", name)
+	d.SetId(name)
+	return []*schema.ResourceData{d}, nil
+}
+
+func mappedSubnetsMappedHostToResource(d *schema.ResourceData, m *sdk.MappedHost) error {
 	d.Set("name", m.Name)
 	d.Set("mapped_host", m.MappedHost)
 	d.Set("ignore_bounds", m.IgnoreBounds)
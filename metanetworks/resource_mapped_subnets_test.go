@@ -0,0 +1,136 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMappedSubnets_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_mapped_subnets.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_mapped_subnets", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMappedSubnetsConfig(rName, "first", `["10.40.0.0/24"]`, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "mapped_subnets.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "mapped_subnets.*", "10.40.0.0/24"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "blue"),
+					resource.TestCheckResourceAttrSet(resourceName, "dns_name"),
+				),
+			},
+			{
+				Config: testAccMappedSubnetsConfig(rName, "second", `["10.40.0.0/24", "10.40.1.0/24"]`, "green"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "mapped_subnets.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "mapped_subnets.*", "10.40.1.0/24"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "green"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccMappedSubnets_tagsDrift(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_mapped_subnets.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_mapped_subnets", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMappedSubnetsConfig(rName, "first", `["10.40.0.0/24"]`, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckNetworkElementTagsChanged(resourceName, map[string]string{"color": "red", "owner": "portal"}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccMappedSubnetsConfig(rName, "first", `["10.40.0.0/24"]`, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "blue"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMappedSubnets_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_mapped_subnets.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_mapped_subnets", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMappedSubnetsConfig(rName, "first", `["10.40.0.0/24"]`, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteNetworkElement),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// testAccGetNetworkElement gets the network element behind a device, mapped
+// service, mapped subnets or native service.
+func testAccGetNetworkElement(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetNetworkElement(ctx, id)
+	return err
+}
+
+func testAccMappedSubnetsConfig(rName, description, subnets, color string) string {
+	return fmt.Sprintf(`
+resource "metanetworks_mapped_subnets" "test" {
+  name           = %[1]q
+  description    = %[2]q
+  mapped_subnets = %[3]s
+
+  tags = {
+    color = %[4]q
+  }
+}
+`, rName, description, subnets, color)
+}
+
+// testAccMappedSubnetsElementsConfig declares count mapped subnets, as
+// network elements to attach to other resources.
+func testAccMappedSubnetsElementsConfig(rName string, count int) string {
+	return fmt.Sprintf(`
+resource "metanetworks_mapped_subnets" "test" {
+  count          = %[2]d
+  name           = "%[1]s-${count.index}"
+  mapped_subnets = ["10.50.${count.index}.0/24"]
+}
+`, rName, count)
+}
@@ -1,6 +1,12 @@
 package metanetworks
 
 import (
+	"context"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -66,101 +72,105 @@ func resourceMetaport() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourceMetaportCreate,
-		Read:   resourceMetaportRead,
-		Update: resourceMetaportUpdate,
-		Delete: resourceMetaportDelete,
+		CreateContext: resourceMetaportCreate,
+		ReadContext:   resourceMetaportRead,
+		UpdateContext: resourceMetaportUpdate,
+		DeleteContext: resourceMetaportDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourceMetaportCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	enabled := d.Get("enabled").(bool)
 	allowSupport := d.Get("allow_support").(bool)
 
-	metaport := MetaPort{
+	metaport := sdk.CreateMetaPortRequest{
 		Name:         name,
 		Description:  description,
 		Enabled:      enabled,
 		AllowSupport: &allowSupport,
 	}
-	var newMetaport *MetaPort
-	newMetaport, err := client.CreateMetaPort(&metaport)
+	var newMetaport *sdk.MetaPort
+	newMetaport, err := client.CreateMetaPort(ctx, &metaport)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(newMetaport.ID)
 	err = metaportToResource(d, newMetaport)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceMetaportRead(d, m)
+	return resourceMetaportRead(ctx, d, m)
 }
 
-func resourceMetaportRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	metaport, err := client.GetMetaPort(d.Id())
+	metaport, err := client.GetMetaPort(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Metaport %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = metaportToResource(d, metaport)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceMetaportUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	enabled := d.Get("enabled").(bool)
 	allowSupport := d.Get("allow_support").(bool)
 
-	metaport := MetaPort{
-		Name:         name,
-		Description:  description,
-		Enabled:      enabled,
+	metaport := sdk.UpdateMetaPortRequest{
+		Name:         &name,
+		Description:  &description,
+		Enabled:      &enabled,
 		AllowSupport: &allowSupport,
 	}
 
-	var updatedMetaport *MetaPort
-	updatedMetaport, err := client.UpdateMetaPort(d.Id(), &metaport)
+	var updatedMetaport *sdk.MetaPort
+	updatedMetaport, err := client.UpdateMetaPort(ctx, d.Id(), &metaport)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = metaportToResource(d, updatedMetaport)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceMetaportRead(d, m)
+	return resourceMetaportRead(ctx, d, m)
 }
 
-func resourceMetaportDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteMetaPort(d.Id())
+	err := client.DeleteMetaPort(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func metaportToResource(d *schema.ResourceData, m *MetaPort) error {
+func metaportToResource(d *schema.ResourceData, m *sdk.MetaPort) error {
 	err := d.Set("name", m.Name)
 	if err != nil {
 		return err
@@ -1,17 +1,18 @@
 package metanetworks
 
 import (
-	"errors"
-	"fmt"
-	"strings"
-	"time"
+	"context"
+	"log"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceMetaportAttachment() *schema.Resource {
 	return &schema.Resource{
+		Description: "Maps a network element to a Metaport, leaving its other network elements alone. Don't use it for a Metaport whose network elements are set by `metanetworks_metaport_mapped_elements`, which removes those it doesn't list. The attachment is written with the ETag of the Metaport when the API returns one. Otherwise the Metaport is read again right before the write and its `modified_at` compared, which leaves a short window: a change to its network elements made by someone else between that read and the write is overwritten.",
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The ID of this resource.",
@@ -31,17 +32,23 @@ func resourceMetaportAttachment() *schema.Resource {
 				ForceNew:    true,
 			},
 		},
-		Create: resourceMetaportAttachmentCreate,
-		Read:   resourceMetaportAttachmentRead,
-		Delete: resourceMetaportAttachmentDelete,
+		CreateContext: resourceMetaportAttachmentCreate,
+		ReadContext:   resourceMetaportAttachmentRead,
+		DeleteContext: resourceMetaportAttachmentDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(metaportAttachmentCreateTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importCompositeID("metaport_id", "network_element_id", func(ctx context.Context, client sdk.API, metaportID, elementID string) error {
+				return metaportMappedElements(client).checkMember(ctx, metaportID, elementID)
+			}),
 		},
 	}
 }
 
-func resourceMetaportAttachmentCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	elementID := d.Get("network_element_id").(string)
 	metaportID := d.Get("metaport_id").(string)
@@ -49,51 +56,39 @@ func resourceMetaportAttachmentCreate(d *schema.ResourceData, m interface{}) err
 	metanetworksMutexKV.Lock(metaportID)
 	defer metanetworksMutexKV.Unlock(metaportID)
 
-	var metaport *MetaPort
-	metaport, err := client.GetMetaPort(metaportID)
-	if err != nil {
-		return err
-	}
-
-	for i := 0; i < len(metaport.MappedElements); i++ {
-		if metaport.MappedElements[i] == elementID {
-			return errors.New("That network element is already mapped to this MetaPort")
-		}
-
-	}
-
-	metaport.MappedElements = append(metaport.MappedElements, elementID)
-	_, err = client.UpdateMetaPort(metaportID, metaport)
+	err := metaportMappedElements(client).addMember(ctx, metaportID, elementID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = WaitMetaportAttachmentCreate(client, metaportID, elementID)
+	err = WaitMetaportAttachmentCreate(ctx, client, metaportID, elementID, d.Timeout(schema.TimeoutCreate))
 
 	if err != nil {
-		return fmt.Errorf("Error waiting for metaport attachment creation (%s) (%s)", metaportID, err)
+		return diag.Errorf("Error waiting for metaport attachment creation (%s) (%s)", metaportID, err)
 	}
 
-	d.SetId(fmt.Sprintf("%s_%s", metaportID, elementID))
+	d.SetId(compositeID(metaportID, elementID))
 
-	return resourceMetaportAttachmentRead(d, m)
+	return resourceMetaportAttachmentRead(ctx, d, m)
 }
 
-func resourceMetaportAttachmentRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	id := d.Get("id").(string)
-	ids := strings.Split(id, "_")
-	if len(ids) != 2 {
-		return fmt.Errorf("Error missing id for metaport attachment got (%s)", id)
+	metaportID, elementID, err := parseCompositeID(d.Id(), "<metaport_id>_<network_element_id>")
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	metaportID := ids[0]
-	elementID := ids[1]
 
-	var metaport *MetaPort
-	metaport, err := client.GetMetaPort(metaportID)
+	var metaport *sdk.MetaPort
+	metaport, err = client.GetMetaPort(ctx, metaportID)
 	if err != nil {
-		return err
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Metaport Attachment %q because metaport %q no longer exists", d.Id(), metaportID)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -115,8 +110,8 @@ func resourceMetaportAttachmentRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceMetaportAttachmentDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	elementID := d.Get("network_element_id").(string)
 	metaportID := d.Get("metaport_id").(string)
@@ -124,33 +119,32 @@ func resourceMetaportAttachmentDelete(d *schema.ResourceData, m interface{}) err
 	metanetworksMutexKV.Lock(metaportID)
 	defer metanetworksMutexKV.Unlock(metaportID)
 
-	var metaport *MetaPort
-	metaport, err := client.GetMetaPort(metaportID)
+	err := metaportMappedElements(client).removeMember(ctx, metaportID, elementID, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
-		return err
+		return diag.Errorf("Error in metaport attachment deletion (%s) (%s)", metaportID, err)
 	}
 
-	// Note that if the entry has already been deleted this won't fail.
-	for i := 0; i < len(metaport.MappedElements); i++ {
-		if metaport.MappedElements[i] == elementID {
-			metaport.MappedElements = append(metaport.MappedElements[:i], metaport.MappedElements[i+1:]...)
-			break
-		}
-	}
+	return nil
+}
 
-	err = resource.Retry(5*time.Second, func() *resource.RetryError {
-		if _, err := client.UpdateMetaPort(metaportID, metaport); err != nil {
-			if !strings.Contains(err.Error(), "is busy. Try again later.") {
-				return resource.NonRetryableError(err)
+// metaportMappedElements is the list of network elements attached to a MetaPort.
+func metaportMappedElements(client sdk.MetaPortsAPI) *memberListAPI {
+	return &memberListAPI{
+		parent: "MetaPort",
+		list:   "mapped elements",
+		get: func(ctx context.Context, metaportID string) (*memberList, error) {
+			metaport, err := client.GetMetaPort(ctx, metaportID)
+			if err != nil {
+				return nil, err
 			}
-			return resource.RetryableError(err)
-		}
-		return nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("Error in metaport attachment deletion (%s) (%s)", metaportID, err)
+			return &memberList{members: metaport.MappedElements, etag: metaport.ETag, modifiedAt: metaport.ModifiedAt}, nil
+		},
+		set: func(ctx context.Context, metaportID string, members []string, ifMatch string) error {
+			_, err := client.UpdateMetaPort(ctx, metaportID, &sdk.UpdateMetaPortRequest{
+				MappedElements: sdk.StringsOrNull(members),
+				IfMatch:        ifMatch,
+			})
+			return err
+		},
 	}
-
-	return nil
 }
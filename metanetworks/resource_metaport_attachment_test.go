@@ -0,0 +1,151 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMetaportAttachment_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_metaport_attachment.test.0"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_metaport_attachment", testAccGetMetaportAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportAttachmentConfig(rName, 2, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetMetaportAttachment),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttrPair(resourceName, "metaport_id", "metanetworks_metaport.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "network_element_id", "metanetworks_mapped_subnets.test.0", "id"),
+				),
+			},
+			{
+				Config: testAccMetaportAttachmentConfig(rName, 2, 1, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetMetaportAttachment),
+					testAccCheckIDChanged(resourceName, &id, true),
+					resource.TestCheckResourceAttrPair(resourceName, "network_element_id", "metanetworks_mapped_subnets.test.1", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccMetaportAttachment_disappears(t *testing.T) {
+	testAccSkipReplay(t)
+
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_metaport_attachment.test.0"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_metaport_attachment", testAccGetMetaportAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportAttachmentConfig(rName, 2, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetMetaportAttachment),
+					testAccCheckDisappears(resourceName, testAccDeleteMetaportAttachment),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestAccMetaportAttachment_concurrent attaches several network elements to
+// the same Metaport at once, none of them may be lost.
+func TestAccMetaportAttachment_concurrent(t *testing.T) {
+	testAccSkipReplay(t)
+
+	rName := testAccRandomName(t)
+	metaportName := "metanetworks_metaport.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_metaport_attachment", testAccGetMetaportAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportAttachmentConfig(rName, 3, 3, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(metaportName, testAccMetaportMappedElements, 3),
+					testAccCheckExists("metanetworks_metaport_attachment.test.0", testAccGetMetaportAttachment),
+					testAccCheckExists("metanetworks_metaport_attachment.test.1", testAccGetMetaportAttachment),
+					testAccCheckExists("metanetworks_metaport_attachment.test.2", testAccGetMetaportAttachment),
+				),
+			},
+			{
+				Config: testAccMetaportAttachmentConfig(rName, 3, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(metaportName, testAccMetaportMappedElements, 1),
+					testAccCheckExists("metanetworks_metaport_attachment.test.0", testAccGetMetaportAttachment),
+				),
+			},
+			{
+				Config: testAccMetaportAttachmentConfig(rName, 3, 0, 0),
+				Check:  testAccCheckMembers(metaportName, testAccMetaportMappedElements, 0),
+			},
+		},
+	})
+}
+
+var testAccGetMetaportAttachment = testAccGetMember(testAccMetaportMappedElements)
+
+func testAccMetaportMappedElements(client sdk.API, ctx context.Context, id string) ([]string, error) {
+	metaport, err := client.GetMetaPort(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return metaport.MappedElements, nil
+}
+
+// testAccDeleteMetaportAttachment removes the network element from the
+// Metaport out of band.
+func testAccDeleteMetaportAttachment(client sdk.API, ctx context.Context, id string) error {
+	metaportID, elementID, err := testAccSplitID(id)
+	if err != nil {
+		return err
+	}
+
+	metaport, err := client.GetMetaPort(ctx, metaportID)
+	if err != nil {
+		return err
+	}
+	metaport.MappedElements = testAccRemoveMember(metaport.MappedElements, elementID)
+
+	_, err = client.UpdateMetaPort(ctx, metaportID, &sdk.UpdateMetaPortRequest{MappedElements: sdk.StringsOrNull(metaport.MappedElements)})
+	return err
+}
+
+// testAccMetaportAttachmentConfig attaches count of the elements mapped
+// subnets to a Metaport, starting with the element at offset.
+func testAccMetaportAttachmentConfig(rName string, elements, count, offset int) string {
+	return testAccMappedSubnetsElementsConfig(rName, elements) + fmt.Sprintf(`
+resource "metanetworks_metaport" "test" {
+  name = %[1]q
+}
+
+resource "metanetworks_metaport_attachment" "test" {
+  count              = %[2]d
+  metaport_id        = metanetworks_metaport.test.id
+  network_element_id = metanetworks_mapped_subnets.test[count.index + %[3]d].id
+}
+`, rName, count, offset)
+}
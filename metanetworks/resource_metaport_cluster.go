@@ -1,6 +1,12 @@
 package metanetworks
 
 import (
+	"context"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -45,93 +51,97 @@ func resourceMetaportCluster() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourceMetaportClusterCreate,
-		Read:   resourceMetaportClusterRead,
-		Update: resourceMetaportClusterUpdate,
-		Delete: resourceMetaportClusterDelete,
+		CreateContext: resourceMetaportClusterCreate,
+		ReadContext:   resourceMetaportClusterRead,
+		UpdateContext: resourceMetaportClusterUpdate,
+		DeleteContext: resourceMetaportClusterDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourceMetaportClusterCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportClusterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 
-	metaportCluster := MetaportCluster{
+	metaportCluster := sdk.CreateMetaportClusterRequest{
 		Name:        name,
 		Description: description,
 	}
-	var newMetaportCluster *MetaportCluster
-	newMetaportCluster, err := client.CreateMetaPortCluster(&metaportCluster)
+	var newMetaportCluster *sdk.MetaportCluster
+	newMetaportCluster, err := client.CreateMetaPortCluster(ctx, &metaportCluster)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(newMetaportCluster.ID)
 	err = metaportClusterToResource(d, newMetaportCluster)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceMetaportClusterRead(d, m)
+	return resourceMetaportClusterRead(ctx, d, m)
 }
 
-func resourceMetaportClusterRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportClusterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	metaportCluster, err := client.GetMetaPortCluster(d.Id())
+	metaportCluster, err := client.GetMetaPortCluster(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Metaport Cluster %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = metaportClusterToResource(d, metaportCluster)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceMetaportClusterUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportClusterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 
-	metaportCluster := MetaportCluster{
-		Name:        name,
-		Description: description,
+	metaportCluster := sdk.UpdateMetaportClusterRequest{
+		Name:        &name,
+		Description: &description,
 	}
 
-	var updatedMetaportCluster *MetaportCluster
-	updatedMetaportCluster, err := client.UpdateMetaPortCluster(d.Id(), &metaportCluster)
+	var updatedMetaportCluster *sdk.MetaportCluster
+	updatedMetaportCluster, err := client.UpdateMetaPortCluster(ctx, d.Id(), &metaportCluster)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = metaportClusterToResource(d, updatedMetaportCluster)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceMetaportClusterRead(d, m)
+	return resourceMetaportClusterRead(ctx, d, m)
 }
 
-func resourceMetaportClusterDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportClusterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteMetaPortCluster(d.Id())
+	err := client.DeleteMetaPortCluster(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func metaportClusterToResource(d *schema.ResourceData, m *MetaportCluster) error {
+func metaportClusterToResource(d *schema.ResourceData, m *sdk.MetaportCluster) error {
 	err := d.Set("name", m.Name)
 	if err != nil {
 		return err
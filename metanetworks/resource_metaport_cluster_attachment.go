@@ -1,17 +1,18 @@
 package metanetworks
 
 import (
-	"errors"
-	"fmt"
-	"strings"
-	"time"
+	"context"
+	"log"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceMetaportClusterAttachment() *schema.Resource {
 	return &schema.Resource{
+		Description: "Maps a network element to a Metaport cluster, leaving its other network elements alone. Don't use it for a Metaport cluster whose network elements are set by `metanetworks_metaport_cluster_mapped_elements`, which removes those it doesn't list. The attachment is written with the ETag of the Metaport cluster when the API returns one. Otherwise the Metaport cluster is read again right before the write and its `modified_at` compared, which leaves a short window: a change to its network elements made by someone else between that read and the write is overwritten.",
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The ID of this resource.",
@@ -31,17 +32,23 @@ func resourceMetaportClusterAttachment() *schema.Resource {
 				ForceNew:    true,
 			},
 		},
-		Create: resourceMetaportClusterAttachmentCreate,
-		Read:   resourceMetaportClusterAttachmentRead,
-		Delete: resourceMetaportClusterAttachmentDelete,
+		CreateContext: resourceMetaportClusterAttachmentCreate,
+		ReadContext:   resourceMetaportClusterAttachmentRead,
+		DeleteContext: resourceMetaportClusterAttachmentDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(metaportClusterAttachmentCreateTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importCompositeID("metaport_cluster_id", "network_element_id", func(ctx context.Context, client sdk.API, metaportClusterID, elementID string) error {
+				return metaportClusterMappedElements(client).checkMember(ctx, metaportClusterID, elementID)
+			}),
 		},
 	}
 }
 
-func resourceMetaportClusterAttachmentCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportClusterAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	elementID := d.Get("network_element_id").(string)
 	metaporClustertID := d.Get("metaport_cluster_id").(string)
@@ -49,51 +56,39 @@ func resourceMetaportClusterAttachmentCreate(d *schema.ResourceData, m interface
 	metanetworksMutexKV.Lock(metaporClustertID)
 	defer metanetworksMutexKV.Unlock(metaporClustertID)
 
-	var metaportCluster *MetaportCluster
-	metaportCluster, err := client.GetMetaPortCluster(metaporClustertID)
-	if err != nil {
-		return err
-	}
-
-	for i := 0; i < len(metaportCluster.MappedElements); i++ {
-		if metaportCluster.MappedElements[i] == elementID {
-			return errors.New("That network element is already mapped to this Metaport Cluster")
-		}
-
-	}
-
-	metaportCluster.MappedElements = append(metaportCluster.MappedElements, elementID)
-	_, err = client.UpdateMetaPortCluster(metaporClustertID, metaportCluster)
+	err := metaportClusterMappedElements(client).addMember(ctx, metaporClustertID, elementID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = WaitMetaportClusterAttachmentCreate(client, metaporClustertID, elementID)
+	err = WaitMetaportClusterAttachmentCreate(ctx, client, metaporClustertID, elementID, d.Timeout(schema.TimeoutCreate))
 
 	if err != nil {
-		return fmt.Errorf("Error waiting for metaport attachment creation (%s) (%s)", metaporClustertID, err)
+		return diag.Errorf("Error waiting for metaport attachment creation (%s) (%s)", metaporClustertID, err)
 	}
 
-	d.SetId(fmt.Sprintf("%s_%s", metaporClustertID, elementID))
+	d.SetId(compositeID(metaporClustertID, elementID))
 
-	return resourceMetaportClusterAttachmentRead(d, m)
+	return resourceMetaportClusterAttachmentRead(ctx, d, m)
 }
 
-func resourceMetaportClusterAttachmentRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportClusterAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	id := d.Get("id").(string)
-	ids := strings.Split(id, "_")
-	if len(ids) != 2 {
-		return fmt.Errorf("Error missing id for metaport cluster attachment got (%s)", id)
+	metaportClusterID, elementID, err := parseCompositeID(d.Id(), "<metaport_cluster_id>_<network_element_id>")
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	metaportClusterID := ids[0]
-	elementID := ids[1]
 
-	var metaportCluster *MetaportCluster
-	metaportCluster, err := client.GetMetaPortCluster(metaportClusterID)
+	var metaportCluster *sdk.MetaportCluster
+	metaportCluster, err = client.GetMetaPortCluster(ctx, metaportClusterID)
 	if err != nil {
-		return err
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Metaport Cluster Attachment %q because metaport cluster %q no longer exists", d.Id(), metaportClusterID)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -115,8 +110,8 @@ func resourceMetaportClusterAttachmentRead(d *schema.ResourceData, m interface{}
 	return nil
 }
 
-func resourceMetaportClusterAttachmentDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportClusterAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	elementID := d.Get("network_element_id").(string)
 	metaportClusterID := d.Get("metaport_cluster_id").(string)
@@ -124,33 +119,32 @@ func resourceMetaportClusterAttachmentDelete(d *schema.ResourceData, m interface
 	metanetworksMutexKV.Lock(metaportClusterID)
 	defer metanetworksMutexKV.Unlock(metaportClusterID)
 
-	var metaportCluster *MetaportCluster
-	metaportCluster, err := client.GetMetaPortCluster(metaportClusterID)
+	err := metaportClusterMappedElements(client).removeMember(ctx, metaportClusterID, elementID, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
-		return err
+		return diag.Errorf("Error in Metaport Cluster attachment deletion (%s) (%s)", metaportClusterID, err)
 	}
 
-	// Note that if the entry has already been deleted this won't fail.
-	for i := 0; i < len(metaportCluster.MappedElements); i++ {
-		if metaportCluster.MappedElements[i] == elementID {
-			metaportCluster.MappedElements = append(metaportCluster.MappedElements[:i], metaportCluster.MappedElements[i+1:]...)
-			break
-		}
-	}
+	return nil
+}
 
-	err = resource.Retry(5*time.Second, func() *resource.RetryError {
-		if _, err := client.UpdateMetaPortCluster(metaportClusterID, metaportCluster); err != nil {
-			if !strings.Contains(err.Error(), "is busy. Try again later.") {
-				return resource.NonRetryableError(err)
+// metaportClusterMappedElements is the list of network elements attached to a Metaport Cluster.
+func metaportClusterMappedElements(client sdk.MetaPortClustersAPI) *memberListAPI {
+	return &memberListAPI{
+		parent: "Metaport Cluster",
+		list:   "mapped elements",
+		get: func(ctx context.Context, metaportClusterID string) (*memberList, error) {
+			metaportCluster, err := client.GetMetaPortCluster(ctx, metaportClusterID)
+			if err != nil {
+				return nil, err
 			}
-			return resource.RetryableError(err)
-		}
-		return nil
-	})
-
-	if err != nil {
-		return fmt.Errorf("Error in Metaport Cluster attachment deletion (%s) (%s)", metaportClusterID, err)
+			return &memberList{members: metaportCluster.MappedElements, etag: metaportCluster.ETag, modifiedAt: metaportCluster.ModifiedAt}, nil
+		},
+		set: func(ctx context.Context, metaportClusterID string, members []string, ifMatch string) error {
+			_, err := client.UpdateMetaPortCluster(ctx, metaportClusterID, &sdk.UpdateMetaportClusterRequest{
+				MappedElements: sdk.StringsOrNull(members),
+				IfMatch:        ifMatch,
+			})
+			return err
+		},
 	}
-
-	return nil
 }
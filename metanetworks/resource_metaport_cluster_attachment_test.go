@@ -0,0 +1,151 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMetaportClusterAttachment_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_metaport_cluster_attachment.test.0"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_metaport_cluster_attachment", testAccGetMetaportClusterAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportClusterAttachmentConfig(rName, 2, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetMetaportClusterAttachment),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttrPair(resourceName, "metaport_cluster_id", "metanetworks_metaport_cluster.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "network_element_id", "metanetworks_mapped_subnets.test.0", "id"),
+				),
+			},
+			{
+				Config: testAccMetaportClusterAttachmentConfig(rName, 2, 1, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetMetaportClusterAttachment),
+					testAccCheckIDChanged(resourceName, &id, true),
+					resource.TestCheckResourceAttrPair(resourceName, "network_element_id", "metanetworks_mapped_subnets.test.1", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccMetaportClusterAttachment_disappears(t *testing.T) {
+	testAccSkipReplay(t)
+
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_metaport_cluster_attachment.test.0"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_metaport_cluster_attachment", testAccGetMetaportClusterAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportClusterAttachmentConfig(rName, 2, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetMetaportClusterAttachment),
+					testAccCheckDisappears(resourceName, testAccDeleteMetaportClusterAttachment),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestAccMetaportClusterAttachment_concurrent attaches several network elements to
+// the same Metaport cluster at once, none of them may be lost.
+func TestAccMetaportClusterAttachment_concurrent(t *testing.T) {
+	testAccSkipReplay(t)
+
+	rName := testAccRandomName(t)
+	parentName := "metanetworks_metaport_cluster.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_metaport_cluster_attachment", testAccGetMetaportClusterAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportClusterAttachmentConfig(rName, 3, 3, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(parentName, testAccMetaportClusterMappedElements, 3),
+					testAccCheckExists("metanetworks_metaport_cluster_attachment.test.0", testAccGetMetaportClusterAttachment),
+					testAccCheckExists("metanetworks_metaport_cluster_attachment.test.1", testAccGetMetaportClusterAttachment),
+					testAccCheckExists("metanetworks_metaport_cluster_attachment.test.2", testAccGetMetaportClusterAttachment),
+				),
+			},
+			{
+				Config: testAccMetaportClusterAttachmentConfig(rName, 3, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(parentName, testAccMetaportClusterMappedElements, 1),
+					testAccCheckExists("metanetworks_metaport_cluster_attachment.test.0", testAccGetMetaportClusterAttachment),
+				),
+			},
+			{
+				Config: testAccMetaportClusterAttachmentConfig(rName, 3, 0, 0),
+				Check:  testAccCheckMembers(parentName, testAccMetaportClusterMappedElements, 0),
+			},
+		},
+	})
+}
+
+var testAccGetMetaportClusterAttachment = testAccGetMember(testAccMetaportClusterMappedElements)
+
+func testAccMetaportClusterMappedElements(client sdk.API, ctx context.Context, id string) ([]string, error) {
+	metaportCluster, err := client.GetMetaPortCluster(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return metaportCluster.MappedElements, nil
+}
+
+// testAccDeleteMetaportClusterAttachment removes the network element from
+// the Metaport cluster out of band.
+func testAccDeleteMetaportClusterAttachment(client sdk.API, ctx context.Context, id string) error {
+	metaportClusterID, elementID, err := testAccSplitID(id)
+	if err != nil {
+		return err
+	}
+
+	metaportCluster, err := client.GetMetaPortCluster(ctx, metaportClusterID)
+	if err != nil {
+		return err
+	}
+	metaportCluster.MappedElements = testAccRemoveMember(metaportCluster.MappedElements, elementID)
+
+	_, err = client.UpdateMetaPortCluster(ctx, metaportClusterID, &sdk.UpdateMetaportClusterRequest{MappedElements: sdk.StringsOrNull(metaportCluster.MappedElements)})
+	return err
+}
+
+// testAccMetaportClusterAttachmentConfig attaches count of the elements mapped
+// subnets to a Metaport cluster, starting with the element at offset.
+func testAccMetaportClusterAttachmentConfig(rName string, elements, count, offset int) string {
+	return testAccMappedSubnetsElementsConfig(rName, elements) + fmt.Sprintf(`
+resource "metanetworks_metaport_cluster" "test" {
+  name = %[1]q
+}
+
+resource "metanetworks_metaport_cluster_attachment" "test" {
+  count               = %[2]d
+  metaport_cluster_id = metanetworks_metaport_cluster.test.id
+  network_element_id  = metanetworks_mapped_subnets.test[count.index + %[3]d].id
+}
+`, rName, count, offset)
+}
@@ -0,0 +1,20 @@
+package metanetworks
+
+import (
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceMetaportClusterMappedElements() *schema.Resource {
+	return resourceMemberList(&memberListResource{
+		description:       "Sets all the network elements of a Metaport cluster, removing those added outside of Terraform. It conflicts with `metanetworks_metaport_cluster_attachment`: use either this resource or attachments for a Metaport cluster, not both, or each apply undoes the changes of the other.",
+		parentKey:         "metaport_cluster_id",
+		listKey:           "mapped_elements",
+		parentDescription: "The ID of the Metaport cluster.",
+		listDescription:   "The IDs of the network elements mapped to the Metaport cluster.",
+		access: func(client sdk.API) *memberListAPI {
+			return metaportClusterMappedElements(client)
+		},
+	})
+}
@@ -0,0 +1,82 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMetaportCluster_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_metaport_cluster.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_metaport_cluster", testAccGetMetaportCluster),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportClusterConfig(rName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetMetaportCluster),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "mapped_elements.#", "0"),
+				),
+			},
+			{
+				Config: testAccMetaportClusterConfig(rName, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccMetaportCluster_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_metaport_cluster.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_metaport_cluster", testAccGetMetaportCluster),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportClusterConfig(rName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetMetaportCluster),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteMetaPortCluster),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccGetMetaportCluster(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetMetaPortCluster(ctx, id)
+	return err
+}
+
+func testAccMetaportClusterConfig(rName, description string) string {
+	return fmt.Sprintf(`
+resource "metanetworks_metaport_cluster" "test" {
+  name        = %[1]q
+  description = %[2]q
+}
+`, rName, description)
+}
@@ -0,0 +1,20 @@
+package metanetworks
+
+import (
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceMetaportMappedElements() *schema.Resource {
+	return resourceMemberList(&memberListResource{
+		description:       "Sets all the network elements of a Metaport, removing those added outside of Terraform. It conflicts with `metanetworks_metaport_attachment`: use either this resource or attachments for a Metaport, not both, or each apply undoes the changes of the other.",
+		parentKey:         "metaport_id",
+		listKey:           "mapped_elements",
+		parentDescription: "The ID of the Metaport.",
+		listDescription:   "The IDs of the network elements mapped to the Metaport.",
+		access: func(client sdk.API) *memberListAPI {
+			return metaportMappedElements(client)
+		},
+	})
+}
@@ -1,6 +1,11 @@
 package metanetworks
 
 import (
+	"context"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -25,32 +30,32 @@ func resourceMetaportOTAC() *schema.Resource {
 				Sensitive:   true,
 			},
 		},
-		Create: resourceMetaportOTACCreate,
-		Read:   resourceMetaportOTACRead,
-		Delete: resourceMetaportOTACDelete,
+		CreateContext: resourceMetaportOTACCreate,
+		ReadContext:   resourceMetaportOTACRead,
+		DeleteContext: resourceMetaportOTACDelete,
 	}
 }
 
-func resourceMetaportOTACCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceMetaportOTACCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	metaportID := d.Get("metaport_id").(string)
-	otacSecret, err := client.GenerateMetaPortOTAC(metaportID)
+	otacSecret, err := client.GenerateMetaPortOTAC(ctx, metaportID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("secret", otacSecret)
 	d.SetId(otacSecret[0:5])
 
-	return resourceMetaportOTACRead(d, m)
+	return resourceMetaportOTACRead(ctx, d, m)
 }
 
-func resourceMetaportOTACRead(d *schema.ResourceData, m interface{}) error {
+func resourceMetaportOTACRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// fire and forget. The code is valid for a short time, there is no state.
 	return nil
 }
 
-func resourceMetaportOTACDelete(d *schema.ResourceData, m interface{}) error {
+func resourceMetaportOTACDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	return nil
 }
@@ -0,0 +1,52 @@
+package metanetworks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMetaportOTAC_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_metaport_otac.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		// The OTAC is not kept by the API, only the Metaports are.
+		CheckDestroy: testAccCheckDestroy("metanetworks_metaport", testAccGetMetaport),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportOTACConfig(rName, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttrPair(resourceName, "metaport_id", "metanetworks_metaport.test.0", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "secret"),
+				),
+			},
+			{
+				Config: testAccMetaportOTACConfig(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, true),
+					resource.TestCheckResourceAttrPair(resourceName, "metaport_id", "metanetworks_metaport.test.1", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "secret"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMetaportOTACConfig(rName string, index int) string {
+	return fmt.Sprintf(`
+resource "metanetworks_metaport" "test" {
+  count = 2
+  name  = "%[1]s-${count.index}"
+}
+
+resource "metanetworks_metaport_otac" "test" {
+  metaport_id = metanetworks_metaport.test[%[2]d].id
+}
+`, rName, index)
+}
@@ -0,0 +1,86 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMetaport_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_metaport.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_metaport", testAccGetMetaport),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetMetaport),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "allow_support", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "dns_name"),
+				),
+			},
+			{
+				Config: testAccMetaportConfig(rName, "second", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccMetaport_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_metaport.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_metaport", testAccGetMetaport),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMetaportConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetMetaport),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteMetaPort),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccGetMetaport(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetMetaPort(ctx, id)
+	return err
+}
+
+func testAccMetaportConfig(rName, description string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "metanetworks_metaport" "test" {
+  name        = %[1]q
+  description = %[2]q
+  enabled     = %[3]t
+}
+`, rName, description, enabled)
+}
@@ -1,8 +1,12 @@
 package metanetworks
 
 import (
-	"fmt"
+	"context"
+	"log"
 
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -68,111 +72,128 @@ func resourceNativeService() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourceNativeServiceCreate,
-		Read:   resourceNativeServiceRead,
-		Update: resourceNativeServiceUpdate,
-		Delete: resourceNativeServiceDelete,
+		CreateContext: resourceNativeServiceCreate,
+		ReadContext:   resourceNativeServiceRead,
+		UpdateContext: resourceNativeServiceUpdate,
+		DeleteContext: resourceNativeServiceDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(networkElementCreateTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: importNetworkElement(networkElementTypeNativeService),
 		},
 	}
 }
 
-func resourceNativeServiceCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceNativeServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	enabled := d.Get("enabled").(bool)
 
-	networkElement := NetworkElement{
+	networkElement := sdk.CreateNetworkElementRequest{
 		Name:        name,
 		Description: description,
 		Enabled:     &enabled,
 	}
-	var newNativeService *NetworkElement
-	newNativeService, err := client.CreateNetworkElement(&networkElement)
+	var newNativeService *sdk.NetworkElement
+	newNativeService, err := client.CreateNetworkElement(ctx, &networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = WaitNetworkElementCreate(client, newNativeService.ID)
+	err = WaitNetworkElementCreate(ctx, client, newNativeService.ID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return fmt.Errorf("Error waiting for native service creation (%s) (%s)", newNativeService.ID, err)
+		return diag.Errorf("Error waiting for native service creation (%s) (%s)", newNativeService.ID, err)
 	}
 	d.SetId(newNativeService.ID)
 
 	err = nativeServiceToResource(d, newNativeService)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	err = client.SetNetworkElementTags(d)
+	err = setNetworkElementTags(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceNativeServiceRead(d, m)
+	return resourceNativeServiceRead(ctx, d, m)
 }
 
-func resourceNativeServiceRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceNativeServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	networkElement, err := client.GetNetworkElement(d.Id())
+	networkElement, err := client.GetNetworkElement(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Native Service %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
+	err = checkNetworkElementTypeUnchanged(networkElement, networkElementTypeNativeService)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	err = nativeServiceToResource(d, networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	err = readNetworkElementTags(ctx, client, d, networkElement)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceNativeServiceUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceNativeServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 	enabled := d.Get("enabled").(bool)
 
-	networkElement := NetworkElement{
-		Name:        name,
-		Description: description,
+	networkElement := sdk.UpdateNetworkElementRequest{
+		Name:        &name,
+		Description: &description,
 		Enabled:     &enabled,
 	}
-	var updatedNativeService *NetworkElement
-	updatedNativeService, err := client.UpdateNetworkElement(d.Id(), &networkElement)
+	var updatedNativeService *sdk.NetworkElement
+	updatedNativeService, err := client.UpdateNetworkElement(ctx, d.Id(), &networkElement)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = nativeServiceToResource(d, updatedNativeService)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	err = client.SetNetworkElementTags(d)
+	err = setNetworkElementTags(ctx, client, d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceNativeServiceRead(d, m)
+	return resourceNativeServiceRead(ctx, d, m)
 }
 
-func resourceNativeServiceDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceNativeServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteNetworkElement(d.Id())
+	err := client.DeleteNetworkElement(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func nativeServiceToResource(d *schema.ResourceData, m *NetworkElement) error {
+func nativeServiceToResource(d *schema.ResourceData, m *sdk.NetworkElement) error {
 	d.Set("name", m.Name)
 	d.Set("description", m.Description)
 	d.Set("enabled", m.Enabled)
@@ -1,9 +1,12 @@
 package metanetworks
 
 import (
-	"errors"
-	"fmt"
+	"context"
+	"log"
 
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -26,60 +29,87 @@ func resourceNativeServiceAlias() *schema.Resource {
 				ForceNew: true,
 			},
 		},
-		Create: resourceNativeServiceAliasCreate,
-		Read:   resourceNativeServiceAliasRead,
-		Delete: resourceNativeServiceAliasDelete,
+		CreateContext: resourceNativeServiceAliasCreate,
+		ReadContext:   resourceNativeServiceAliasRead,
+		DeleteContext: resourceNativeServiceAliasDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importCompositeID("native_service_id", "alias", checkAlias),
+		},
 	}
 }
 
-func resourceNativeServiceAliasCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceNativeServiceAliasCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	nativeServiceID := d.Get("native_service_id").(string)
 	alias := d.Get("alias").(string)
 
-	var networkElement *NetworkElement
-	networkElement, err := client.GetNetworkElement(nativeServiceID)
+	var networkElement *sdk.NetworkElement
+	networkElement, err := client.GetNetworkElement(ctx, nativeServiceID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	for i := 0; i < len(networkElement.Aliases); i++ {
 		if networkElement.Aliases[i] == alias {
-			return errors.New("That is alias is already present on the Native Service")
+			return diag.Errorf("That is alias is already present on the Native Service")
 		}
 	}
 
-	_, err = client.SetNetworkElementAlias(nativeServiceID, alias)
+	_, err = client.SetNetworkElementAlias(ctx, nativeServiceID, alias)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	d.SetId(fmt.Sprintf("%s_%s", nativeServiceID, alias))
+	d.SetId(compositeID(nativeServiceID, alias))
 
-	return resourceNativeServiceAliasRead(d, m)
+	return resourceNativeServiceAliasRead(ctx, d, m)
 }
 
-func resourceNativeServiceAliasRead(d *schema.ResourceData, m interface{}) error {
+func resourceNativeServiceAliasRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
+
+	nativeServiceID := d.Get("native_service_id").(string)
+	alias := d.Get("alias").(string)
+
+	var networkElement *sdk.NetworkElement
+	networkElement, err := client.GetNetworkElement(ctx, nativeServiceID)
+	if err != nil {
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Native Service Alias %q because native service %q no longer exists", d.Id(), nativeServiceID)
+			d.SetId("")
+
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	for i := 0; i < len(networkElement.Aliases); i++ {
+		if networkElement.Aliases[i] == alias {
+			return nil
+		}
+	}
+	log.Printf("[WARN] Removing Native Service Alias %q because it's gone", d.Id())
+	d.SetId("")
 	return nil
 }
 
-func resourceNativeServiceAliasDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceNativeServiceAliasDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	nativeServiceID := d.Get("native_service_id").(string)
 	alias := d.Get("alias").(string)
-	var networkElement *NetworkElement
-	networkElement, err := client.GetNetworkElement(nativeServiceID)
+	var networkElement *sdk.NetworkElement
+	networkElement, err := client.GetNetworkElement(ctx, nativeServiceID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	for i := 0; i < len(networkElement.Aliases); i++ {
 		if networkElement.Aliases[i] == alias {
-			_, err = client.DeleteNetworkElementAlias(nativeServiceID, alias)
+			_, err = client.DeleteNetworkElementAlias(ctx, nativeServiceID, alias)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -0,0 +1,78 @@
+package metanetworks
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNativeServiceAlias_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_native_service_alias.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_native_service_alias", testAccGetNetworkElementAlias),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNativeServiceAliasConfig(rName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElementAlias),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttrPair(resourceName, "native_service_id", "metanetworks_native_service.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "alias", "first."+rName+".example.com"),
+				),
+			},
+			{
+				Config: testAccNativeServiceAliasConfig(rName, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElementAlias),
+					testAccCheckIDChanged(resourceName, &id, true),
+					resource.TestCheckResourceAttr(resourceName, "alias", "second."+rName+".example.com"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNativeServiceAlias_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_native_service_alias.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_native_service_alias", testAccGetNetworkElementAlias),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNativeServiceAliasConfig(rName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElementAlias),
+					testAccCheckDisappears(resourceName, testAccDeleteNetworkElementAlias),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccNativeServiceAliasConfig(rName, prefix string) string {
+	return fmt.Sprintf(`
+resource "metanetworks_native_service" "test" {
+  name = %[1]q
+}
+
+resource "metanetworks_native_service_alias" "test" {
+  native_service_id = metanetworks_native_service.test.id
+  alias             = "%[2]s.%[1]s.example.com"
+}
+`, rName, prefix)
+}
@@ -0,0 +1,113 @@
+package metanetworks
+
+import (
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNativeService_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_native_service.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_native_service", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNativeServiceConfig(rName, "first", true, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "blue"),
+					resource.TestCheckResourceAttrSet(resourceName, "dns_name"),
+				),
+			},
+			{
+				Config: testAccNativeServiceConfig(rName, "second", false, "green"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "green"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNativeService_tagsDrift(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_native_service.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_native_service", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNativeServiceConfig(rName, "first", true, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckNetworkElementTagsChanged(resourceName, map[string]string{"color": "red", "owner": "portal"}),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccNativeServiceConfig(rName, "first", true, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.color", "blue"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNativeService_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_native_service.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_native_service", testAccGetNetworkElement),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNativeServiceConfig(rName, "first", true, "blue"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetNetworkElement),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteNetworkElement),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccNativeServiceConfig(rName, description string, enabled bool, color string) string {
+	return fmt.Sprintf(`
+resource "metanetworks_native_service" "test" {
+  name        = %[1]q
+  description = %[2]q
+  enabled     = %[3]t
+
+  tags = {
+    color = %[4]q
+  }
+}
+`, rName, description, enabled, color)
+}
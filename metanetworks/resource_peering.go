@@ -1,6 +1,12 @@
 package metanetworks
 
 import (
+	"context"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -56,111 +62,113 @@ func resourcePeering() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourcePeeringCreate,
-		Read:   resourcePeeringRead,
-		Update: resourcePeeringUpdate,
-		Delete: resourcePeeringDelete,
+		CreateContext: resourcePeeringCreate,
+		ReadContext:   resourcePeeringRead,
+		UpdateContext: resourcePeeringUpdate,
+		DeleteContext: resourcePeeringDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourcePeeringCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePeeringCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	description := d.Get("description").(string)
 	egressNAT := d.Get("egress_nat").(bool)
 	enabled := d.Get("enabled").(bool)
 	name := d.Get("name").(string)
-	peers := resourceTypeSetToStringSlice(d.Get("peers").(*schema.Set))
 
-	peering := Peering{
+	peering := sdk.CreatePeeringRequest{
 		Description: description,
 		EgressNAT:   egressNAT,
 		Enabled:     enabled,
 		Name:        name,
-		Peers:       peers,
 	}
 
-	var newPeering *Peering
-	newPeering, err := client.CreatePeering(&peering)
+	var newPeering *sdk.Peering
+	newPeering, err := client.CreatePeering(ctx, &peering)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(newPeering.ID)
 
 	err = peeringToResource(d, newPeering)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourcePeeringRead(d, m)
+	return resourcePeeringRead(ctx, d, m)
 }
 
-func resourcePeeringRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePeeringRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	peering, err := client.GetPeering(d.Id())
+	peering, err := client.GetPeering(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Peering %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = peeringToResource(d, peering)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourcePeeringUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePeeringUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	description := d.Get("description").(string)
 	egressNAT := d.Get("egress_nat").(bool)
 	enabled := d.Get("enabled").(bool)
 	name := d.Get("name").(string)
-	peers := resourceTypeSetToStringSlice(d.Get("peers").(*schema.Set))
 
-	peering := Peering{
-		Description: description,
-		EgressNAT:   egressNAT,
-		Enabled:     enabled,
-		Name:        name,
-		Peers:       peers,
+	// The peers are owned by the attachment and member list resources, so
+	// they're left out to keep the server's list intact.
+	peering := sdk.UpdatePeeringRequest{
+		Description: &description,
+		EgressNAT:   &egressNAT,
+		Enabled:     &enabled,
+		Name:        &name,
 	}
 
-	var updatedPeering *Peering
-	updatedPeering, err := client.UpdatePeering(d.Id(), &peering)
+	var updatedPeering *sdk.Peering
+	updatedPeering, err := client.UpdatePeering(ctx, d.Id(), &peering)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(updatedPeering.ID)
 
 	err = peeringToResource(d, updatedPeering)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourcePeeringRead(d, m)
+	return resourcePeeringRead(ctx, d, m)
 }
 
-func resourcePeeringDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePeeringDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeletePeering(d.Id())
+	err := client.DeletePeering(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func peeringToResource(d *schema.ResourceData, m *Peering) error {
+func peeringToResource(d *schema.ResourceData, m *sdk.Peering) error {
 	d.Set("description", m.Description)
 	d.Set("egress_nat", m.EgressNAT)
 	d.Set("enabled", m.Enabled)
@@ -1,14 +1,18 @@
 package metanetworks
 
 import (
-	"errors"
-	"fmt"
+	"context"
+	"log"
 
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourcePeeringAttachment() *schema.Resource {
 	return &schema.Resource{
+		Description: "Adds a network element to the peers of a peering, leaving the other peers alone. Don't use it for a peering whose peers are set by `metanetworks_peering_peers`, which removes those it doesn't list. The attachment is written with the ETag of the peering when the API returns one. Otherwise the peering is read again right before the write and its `modified_at` compared, which leaves a short window: a change to its peers made by someone else between that read and the write is overwritten.",
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The ID of the peering attachment.",
@@ -28,14 +32,23 @@ func resourcePeeringAttachment() *schema.Resource {
 				ForceNew:    true,
 			},
 		},
-		Create: resourcePeeringAttachmentCreate,
-		Read:   resourcePeeringAttachmentRead,
-		Delete: resourcePeeringAttachmentDelete,
+		CreateContext: resourcePeeringAttachmentCreate,
+		ReadContext:   resourcePeeringAttachmentRead,
+		DeleteContext: resourcePeeringAttachmentDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importCompositeID("peering_id", "network_element_id", func(ctx context.Context, client sdk.API, peeringID, elementID string) error {
+				return peeringPeers(client).checkMember(ctx, peeringID, elementID)
+			}),
+		},
 	}
 }
 
-func resourcePeeringAttachmentCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePeeringAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	elementID := d.Get("network_element_id").(string)
 	peeringID := d.Get("peering_id").(string)
@@ -43,40 +56,33 @@ func resourcePeeringAttachmentCreate(d *schema.ResourceData, m interface{}) erro
 	metanetworksMutexKV.Lock(peeringID)
 	defer metanetworksMutexKV.Unlock(peeringID)
 
-	var peering *Peering
-	peering, err := client.GetPeering(peeringID)
-	if err != nil {
-		return err
-	}
-
-	for i := 0; i < len(peering.Peers); i++ {
-		if peering.Peers[i] == elementID {
-			return errors.New("That network element is already mapped to this Peering")
-		}
-
-	}
-
-	peering.Peers = append(peering.Peers, elementID)
-	_, err = client.UpdatePeering(peeringID, peering)
+	err := peeringPeers(client).addMember(ctx, peeringID, elementID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	d.SetId(fmt.Sprintf("%s_%s", peeringID, elementID))
+	d.SetId(compositeID(peeringID, elementID))
 
-	return resourcePeeringAttachmentRead(d, m)
+	return resourcePeeringAttachmentRead(ctx, d, m)
 }
 
-func resourcePeeringAttachmentRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePeeringAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	elementID := d.Get("network_element_id").(string)
-	peeringID := d.Get("peering_id").(string)
+	peeringID, elementID, err := parseCompositeID(d.Id(), "<peering_id>_<network_element_id>")
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	var peering *Peering
-	peering, err := client.GetPeering(peeringID)
+	var peering *sdk.Peering
+	peering, err = client.GetPeering(ctx, peeringID)
 	if err != nil {
-		return err
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Peering Attachment %q because peering %q no longer exists", d.Id(), peeringID)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -91,13 +97,16 @@ func resourcePeeringAttachmentRead(d *schema.ResourceData, m interface{}) error
 	// If not present we need to destroy the terraform resource so that it is recreated.
 	if !found {
 		d.SetId("")
+	} else {
+		d.Set("network_element_id", elementID)
+		d.Set("peering_id", peeringID)
 	}
 
 	return nil
 }
 
-func resourcePeeringAttachmentDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePeeringAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	elementID := d.Get("network_element_id").(string)
 	peeringID := d.Get("peering_id").(string)
@@ -105,20 +114,28 @@ func resourcePeeringAttachmentDelete(d *schema.ResourceData, m interface{}) erro
 	metanetworksMutexKV.Lock(peeringID)
 	defer metanetworksMutexKV.Unlock(peeringID)
 
-	var peering *Peering
-	peering, err := client.GetPeering(peeringID)
-	if err != nil {
-		return err
-	}
+	err := peeringPeers(client).removeMember(ctx, peeringID, elementID, d.Timeout(schema.TimeoutDelete))
+	return diag.FromErr(err)
+}
 
-	// Note that if the entry has already been deleted this won't fail.
-	for i := 0; i < len(peering.Peers); i++ {
-		if peering.Peers[i] == elementID {
-			peering.Peers = append(peering.Peers[:i], peering.Peers[i+1:]...)
-			break
-		}
+// peeringPeers is the list of network elements attached to a Peering.
+func peeringPeers(client sdk.PeeringsAPI) *memberListAPI {
+	return &memberListAPI{
+		parent: "Peering",
+		list:   "peers",
+		get: func(ctx context.Context, peeringID string) (*memberList, error) {
+			peering, err := client.GetPeering(ctx, peeringID)
+			if err != nil {
+				return nil, err
+			}
+			return &memberList{members: peering.Peers, etag: peering.ETag, modifiedAt: peering.ModifiedAt}, nil
+		},
+		set: func(ctx context.Context, peeringID string, members []string, ifMatch string) error {
+			_, err := client.UpdatePeering(ctx, peeringID, &sdk.UpdatePeeringRequest{
+				Peers:   sdk.StringsOrNull(members),
+				IfMatch: ifMatch,
+			})
+			return err
+		},
 	}
-
-	_, err = client.UpdatePeering(peeringID, peering)
-	return err
 }
@@ -0,0 +1,175 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPeeringAttachment_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_peering_attachment.test.0"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_peering_attachment", testAccGetPeeringAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPeeringAttachmentConfig(rName, 2, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetPeeringAttachment),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttrPair(resourceName, "peering_id", "metanetworks_peering.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "network_element_id", "metanetworks_mapped_subnets.test.0", "id"),
+				),
+			},
+			{
+				Config: testAccPeeringAttachmentConfig(rName, 2, 1, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetPeeringAttachment),
+					testAccCheckIDChanged(resourceName, &id, true),
+					resource.TestCheckResourceAttrPair(resourceName, "network_element_id", "metanetworks_mapped_subnets.test.1", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "pe-123",
+				ExpectError:   regexp.MustCompile(`Invalid ID "pe-123", expected <peering_id>_<network_element_id>`),
+			},
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					peering, err := testAccPrimary(s, "metanetworks_peering.test")
+					if err != nil {
+						return "", err
+					}
+					detached, err := testAccPrimary(s, "metanetworks_mapped_subnets.test.0")
+					if err != nil {
+						return "", err
+					}
+					return compositeID(peering.ID, detached.ID), nil
+				},
+				ExpectError: regexp.MustCompile(`is not in the peers of Peering`),
+			},
+		},
+	})
+}
+
+func TestAccPeeringAttachment_disappears(t *testing.T) {
+	testAccSkipReplay(t)
+
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_peering_attachment.test.0"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_peering_attachment", testAccGetPeeringAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPeeringAttachmentConfig(rName, 2, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetPeeringAttachment),
+					testAccCheckDisappears(resourceName, testAccDeletePeeringAttachment),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestAccPeeringAttachment_concurrent attaches several network elements to
+// the same peering at once, none of them may be lost.
+func TestAccPeeringAttachment_concurrent(t *testing.T) {
+	testAccSkipReplay(t)
+
+	rName := testAccRandomName(t)
+	parentName := "metanetworks_peering.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_peering_attachment", testAccGetPeeringAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPeeringAttachmentConfig(rName, 3, 3, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(parentName, testAccPeeringPeers, 3),
+					testAccCheckExists("metanetworks_peering_attachment.test.0", testAccGetPeeringAttachment),
+					testAccCheckExists("metanetworks_peering_attachment.test.1", testAccGetPeeringAttachment),
+					testAccCheckExists("metanetworks_peering_attachment.test.2", testAccGetPeeringAttachment),
+				),
+			},
+			{
+				Config: testAccPeeringAttachmentConfig(rName, 3, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(parentName, testAccPeeringPeers, 1),
+					testAccCheckExists("metanetworks_peering_attachment.test.0", testAccGetPeeringAttachment),
+				),
+			},
+			{
+				Config: testAccPeeringAttachmentConfig(rName, 3, 0, 0),
+				Check:  testAccCheckMembers(parentName, testAccPeeringPeers, 0),
+			},
+		},
+	})
+}
+
+var testAccGetPeeringAttachment = testAccGetMember(testAccPeeringPeers)
+
+func testAccPeeringPeers(client sdk.API, ctx context.Context, id string) ([]string, error) {
+	peering, err := client.GetPeering(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return peering.Peers, nil
+}
+
+// testAccDeletePeeringAttachment removes the network element from the peering
+// out of band.
+func testAccDeletePeeringAttachment(client sdk.API, ctx context.Context, id string) error {
+	peeringID, elementID, err := testAccSplitID(id)
+	if err != nil {
+		return err
+	}
+
+	peering, err := client.GetPeering(ctx, peeringID)
+	if err != nil {
+		return err
+	}
+	peering.Peers = testAccRemoveMember(peering.Peers, elementID)
+
+	_, err = client.UpdatePeering(ctx, peeringID, &sdk.UpdatePeeringRequest{Peers: sdk.StringsOrNull(peering.Peers)})
+	return err
+}
+
+// testAccPeeringAttachmentConfig attaches count of the elements mapped
+// subnets to a peering, starting with the element at offset.
+func testAccPeeringAttachmentConfig(rName string, elements, count, offset int) string {
+	return testAccMappedSubnetsElementsConfig(rName, elements) + fmt.Sprintf(`
+resource "metanetworks_peering" "test" {
+  name = %[1]q
+}
+
+resource "metanetworks_peering_attachment" "test" {
+  count              = %[2]d
+  peering_id         = metanetworks_peering.test.id
+  network_element_id = metanetworks_mapped_subnets.test[count.index + %[3]d].id
+}
+`, rName, count, offset)
+}
@@ -0,0 +1,20 @@
+package metanetworks
+
+import (
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePeeringPeers() *schema.Resource {
+	return resourceMemberList(&memberListResource{
+		description:       "Sets all the peers of a peering, removing those added outside of Terraform. It conflicts with `metanetworks_peering_attachment`: use either this resource or attachments for a peering, not both, or each apply undoes the changes of the other.",
+		parentKey:         "peering_id",
+		listKey:           "peers",
+		parentDescription: "The ID of the peering.",
+		listDescription:   "The IDs of the network elements peered.",
+		access: func(client sdk.API) *memberListAPI {
+			return peeringPeers(client)
+		},
+	})
+}
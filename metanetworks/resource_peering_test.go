@@ -0,0 +1,87 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPeering_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_peering.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_peering", testAccGetPeering),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPeeringConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetPeering),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "egress_nat", "true"),
+				),
+			},
+			{
+				Config: testAccPeeringConfig(rName, "second", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "egress_nat", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPeering_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_peering.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_peering", testAccGetPeering),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPeeringConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetPeering),
+					testAccCheckDisappears(resourceName, sdk.API.DeletePeering),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccGetPeering(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetPeering(ctx, id)
+	return err
+}
+
+func testAccPeeringConfig(rName, description string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "metanetworks_peering" "test" {
+  name        = %[1]q
+  description = %[2]q
+  enabled     = %[3]t
+  egress_nat  = %[3]t
+}
+`, rName, description, enabled)
+}
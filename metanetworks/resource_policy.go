@@ -1,8 +1,12 @@
 package metanetworks
 
 import (
-	"fmt"
+	"context"
+	"log"
 
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -70,18 +74,23 @@ func resourcePolicy() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourcePolicyCreate,
-		Read:   resourcePolicyRead,
-		Update: resourcePolicyUpdate,
-		Delete: resourcePolicyDelete,
+		CreateContext: resourcePolicyCreate,
+		ReadContext:   resourcePolicyRead,
+		UpdateContext: resourcePolicyUpdate,
+		DeleteContext: resourcePolicyDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(policyCreateTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -91,7 +100,7 @@ func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
 	destinations := resourceTypeSetToStringSlice(d.Get("destinations").(*schema.Set))
 	protocolGroups := resourceTypeSetToStringSlice(d.Get("protocol_groups").(*schema.Set))
 
-	policy := Policy{
+	policy := sdk.CreatePolicyRequest{
 		Name:           name,
 		Description:    description,
 		Enabled:        enabled,
@@ -101,46 +110,50 @@ func resourcePolicyCreate(d *schema.ResourceData, m interface{}) error {
 		ProtocolGroups: protocolGroups,
 	}
 
-	var newPolicy *Policy
-	newPolicy, err := client.CreatePolicy(&policy)
+	var newPolicy *sdk.Policy
+	newPolicy, err := client.CreatePolicy(ctx, &policy)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = WaitPolicyCreate(client, newPolicy.ID)
+	err = WaitPolicyCreate(ctx, client, newPolicy.ID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return fmt.Errorf("Error waiting for policy creation (%s) (%s)", newPolicy.ID, err)
+		return diag.Errorf("Error waiting for policy creation (%s) (%s)", newPolicy.ID, err)
 	}
 
 	d.SetId(newPolicy.ID)
 
 	err = policyToResource(d, newPolicy)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourcePolicyRead(d, m)
+	return resourcePolicyRead(ctx, d, m)
 }
 
-func resourcePolicyRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	policy, err := client.GetPolicy(d.Id())
+	policy, err := client.GetPolicy(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Policy %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = policyToResource(d, policy)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -150,44 +163,44 @@ func resourcePolicyUpdate(d *schema.ResourceData, m interface{}) error {
 	destinations := resourceTypeSetToStringSlice(d.Get("destinations").(*schema.Set))
 	protocolGroups := resourceTypeSetToStringSlice(d.Get("protocol_groups").(*schema.Set))
 
-	policy := Policy{
-		Name:           name,
-		Description:    description,
-		Enabled:        enabled,
-		Destinations:   destinations,
-		ExemptSources:  exemptSources,
-		Sources:        sources,
-		ProtocolGroups: protocolGroups,
+	policy := sdk.UpdatePolicyRequest{
+		Name:           &name,
+		Description:    &description,
+		Enabled:        &enabled,
+		Destinations:   sdk.StringsOrNull(destinations),
+		ExemptSources:  sdk.StringsOrNull(exemptSources),
+		Sources:        sdk.StringsOrNull(sources),
+		ProtocolGroups: sdk.StringsOrNull(protocolGroups),
 	}
 
-	var updatedPolicy *Policy
-	updatedPolicy, err := client.UpdatePolicy(d.Id(), &policy)
+	var updatedPolicy *sdk.Policy
+	updatedPolicy, err := client.UpdatePolicy(ctx, d.Id(), &policy)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(updatedPolicy.ID)
 
 	err = policyToResource(d, updatedPolicy)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourcePolicyRead(d, m)
+	return resourcePolicyRead(ctx, d, m)
 }
 
-func resourcePolicyDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeletePolicy(d.Id())
+	err := client.DeletePolicy(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func policyToResource(d *schema.ResourceData, m *Policy) error {
+func policyToResource(d *schema.ResourceData, m *sdk.Policy) error {
 	d.Set("description", m.Description)
 	d.Set("name", m.Name)
 	d.Set("destinations", m.Destinations)
@@ -0,0 +1,104 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPolicy_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_policy.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_policy", testAccGetPolicy),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetPolicy),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "sources.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "destinations.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "protocol_groups.#", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+				),
+			},
+			{
+				Config: testAccPolicyConfig(rName, "second", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPolicy_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_policy.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_policy", testAccGetPolicy),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPolicyConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetPolicy),
+					testAccCheckDisappears(resourceName, sdk.API.DeletePolicy),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccGetPolicy(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetPolicy(ctx, id)
+	return err
+}
+
+func testAccPolicyConfig(rName, description string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "metanetworks_group" "test" {
+  name = %[1]q
+}
+
+resource "metanetworks_mapped_subnets" "test" {
+  name           = %[1]q
+  mapped_subnets = ["10.20.0.0/24"]
+}
+
+data "metanetworks_protocol_group" "https" {
+  name_regex = "^HTTPS$"
+}
+
+resource "metanetworks_policy" "test" {
+  name            = %[1]q
+  description     = %[2]q
+  enabled         = %[3]t
+  sources         = [metanetworks_group.test.id]
+  destinations    = [metanetworks_mapped_subnets.test.id]
+  protocol_groups = [data.metanetworks_protocol_group.https.id]
+}
+`, rName, description, enabled)
+}
@@ -1,9 +1,26 @@
 package metanetworks
 
 import (
+	"context"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// postureCheckFactorTypes are the types of the checks of a posture check.
+var postureCheckFactorTypes = []string{
+	"jailbroken_rooted",
+	"screen_lock_enabled",
+	"minimum_app_version",
+	"minimum_os_version",
+	"malicious_app_detection",
+	"developer_mode_enabled",
+}
+
 func resourcePostureCheck() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -58,9 +75,10 @@ func resourcePostureCheck() *schema.Resource {
 							Optional:    true,
 						},
 						"type": {
-							Description: "Values: `jailbroken_rooted`, `screen_lock_enabled`, `minimum_app_version`, `minimum_os_version`, `malicious_app_detection`, `developer_mode_enabled`.",
-							Type:        schema.TypeString,
-							Required:    true,
+							Description:  "Values: `jailbroken_rooted`, `screen_lock_enabled`, `minimum_app_version`, `minimum_os_version`, `malicious_app_detection`, `developer_mode_enabled`.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(postureCheckFactorTypes, false),
 						},
 					},
 				},
@@ -107,18 +125,18 @@ func resourcePostureCheck() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourcePostureCheckCreate,
-		Read:   resourcePostureCheckRead,
-		Update: resourcePostureCheckUpdate,
-		Delete: resourcePostureCheckDelete,
+		CreateContext: resourcePostureCheckCreate,
+		ReadContext:   resourcePostureCheckRead,
+		UpdateContext: resourcePostureCheckUpdate,
+		DeleteContext: resourcePostureCheckDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourcePostureCheckCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePostureCheckCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -129,12 +147,12 @@ func resourcePostureCheckCreate(d *schema.ResourceData, m interface{}) error {
 	enabled := d.Get("enabled").(bool)
 	applyToOrg := d.Get("apply_to_org").(bool)
 	interval := d.Get("interval").(int)
-	check := d.Get("check").([]interface{})
+	check := expandPostureCheckFactors(d.Get("check").([]interface{}))
 	when := resourceTypeSetToStringSlice(d.Get("when").(*schema.Set))
 	applyToEntities := resourceTypeSetToStringSlice(d.Get("sources").(*schema.Set))
 	exemptEntities := resourceTypeSetToStringSlice(d.Get("exempt_sources").(*schema.Set))
 
-	postureCheck := PostureCheck{
+	postureCheck := sdk.CreatePostureCheckRequest{
 		Name:              name,
 		Description:       description,
 		Action:            action,
@@ -150,41 +168,45 @@ func resourcePostureCheckCreate(d *schema.ResourceData, m interface{}) error {
 		ApplyToEntities:   applyToEntities,
 	}
 
-	var newPostureCheck *PostureCheck
-	newPostureCheck, err := client.CreatePostureCheck(&postureCheck)
+	var newPostureCheck *sdk.PostureCheck
+	newPostureCheck, err := client.CreatePostureCheck(ctx, &postureCheck)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(newPostureCheck.ID)
 
 	err = postureCheckToResource(d, newPostureCheck)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourcePostureCheckRead(d, m)
+	return resourcePostureCheckRead(ctx, d, m)
 }
 
-func resourcePostureCheckRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePostureCheckRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	postureCheck, err := client.GetPostureCheck(d.Id())
+	postureCheck, err := client.GetPostureCheck(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Posture Check %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = postureCheckToResource(d, postureCheck)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourcePostureCheckUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePostureCheckUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -195,55 +217,55 @@ func resourcePostureCheckUpdate(d *schema.ResourceData, m interface{}) error {
 	enabled := d.Get("enabled").(bool)
 	applyToOrg := d.Get("apply_to_org").(bool)
 	interval := d.Get("interval").(int)
-	check := d.Get("check").([]interface{})
+	check := expandPostureCheckFactors(d.Get("check").([]interface{}))
 	when := resourceTypeSetToStringSlice(d.Get("when").(*schema.Set))
 	applyToEntities := resourceTypeSetToStringSlice(d.Get("sources").(*schema.Set))
 	exemptEntities := resourceTypeSetToStringSlice(d.Get("exempt_sources").(*schema.Set))
 
-	postureCheck := PostureCheck{
-		Name:              name,
-		Description:       description,
-		Action:            action,
-		OSQuery:           osQuery,
-		Platform:          platform,
-		UserMessageOnFail: userMessageOnFail,
-		Enabled:           enabled,
-		ApplyToOrg:        applyToOrg,
-		Interval:          interval,
-		Check:             check,
-		When:              when,
-		ExemptEntities:    exemptEntities,
-		ApplyToEntities:   applyToEntities,
+	postureCheck := sdk.UpdatePostureCheckRequest{
+		Name:              &name,
+		Description:       sdk.StringOrNull(description),
+		Action:            &action,
+		OSQuery:           sdk.StringOrNull(osQuery),
+		Platform:          &platform,
+		UserMessageOnFail: sdk.StringOrNull(userMessageOnFail),
+		Enabled:           &enabled,
+		ApplyToOrg:        &applyToOrg,
+		Interval:          sdk.IntOrNull(interval),
+		Check:             &check,
+		When:              sdk.StringsOrNull(when),
+		ExemptEntities:    sdk.StringsOrNull(exemptEntities),
+		ApplyToEntities:   sdk.StringsOrNull(applyToEntities),
 	}
 
-	var updatedPostureCheck *PostureCheck
-	updatedPostureCheck, err := client.UpdatePostureCheck(d.Id(), &postureCheck)
+	var updatedPostureCheck *sdk.PostureCheck
+	updatedPostureCheck, err := client.UpdatePostureCheck(ctx, d.Id(), &postureCheck)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(updatedPostureCheck.ID)
 
 	err = postureCheckToResource(d, updatedPostureCheck)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourcePostureCheckRead(d, m)
+	return resourcePostureCheckRead(ctx, d, m)
 }
 
-func resourcePostureCheckDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourcePostureCheckDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeletePostureCheck(d.Id())
+	err := client.DeletePostureCheck(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func postureCheckToResource(d *schema.ResourceData, m *PostureCheck) error {
+func postureCheckToResource(d *schema.ResourceData, m *sdk.PostureCheck) error {
 	d.Set("description", m.Description)
 	d.Set("name", m.Name)
 	d.Set("action", m.Action)
@@ -253,10 +275,10 @@ func postureCheckToResource(d *schema.ResourceData, m *PostureCheck) error {
 	d.Set("apply_to_org", m.ApplyToOrg)
 	d.Set("user_message_on_fail", m.UserMessageOnFail)
 	d.Set("interval", m.Interval)
-	d.Set("check", m.Check)
+	d.Set("check", flattenPostureCheckFactors(m.Check))
 	d.Set("when", m.When)
-	d.Set("exempt_entities", m.ExemptEntities)
-	d.Set("apply_to_entities", m.ApplyToEntities)
+	d.Set("exempt_sources", m.ExemptEntities)
+	d.Set("sources", m.ApplyToEntities)
 	d.Set("created_at", m.CreatedAt)
 	d.Set("modified_at", m.ModifiedAt)
 
@@ -264,3 +286,31 @@ func postureCheckToResource(d *schema.ResourceData, m *PostureCheck) error {
 
 	return nil
 }
+
+func flattenPostureCheckFactors(in []sdk.PostureCheckFactor) []map[string]interface{} {
+	var out = make([]map[string]interface{}, len(in), len(in))
+	for i, v := range in {
+		m := make(map[string]interface{})
+		m["type"] = v.Type
+		m["min_version"] = v.MinVersion
+		out[i] = m
+	}
+	return out
+}
+
+func expandPostureCheckFactors(data []interface{}) []sdk.PostureCheckFactor {
+	factors := make([]sdk.PostureCheckFactor, 0, len(data))
+	for _, d := range data {
+		m, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		factors = append(factors, sdk.PostureCheckFactor{
+			Type:       m["type"].(string),
+			MinVersion: m["min_version"].(string),
+		})
+	}
+
+	return factors
+}
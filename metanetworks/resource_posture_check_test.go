@@ -0,0 +1,106 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPostureCheck_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_posture_check.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_posture_check", testAccGetPostureCheck),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostureCheckConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetPostureCheck),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "action", "DISCONNECT"),
+					resource.TestCheckResourceAttr(resourceName, "platform", "macOS"),
+					resource.TestCheckResourceAttr(resourceName, "when.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "when.*", "PRE_CONNECT"),
+					resource.TestCheckResourceAttr(resourceName, "apply_to_org", "true"),
+					resource.TestCheckResourceAttr(resourceName, "check.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "check.0.type", "minimum_os_version"),
+					resource.TestCheckResourceAttr(resourceName, "check.0.min_version", "11.0"),
+					resource.TestCheckResourceAttr(resourceName, "check.1.type", "screen_lock_enabled"),
+				),
+			},
+			{
+				Config: testAccPostureCheckConfig(rName, "second", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPostureCheck_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_posture_check.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_posture_check", testAccGetPostureCheck),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPostureCheckConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetPostureCheck),
+					testAccCheckDisappears(resourceName, sdk.API.DeletePostureCheck),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccGetPostureCheck(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetPostureCheck(ctx, id)
+	return err
+}
+
+func testAccPostureCheckConfig(rName, description string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "metanetworks_posture_check" "test" {
+  name        = %[1]q
+  description = %[2]q
+  enabled     = %[3]t
+  action      = "DISCONNECT"
+  platform    = "macOS"
+  when        = ["PRE_CONNECT"]
+  osquery     = "select * from os_version where major >= 11;"
+
+  check {
+    type        = "minimum_os_version"
+    min_version = "11.0"
+  }
+
+  check {
+    type = "screen_lock_enabled"
+  }
+}
+`, rName, description, enabled)
+}
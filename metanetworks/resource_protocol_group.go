@@ -1,8 +1,12 @@
 package metanetworks
 
 import (
-	"fmt"
+	"context"
+	"log"
 
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -68,23 +72,28 @@ func resourceProtocolGroup() *schema.Resource {
 				Computed: true,
 			},
 		},
-		Create: resourceProtocolGroupCreate,
-		Read:   resourceProtocolGroupRead,
-		Update: resourceProtocolGroupUpdate,
-		Delete: resourceProtocolGroupDelete,
+		CreateContext: resourceProtocolGroupCreate,
+		ReadContext:   resourceProtocolGroupRead,
+		UpdateContext: resourceProtocolGroupUpdate,
+		DeleteContext: resourceProtocolGroupDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(protocolGroupCreateTimeout),
+			Update: schema.DefaultTimeout(defaultTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourceProtocolGroupCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceProtocolGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 
-	protocolGroup := ProtocolGroup{
+	protocolGroup := sdk.CreateProtocolGroupRequest{
 		Name:        name,
 		Description: description,
 	}
@@ -92,97 +101,101 @@ func resourceProtocolGroupCreate(d *schema.ResourceData, m interface{}) error {
 	if v, ok := d.GetOk("protocols"); ok {
 		p, err := expandProtocols(v.([]interface{}), name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		protocolGroup.Protocols = p
 	}
 
-	var newProtocolGroup *ProtocolGroup
-	newProtocolGroup, err := client.CreateProtocolGroup(&protocolGroup)
+	var newProtocolGroup *sdk.ProtocolGroup
+	newProtocolGroup, err := client.CreateProtocolGroup(ctx, &protocolGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = WaitProtocolGroupCreate(client, newProtocolGroup.ID)
+	err = WaitProtocolGroupCreate(ctx, client, newProtocolGroup.ID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return fmt.Errorf("Error waiting for protocol group creation (%s) (%s)", newProtocolGroup.ID, err)
+		return diag.Errorf("Error waiting for protocol group creation (%s) (%s)", newProtocolGroup.ID, err)
 	}
 
 	d.SetId(newProtocolGroup.ID)
 
 	err = protocolGroupToResource(d, newProtocolGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceProtocolGroupRead(d, m)
+	return resourceProtocolGroupRead(ctx, d, m)
 }
 
-func resourceProtocolGroupRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceProtocolGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	var protocolGroup *ProtocolGroup
-	protocolGroup, err := client.GetProtocolGroup(d.Id())
+	var protocolGroup *sdk.ProtocolGroup
+	protocolGroup, err := client.GetProtocolGroup(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Protocol Group %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = protocolGroupToResource(d, protocolGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceProtocolGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceProtocolGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 
-	protocolGroup := ProtocolGroup{
-		Name:        name,
-		Description: description,
+	protocolGroup := sdk.UpdateProtocolGroupRequest{
+		Name:        &name,
+		Description: &description,
 	}
 
 	if v, ok := d.GetOk("protocols"); ok {
 		p, err := expandProtocols(v.([]interface{}), name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
-		protocolGroup.Protocols = p
+		protocolGroup.Protocols = &p
 	}
 
-	var updatedProtocolGroup *ProtocolGroup
-	updatedProtocolGroup, err := client.UpdateProtocolGroup(d.Id(), &protocolGroup)
+	var updatedProtocolGroup *sdk.ProtocolGroup
+	updatedProtocolGroup, err := client.UpdateProtocolGroup(ctx, d.Id(), &protocolGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(updatedProtocolGroup.ID)
 
 	err = protocolGroupToResource(d, updatedProtocolGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceProtocolGroupRead(d, m)
+	return resourceProtocolGroupRead(ctx, d, m)
 }
 
-func resourceProtocolGroupDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceProtocolGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteProtocolGroup(d.Id())
+	err := client.DeleteProtocolGroup(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func protocolGroupToResource(d *schema.ResourceData, m *ProtocolGroup) error {
+func protocolGroupToResource(d *schema.ResourceData, m *sdk.ProtocolGroup) error {
 	d.Set("description", m.Description)
 	d.Set("name", m.Name)
 	err := d.Set("protocols", flattenProtocols(m.Protocols))
@@ -199,7 +212,7 @@ func protocolGroupToResource(d *schema.ResourceData, m *ProtocolGroup) error {
 	return nil
 }
 
-func flattenProtocols(in []Protocol) []map[string]interface{} {
+func flattenProtocols(in []sdk.Protocol) []map[string]interface{} {
 	var out = make([]map[string]interface{}, len(in), len(in))
 	for i, v := range in {
 		m := make(map[string]interface{})
@@ -211,15 +224,15 @@ func flattenProtocols(in []Protocol) []map[string]interface{} {
 	return out
 }
 
-func expandProtocols(data []interface{}, resourceID string) ([]Protocol, error) {
-	protocols := make([]Protocol, 0, len(data))
+func expandProtocols(data []interface{}, resourceID string) ([]sdk.Protocol, error) {
+	protocols := make([]sdk.Protocol, 0, len(data))
 	for _, d := range data {
 		m, ok := d.(map[string]interface{})
 		if !ok {
 			continue
 		}
 
-		protocol := &Protocol{
+		protocol := &sdk.Protocol{
 			FromPort: int64(m["from_port"].(int)),
 			ToPort:   int64(m["to_port"].(int)),
 			Protocol: m["proto"].(string),
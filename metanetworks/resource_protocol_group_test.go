@@ -0,0 +1,94 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccProtocolGroup_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_protocol_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_protocol_group", testAccGetProtocolGroup),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProtocolGroupConfig(rName, "first", 8080),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetProtocolGroup),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "protocols.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "protocols.0.proto", "tcp"),
+					resource.TestCheckResourceAttr(resourceName, "protocols.0.from_port", "8080"),
+					resource.TestCheckResourceAttr(resourceName, "protocols.0.to_port", "8080"),
+					resource.TestCheckResourceAttr(resourceName, "read_only", "false"),
+				),
+			},
+			{
+				Config: testAccProtocolGroupConfig(rName, "second", 8443),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "protocols.0.from_port", "8443"),
+					resource.TestCheckResourceAttr(resourceName, "protocols.0.to_port", "8443"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccProtocolGroup_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_protocol_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_protocol_group", testAccGetProtocolGroup),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProtocolGroupConfig(rName, "first", 8080),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetProtocolGroup),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteProtocolGroup),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccGetProtocolGroup(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetProtocolGroup(ctx, id)
+	return err
+}
+
+func testAccProtocolGroupConfig(rName, description string, port int) string {
+	return fmt.Sprintf(`
+resource "metanetworks_protocol_group" "test" {
+  name        = %[1]q
+  description = %[2]q
+
+  protocols {
+    proto     = "tcp"
+    from_port = %[3]d
+    to_port   = %[3]d
+  }
+}
+`, rName, description, port)
+}
@@ -1,6 +1,12 @@
 package metanetworks
 
 import (
+	"context"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -61,108 +67,110 @@ func resourceRoutingGroup() *schema.Resource {
 				Optional:    true,
 			},
 		},
-		Create: resourceRoutingGroupCreate,
-		Read:   resourceRoutingGroupRead,
-		Update: resourceRoutingGroupUpdate,
-		Delete: resourceRoutingGroupDelete,
+		CreateContext: resourceRoutingGroupCreate,
+		ReadContext:   resourceRoutingGroupRead,
+		UpdateContext: resourceRoutingGroupUpdate,
+		DeleteContext: resourceRoutingGroupDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourceRoutingGroupCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceRoutingGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
-	mappedElementsIDs := resourceTypeSetToStringSlice(d.Get("mapped_elements_ids").(*schema.Set))
 	exemptSources := resourceTypeSetToStringSlice(d.Get("exempt_sources").(*schema.Set))
 	sources := resourceTypeSetToStringSlice(d.Get("sources").(*schema.Set))
 
-	routingGroup := RoutingGroup{
-		Name:           name,
-		Description:    description,
-		MappedElements: mappedElementsIDs,
-		ExemptSources:  exemptSources,
-		Sources:        sources,
+	routingGroup := sdk.CreateRoutingGroupRequest{
+		Name:          name,
+		Description:   description,
+		ExemptSources: exemptSources,
+		Sources:       sources,
 	}
 
-	var newRoutingGroup *RoutingGroup
-	newRoutingGroup, err := client.CreateRoutingGroup(&routingGroup)
+	var newRoutingGroup *sdk.RoutingGroup
+	newRoutingGroup, err := client.CreateRoutingGroup(ctx, &routingGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(newRoutingGroup.ID)
 
 	err = routingGroupToResource(d, newRoutingGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceRoutingGroupRead(d, m)
+	return resourceRoutingGroupRead(ctx, d, m)
 }
 
-func resourceRoutingGroupRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceRoutingGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	var routingGroup *RoutingGroup
-	routingGroup, err := client.GetRoutingGroup(d.Id())
+	var routingGroup *sdk.RoutingGroup
+	routingGroup, err := client.GetRoutingGroup(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Routing Group %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = routingGroupToResource(d, routingGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceRoutingGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceRoutingGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
-	mappedElementsIDs := resourceTypeSetToStringSlice(d.Get("mapped_elements_ids").(*schema.Set))
 	exemptSources := resourceTypeSetToStringSlice(d.Get("exempt_sources").(*schema.Set))
 	sources := resourceTypeSetToStringSlice(d.Get("sources").(*schema.Set))
 
-	routingGroup := RoutingGroup{
-		Name:           name,
-		Description:    description,
-		MappedElements: mappedElementsIDs,
-		ExemptSources:  exemptSources,
-		Sources:        sources,
+	// The mapped elements are owned by the attachment and member list
+	// resources, so they're left out to keep the server's list intact.
+	routingGroup := sdk.UpdateRoutingGroupRequest{
+		Name:          &name,
+		Description:   &description,
+		ExemptSources: sdk.StringsOrNull(exemptSources),
+		Sources:       sdk.StringsOrNull(sources),
 	}
 
-	var updatedRoutingGroup *RoutingGroup
-	updatedRoutingGroup, err := client.UpdateRoutingGroup(d.Id(), &routingGroup)
+	var updatedRoutingGroup *sdk.RoutingGroup
+	updatedRoutingGroup, err := client.UpdateRoutingGroup(ctx, d.Id(), &routingGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(updatedRoutingGroup.ID)
 
 	err = routingGroupToResource(d, updatedRoutingGroup)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceRoutingGroupRead(d, m)
+	return resourceRoutingGroupRead(ctx, d, m)
 }
 
-func resourceRoutingGroupDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceRoutingGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteRoutingGroup(d.Id())
-	return err
+	err := client.DeleteRoutingGroup(ctx, d.Id())
+	return diag.FromErr(err)
 }
 
-func routingGroupToResource(d *schema.ResourceData, m *RoutingGroup) error {
+func routingGroupToResource(d *schema.ResourceData, m *sdk.RoutingGroup) error {
 	d.Set("name", m.Name)
 	d.Set("description", m.Description)
 	d.Set("mapped_elements_ids", m.MappedElements)
@@ -1,14 +1,18 @@
 package metanetworks
 
 import (
-	"errors"
-	"fmt"
+	"context"
+	"log"
 
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceRoutingGroupAttachment() *schema.Resource {
 	return &schema.Resource{
+		Description: "Adds a network element to a routing group, leaving its other network elements alone. Don't use it for a routing group whose network elements are set by `metanetworks_routing_group_mapped_elements`, which removes those it doesn't list. The attachment is written with the ETag of the routing group when the API returns one. Otherwise the routing group is read again right before the write and its `modified_at` compared, which leaves a short window: a change to its network elements made by someone else between that read and the write is overwritten.",
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "The ID of this resource.",
@@ -28,14 +32,23 @@ func resourceRoutingGroupAttachment() *schema.Resource {
 				ForceNew:    true,
 			},
 		},
-		Create: resourceRoutingGroupAttachmentCreate,
-		Read:   resourceRoutingGroupAttachmentRead,
-		Delete: resourceRoutingGroupAttachmentDelete,
+		CreateContext: resourceRoutingGroupAttachmentCreate,
+		ReadContext:   resourceRoutingGroupAttachmentRead,
+		DeleteContext: resourceRoutingGroupAttachmentDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(routingGroupAttachmentCreateTimeout),
+			Delete: schema.DefaultTimeout(defaultTimeout),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importCompositeID("routing_group_id", "network_element_id", func(ctx context.Context, client sdk.API, routingGroupID, elementID string) error {
+				return routingGroupMappedElements(client).checkMember(ctx, routingGroupID, elementID)
+			}),
+		},
 	}
 }
 
-func resourceRoutingGroupAttachmentCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceRoutingGroupAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	elementID := d.Get("network_element_id").(string)
 	routingGroupID := d.Get("routing_group_id").(string)
@@ -43,46 +56,39 @@ func resourceRoutingGroupAttachmentCreate(d *schema.ResourceData, m interface{})
 	metanetworksMutexKV.Lock(routingGroupID)
 	defer metanetworksMutexKV.Unlock(routingGroupID)
 
-	var routingGroup *RoutingGroup
-	routingGroup, err := client.GetRoutingGroup(routingGroupID)
-	if err != nil {
-		return err
-	}
-
-	for i := 0; i < len(routingGroup.MappedElements); i++ {
-		if routingGroup.MappedElements[i] == elementID {
-			return errors.New("That network element is already mapped to this RoutingGroup")
-		}
-
-	}
-
-	routingGroup.MappedElements = append(routingGroup.MappedElements, elementID)
-	_, err = client.UpdateRoutingGroup(routingGroupID, routingGroup)
+	err := routingGroupMappedElements(client).addMember(ctx, routingGroupID, elementID, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	_, err = WaitRoutingGroupAttachmentCreate(client, routingGroupID, elementID)
+	err = WaitRoutingGroupAttachmentCreate(ctx, client, routingGroupID, elementID, d.Timeout(schema.TimeoutCreate))
 
 	if err != nil {
-		return fmt.Errorf("Error waiting for routing group attachment creation (%s) (%s)", routingGroupID, err)
+		return diag.Errorf("Error waiting for routing group attachment creation (%s) (%s)", routingGroupID, err)
 	}
 
-	d.SetId(fmt.Sprintf("%s_%s", routingGroupID, elementID))
+	d.SetId(compositeID(routingGroupID, elementID))
 
-	return resourceRoutingGroupAttachmentRead(d, m)
+	return resourceRoutingGroupAttachmentRead(ctx, d, m)
 }
 
-func resourceRoutingGroupAttachmentRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceRoutingGroupAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	elementID := d.Get("network_element_id").(string)
-	routingGroupID := d.Get("routing_group_id").(string)
+	routingGroupID, elementID, err := parseCompositeID(d.Id(), "<routing_group_id>_<network_element_id>")
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
-	var routingGroup *RoutingGroup
-	routingGroup, err := client.GetRoutingGroup(routingGroupID)
+	var routingGroup *sdk.RoutingGroup
+	routingGroup, err = client.GetRoutingGroup(ctx, routingGroupID)
 	if err != nil {
-		return err
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing Routing Group Attachment %q because routing group %q no longer exists", d.Id(), routingGroupID)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	found := false
@@ -97,13 +103,16 @@ func resourceRoutingGroupAttachmentRead(d *schema.ResourceData, m interface{}) e
 	// If not present we need to destroy the terraform resource so that it is recreated.
 	if !found {
 		d.SetId("")
+	} else {
+		d.Set("network_element_id", elementID)
+		d.Set("routing_group_id", routingGroupID)
 	}
 
 	return nil
 }
 
-func resourceRoutingGroupAttachmentDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceRoutingGroupAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	elementID := d.Get("network_element_id").(string)
 	routingGroupID := d.Get("routing_group_id").(string)
@@ -111,20 +120,28 @@ func resourceRoutingGroupAttachmentDelete(d *schema.ResourceData, m interface{})
 	metanetworksMutexKV.Lock(routingGroupID)
 	defer metanetworksMutexKV.Unlock(routingGroupID)
 
-	var routingGroup *RoutingGroup
-	routingGroup, err := client.GetRoutingGroup(routingGroupID)
-	if err != nil {
-		return err
-	}
+	err := routingGroupMappedElements(client).removeMember(ctx, routingGroupID, elementID, d.Timeout(schema.TimeoutDelete))
+	return diag.FromErr(err)
+}
 
-	// Note that if the entry has already been deleted this won't fail.
-	for i := 0; i < len(routingGroup.MappedElements); i++ {
-		if routingGroup.MappedElements[i] == elementID {
-			routingGroup.MappedElements = append(routingGroup.MappedElements[:i], routingGroup.MappedElements[i+1:]...)
-			break
-		}
+// routingGroupMappedElements is the list of network elements attached to a RoutingGroup.
+func routingGroupMappedElements(client sdk.RoutingGroupsAPI) *memberListAPI {
+	return &memberListAPI{
+		parent: "RoutingGroup",
+		list:   "mapped elements",
+		get: func(ctx context.Context, routingGroupID string) (*memberList, error) {
+			routingGroup, err := client.GetRoutingGroup(ctx, routingGroupID)
+			if err != nil {
+				return nil, err
+			}
+			return &memberList{members: routingGroup.MappedElements, etag: routingGroup.ETag, modifiedAt: routingGroup.ModifiedAt}, nil
+		},
+		set: func(ctx context.Context, routingGroupID string, members []string, ifMatch string) error {
+			_, err := client.UpdateRoutingGroup(ctx, routingGroupID, &sdk.UpdateRoutingGroupRequest{
+				MappedElements: sdk.StringsOrNull(members),
+				IfMatch:        ifMatch,
+			})
+			return err
+		},
 	}
-
-	_, err = client.UpdateRoutingGroup(routingGroupID, routingGroup)
-	return err
 }
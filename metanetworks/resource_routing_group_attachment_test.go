@@ -0,0 +1,151 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRoutingGroupAttachment_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_routing_group_attachment.test.0"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_routing_group_attachment", testAccGetRoutingGroupAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoutingGroupAttachmentConfig(rName, 2, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetRoutingGroupAttachment),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttrPair(resourceName, "routing_group_id", "metanetworks_routing_group.test", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "network_element_id", "metanetworks_mapped_subnets.test.0", "id"),
+				),
+			},
+			{
+				Config: testAccRoutingGroupAttachmentConfig(rName, 2, 1, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetRoutingGroupAttachment),
+					testAccCheckIDChanged(resourceName, &id, true),
+					resource.TestCheckResourceAttrPair(resourceName, "network_element_id", "metanetworks_mapped_subnets.test.1", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRoutingGroupAttachment_disappears(t *testing.T) {
+	testAccSkipReplay(t)
+
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_routing_group_attachment.test.0"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_routing_group_attachment", testAccGetRoutingGroupAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoutingGroupAttachmentConfig(rName, 2, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetRoutingGroupAttachment),
+					testAccCheckDisappears(resourceName, testAccDeleteRoutingGroupAttachment),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+// TestAccRoutingGroupAttachment_concurrent attaches several network elements to
+// the same routing group at once, none of them may be lost.
+func TestAccRoutingGroupAttachment_concurrent(t *testing.T) {
+	testAccSkipReplay(t)
+
+	rName := testAccRandomName(t)
+	parentName := "metanetworks_routing_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_routing_group_attachment", testAccGetRoutingGroupAttachment),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoutingGroupAttachmentConfig(rName, 3, 3, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(parentName, testAccRoutingGroupMappedElements, 3),
+					testAccCheckExists("metanetworks_routing_group_attachment.test.0", testAccGetRoutingGroupAttachment),
+					testAccCheckExists("metanetworks_routing_group_attachment.test.1", testAccGetRoutingGroupAttachment),
+					testAccCheckExists("metanetworks_routing_group_attachment.test.2", testAccGetRoutingGroupAttachment),
+				),
+			},
+			{
+				Config: testAccRoutingGroupAttachmentConfig(rName, 3, 1, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMembers(parentName, testAccRoutingGroupMappedElements, 1),
+					testAccCheckExists("metanetworks_routing_group_attachment.test.0", testAccGetRoutingGroupAttachment),
+				),
+			},
+			{
+				Config: testAccRoutingGroupAttachmentConfig(rName, 3, 0, 0),
+				Check:  testAccCheckMembers(parentName, testAccRoutingGroupMappedElements, 0),
+			},
+		},
+	})
+}
+
+var testAccGetRoutingGroupAttachment = testAccGetMember(testAccRoutingGroupMappedElements)
+
+func testAccRoutingGroupMappedElements(client sdk.API, ctx context.Context, id string) ([]string, error) {
+	routingGroup, err := client.GetRoutingGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return routingGroup.MappedElements, nil
+}
+
+// testAccDeleteRoutingGroupAttachment removes the network element from
+// the routing group out of band.
+func testAccDeleteRoutingGroupAttachment(client sdk.API, ctx context.Context, id string) error {
+	routingGroupID, elementID, err := testAccSplitID(id)
+	if err != nil {
+		return err
+	}
+
+	routingGroup, err := client.GetRoutingGroup(ctx, routingGroupID)
+	if err != nil {
+		return err
+	}
+	routingGroup.MappedElements = testAccRemoveMember(routingGroup.MappedElements, elementID)
+
+	_, err = client.UpdateRoutingGroup(ctx, routingGroupID, &sdk.UpdateRoutingGroupRequest{MappedElements: sdk.StringsOrNull(routingGroup.MappedElements)})
+	return err
+}
+
+// testAccRoutingGroupAttachmentConfig attaches count of the elements mapped
+// subnets to a routing group, starting with the element at offset.
+func testAccRoutingGroupAttachmentConfig(rName string, elements, count, offset int) string {
+	return testAccMappedSubnetsElementsConfig(rName, elements) + fmt.Sprintf(`
+resource "metanetworks_routing_group" "test" {
+  name = %[1]q
+}
+
+resource "metanetworks_routing_group_attachment" "test" {
+  count              = %[2]d
+  routing_group_id   = metanetworks_routing_group.test.id
+  network_element_id = metanetworks_mapped_subnets.test[count.index + %[3]d].id
+}
+`, rName, count, offset)
+}
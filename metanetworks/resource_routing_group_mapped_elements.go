@@ -0,0 +1,20 @@
+package metanetworks
+
+import (
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceRoutingGroupMappedElements() *schema.Resource {
+	return resourceMemberList(&memberListResource{
+		description:       "Sets all the network elements of a routing group, removing those added outside of Terraform. It conflicts with `metanetworks_routing_group_attachment`: use either this resource or attachments for a routing group, not both, or each apply undoes the changes of the other.",
+		parentKey:         "routing_group_id",
+		listKey:           "mapped_elements",
+		parentDescription: "The ID of the routing group.",
+		listDescription:   "The IDs of the network elements routed by the routing group.",
+		access: func(client sdk.API) *memberListAPI {
+			return routingGroupMappedElements(client)
+		},
+	})
+}
@@ -0,0 +1,88 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccRoutingGroup_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_routing_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_routing_group", testAccGetRoutingGroup),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoutingGroupConfig(rName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetRoutingGroup),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "sources.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "mapped_elements_ids.#", "0"),
+				),
+			},
+			{
+				Config: testAccRoutingGroupConfig(rName, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccRoutingGroup_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_routing_group.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_routing_group", testAccGetRoutingGroup),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoutingGroupConfig(rName, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetRoutingGroup),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteRoutingGroup),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccGetRoutingGroup(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetRoutingGroup(ctx, id)
+	return err
+}
+
+func testAccRoutingGroupConfig(rName, description string) string {
+	return fmt.Sprintf(`
+resource "metanetworks_group" "test" {
+  name = %[1]q
+}
+
+resource "metanetworks_routing_group" "test" {
+  name        = %[1]q
+  description = %[2]q
+  sources     = [metanetworks_group.test.id]
+}
+`, rName, description)
+}
@@ -1,6 +1,12 @@
 package metanetworks
 
 import (
+	"context"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -60,18 +66,18 @@ func resourceSwgContentCategories() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourceSwgContentCategoriesCreate,
-		Read:   resourceSwgContentCategoriesRead,
-		Update: resourceSwgContentCategoriesUpdate,
-		Delete: resourceSwgContentCategoriesDelete,
+		CreateContext: resourceSwgContentCategoriesCreate,
+		ReadContext:   resourceSwgContentCategoriesRead,
+		UpdateContext: resourceSwgContentCategoriesUpdate,
+		DeleteContext: resourceSwgContentCategoriesDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourceSwgContentCategoriesCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgContentCategoriesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -80,7 +86,7 @@ func resourceSwgContentCategoriesCreate(d *schema.ResourceData, m interface{}) e
 	types := resourceTypeSetToStringSlice(d.Get("types").(*schema.Set))
 	urls := resourceTypeSetToStringSlice(d.Get("urls").(*schema.Set))
 
-	swgContentCategories := SwgContentCategories{
+	swgContentCategories := sdk.CreateSwgContentCategoriesRequest{
 		Name:                    name,
 		Description:             description,
 		ConfidenceLevel:         confidenceLevel,
@@ -89,40 +95,44 @@ func resourceSwgContentCategoriesCreate(d *schema.ResourceData, m interface{}) e
 		Urls:                    urls,
 	}
 
-	var newSwgContentCategories *SwgContentCategories
-	newSwgContentCategories, err := client.CreateSwgContentCategories(&swgContentCategories)
+	var newSwgContentCategories *sdk.SwgContentCategories
+	newSwgContentCategories, err := client.CreateSwgContentCategories(ctx, &swgContentCategories)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(newSwgContentCategories.ID)
 	err = swgContentCategoriesToResource(d, newSwgContentCategories)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceSwgContentCategoriesRead(d, m)
+	return resourceSwgContentCategoriesRead(ctx, d, m)
 }
 
-func resourceSwgContentCategoriesRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgContentCategoriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	swgContentCategories, err := client.GetSwgContentCategories(d.Id())
+	swgContentCategories, err := client.GetSwgContentCategories(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing SWG Content Categories %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = swgContentCategoriesToResource(d, swgContentCategories)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceSwgContentCategoriesUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgContentCategoriesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -131,43 +141,44 @@ func resourceSwgContentCategoriesUpdate(d *schema.ResourceData, m interface{}) e
 	types := resourceTypeSetToStringSlice(d.Get("types").(*schema.Set))
 	urls := resourceTypeSetToStringSlice(d.Get("urls").(*schema.Set))
 
-	swgContentCategories := SwgContentCategories{
-		Name:                    name,
-		Description:             description,
-		ConfidenceLevel:         confidenceLevel,
-		ForbidUncategorizedUrls: forbidUncategorizedUrls,
-		Types:                   types,
-		Urls:                    urls,
+	swgContentCategories := sdk.UpdateSwgContentCategoriesRequest{
+		Name:                    &name,
+		Description:             &description,
+		ConfidenceLevel:         sdk.StringOrNull(confidenceLevel),
+		ForbidUncategorizedUrls: &forbidUncategorizedUrls,
+		Types:                   sdk.StringsOrNull(types),
+		Urls:                    sdk.StringsOrNull(urls),
 	}
 
-	var updatedSwgContentCategories *SwgContentCategories
-	updatedSwgContentCategories, err := client.UpdateSwgContentCategories(d.Id(), &swgContentCategories)
+	var updatedSwgContentCategories *sdk.SwgContentCategories
+	updatedSwgContentCategories, err := client.UpdateSwgContentCategories(ctx, d.Id(), &swgContentCategories)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(updatedSwgContentCategories.ID)
 
 	err = swgContentCategoriesToResource(d, updatedSwgContentCategories)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceSwgContentCategoriesRead(d, m)
+	return resourceSwgContentCategoriesRead(ctx, d, m)
 }
 
-func resourceSwgContentCategoriesDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgContentCategoriesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteSwgContentCategories(d.Id())
+	err := client.DeleteSwgContentCategories(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func swgContentCategoriesToResource(d *schema.ResourceData, m *SwgContentCategories) error {
+func swgContentCategoriesToResource(d *schema.ResourceData, m *sdk.SwgContentCategories) error {
+	d.Set("name", m.Name)
 	d.Set("description", m.Description)
 	d.Set("confidence_level", m.ConfidenceLevel)
 	d.Set("forbid_uncategorized_urls", m.ForbidUncategorizedUrls)
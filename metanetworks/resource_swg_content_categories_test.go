@@ -0,0 +1,90 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSwgContentCategories_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_swg_content_categories.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_swg_content_categories", testAccGetSwgContentCategories),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSwgContentCategoriesConfig(rName, "first", `["gambling.example.com"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetSwgContentCategories),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "confidence_level", "HIGH"),
+					resource.TestCheckResourceAttr(resourceName, "types.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "urls.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "urls.*", "gambling.example.com"),
+				),
+			},
+			{
+				Config: testAccSwgContentCategoriesConfig(rName, "second", `["gambling.example.com", "games.example.com"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "urls.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "urls.*", "games.example.com"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSwgContentCategories_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_swg_content_categories.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_swg_content_categories", testAccGetSwgContentCategories),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSwgContentCategoriesConfig(rName, "first", `["gambling.example.com"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetSwgContentCategories),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteSwgContentCategories),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccGetSwgContentCategories(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetSwgContentCategories(ctx, id)
+	return err
+}
+
+func testAccSwgContentCategoriesConfig(rName, description, urls string) string {
+	return fmt.Sprintf(`
+resource "metanetworks_swg_content_categories" "test" {
+  name             = %[1]q
+  description      = %[2]q
+  confidence_level = "HIGH"
+  types            = ["Gambling"]
+  urls             = %[3]s
+}
+`, rName, description, urls)
+}
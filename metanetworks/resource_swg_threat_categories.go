@@ -1,6 +1,12 @@
 package metanetworks
 
 import (
+	"context"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -60,18 +66,18 @@ func resourceSwgThreatCategories() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourceSwgThreatCategoriesCreate,
-		Read:   resourceSwgThreatCategoriesRead,
-		Update: resourceSwgThreatCategoriesUpdate,
-		Delete: resourceSwgThreatCategoriesDelete,
+		CreateContext: resourceSwgThreatCategoriesCreate,
+		ReadContext:   resourceSwgThreatCategoriesRead,
+		UpdateContext: resourceSwgThreatCategoriesUpdate,
+		DeleteContext: resourceSwgThreatCategoriesDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourceSwgThreatCategoriesCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgThreatCategoriesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -80,7 +86,7 @@ func resourceSwgThreatCategoriesCreate(d *schema.ResourceData, m interface{}) er
 	confidenceLevel := d.Get("confidence_level").(string)
 	riskLevel := d.Get("risk_level").(string)
 
-	swgThreatCategories := SwgThreatCategories{
+	swgThreatCategories := sdk.CreateSwgThreatCategoriesRequest{
 		Name:            name,
 		Description:     description,
 		Countries:       countries,
@@ -89,42 +95,46 @@ func resourceSwgThreatCategoriesCreate(d *schema.ResourceData, m interface{}) er
 		RiskLevel:       riskLevel,
 	}
 
-	var newSwgThreatCategories *SwgThreatCategories
-	newSwgThreatCategories, err := client.CreateSwgThreatCategories(&swgThreatCategories)
+	var newSwgThreatCategories *sdk.SwgThreatCategories
+	newSwgThreatCategories, err := client.CreateSwgThreatCategories(ctx, &swgThreatCategories)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(newSwgThreatCategories.ID)
 
 	err = swgThreatCategoriesToResource(d, newSwgThreatCategories)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceSwgThreatCategoriesRead(d, m)
+	return resourceSwgThreatCategoriesRead(ctx, d, m)
 }
 
-func resourceSwgThreatCategoriesRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgThreatCategoriesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	var swgThreatCategories *SwgThreatCategories
-	swgThreatCategories, err := client.GetSwgThreatCategories(d.Id())
+	var swgThreatCategories *sdk.SwgThreatCategories
+	swgThreatCategories, err := client.GetSwgThreatCategories(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing SWG Threat Categories %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = swgThreatCategoriesToResource(d, swgThreatCategories)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceSwgThreatCategoriesUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgThreatCategoriesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -133,39 +143,39 @@ func resourceSwgThreatCategoriesUpdate(d *schema.ResourceData, m interface{}) er
 	confidenceLevel := d.Get("confidence_level").(string)
 	riskLevel := d.Get("risk_level").(string)
 
-	swgThreatCategories := SwgThreatCategories{
-		Name:            name,
-		Description:     description,
-		Countries:       countries,
-		Types:           types,
-		ConfidenceLevel: confidenceLevel,
-		RiskLevel:       riskLevel,
+	swgThreatCategories := sdk.UpdateSwgThreatCategoriesRequest{
+		Name:            &name,
+		Description:     &description,
+		Countries:       sdk.StringsOrNull(countries),
+		Types:           sdk.StringsOrNull(types),
+		ConfidenceLevel: sdk.StringOrNull(confidenceLevel),
+		RiskLevel:       sdk.StringOrNull(riskLevel),
 	}
 
-	var updatedSwgThreatCategories *SwgThreatCategories
-	updatedSwgThreatCategories, err := client.UpdateSwgThreatCategories(d.Id(), &swgThreatCategories)
+	var updatedSwgThreatCategories *sdk.SwgThreatCategories
+	updatedSwgThreatCategories, err := client.UpdateSwgThreatCategories(ctx, d.Id(), &swgThreatCategories)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(updatedSwgThreatCategories.ID)
 
 	err = swgThreatCategoriesToResource(d, updatedSwgThreatCategories)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceSwgThreatCategoriesRead(d, m)
+	return resourceSwgThreatCategoriesRead(ctx, d, m)
 }
 
-func resourceSwgThreatCategoriesDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgThreatCategoriesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteSwgThreatCategories(d.Id())
-	return err
+	err := client.DeleteSwgThreatCategories(ctx, d.Id())
+	return diag.FromErr(err)
 }
 
-func swgThreatCategoriesToResource(d *schema.ResourceData, m *SwgThreatCategories) error {
+func swgThreatCategoriesToResource(d *schema.ResourceData, m *sdk.SwgThreatCategories) error {
 	d.Set("name", m.Name)
 	d.Set("description", m.Description)
 	d.Set("countries", m.Countries)
@@ -0,0 +1,91 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSwgThreatCategories_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_swg_threat_categories.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_swg_threat_categories", testAccGetSwgThreatCategories),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSwgThreatCategoriesConfig(rName, "first", "HIGH"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetSwgThreatCategories),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "risk_level", "HIGH"),
+					resource.TestCheckResourceAttr(resourceName, "confidence_level", "HIGH"),
+					resource.TestCheckResourceAttr(resourceName, "types.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "types.*", "Malware"),
+					resource.TestCheckResourceAttr(resourceName, "countries.#", "1"),
+				),
+			},
+			{
+				Config: testAccSwgThreatCategoriesConfig(rName, "second", "MEDIUM"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "risk_level", "MEDIUM"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSwgThreatCategories_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_swg_threat_categories.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_swg_threat_categories", testAccGetSwgThreatCategories),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSwgThreatCategoriesConfig(rName, "first", "HIGH"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetSwgThreatCategories),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteSwgThreatCategories),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccGetSwgThreatCategories(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetSwgThreatCategories(ctx, id)
+	return err
+}
+
+func testAccSwgThreatCategoriesConfig(rName, description, riskLevel string) string {
+	return fmt.Sprintf(`
+resource "metanetworks_swg_threat_categories" "test" {
+  name             = %[1]q
+  description      = %[2]q
+  confidence_level = "HIGH"
+  risk_level       = %[3]q
+  countries        = ["KP"]
+  types            = ["Malware"]
+}
+`, rName, description, riskLevel)
+}
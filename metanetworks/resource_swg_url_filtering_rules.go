@@ -1,6 +1,12 @@
 package metanetworks
 
 import (
+	"context"
+	"log"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -83,18 +89,18 @@ func resourceSwgUrlFilteringRules() *schema.Resource {
 				Computed:    true,
 			},
 		},
-		Create: resourceSwgUrlFilteringRulesCreate,
-		Read:   resourceSwgUrlFilteringRulesRead,
-		Update: resourceSwgUrlFilteringRulesUpdate,
-		Delete: resourceSwgUrlFilteringRulesDelete,
+		CreateContext: resourceSwgUrlFilteringRulesCreate,
+		ReadContext:   resourceSwgUrlFilteringRulesRead,
+		UpdateContext: resourceSwgUrlFilteringRulesUpdate,
+		DeleteContext: resourceSwgUrlFilteringRulesDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
 }
 
-func resourceSwgUrlFilteringRulesCreate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgUrlFilteringRulesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -107,7 +113,7 @@ func resourceSwgUrlFilteringRulesCreate(d *schema.ResourceData, m interface{}) e
 	sources := resourceTypeSetToStringSlice(d.Get("sources").(*schema.Set))
 	forbiddenContentCategories := resourceTypeSetToStringSlice(d.Get("forbidden_content_categories").(*schema.Set))
 
-	swgUrlFilteringRules := SwgUrlFilteringRules{
+	swgUrlFilteringRules := sdk.CreateSwgUrlFilteringRulesRequest{
 		Name:                       name,
 		Description:                description,
 		Action:                     action,
@@ -120,42 +126,46 @@ func resourceSwgUrlFilteringRulesCreate(d *schema.ResourceData, m interface{}) e
 		ForbiddenContentCategories: forbiddenContentCategories,
 	}
 
-	var newSwgUrlFilteringRules *SwgUrlFilteringRules
-	newSwgUrlFilteringRules, err := client.CreateSwgUrlFilteringRules(&swgUrlFilteringRules)
+	var newSwgUrlFilteringRules *sdk.SwgUrlFilteringRules
+	newSwgUrlFilteringRules, err := client.CreateSwgUrlFilteringRules(ctx, &swgUrlFilteringRules)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(newSwgUrlFilteringRules.ID)
 
 	err = swgUrlFilteringRulesToResource(d, newSwgUrlFilteringRules)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceSwgUrlFilteringRulesRead(d, m)
+	return resourceSwgUrlFilteringRulesRead(ctx, d, m)
 }
 
-func resourceSwgUrlFilteringRulesRead(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgUrlFilteringRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	var swgUrlFilteringRules *SwgUrlFilteringRules
-	swgUrlFilteringRules, err := client.GetSwgUrlFilteringRules(d.Id())
+	var swgUrlFilteringRules *sdk.SwgUrlFilteringRules
+	swgUrlFilteringRules, err := client.GetSwgUrlFilteringRules(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return nil
+		if sdk.IsNotFound(err) {
+			log.Printf("[WARN] Removing SWG URL Filtering Rules %q because it's gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
 	}
 
 	err = swgUrlFilteringRulesToResource(d, swgUrlFilteringRules)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceSwgUrlFilteringRulesUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgUrlFilteringRulesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
@@ -168,43 +178,43 @@ func resourceSwgUrlFilteringRulesUpdate(d *schema.ResourceData, m interface{}) e
 	sources := resourceTypeSetToStringSlice(d.Get("sources").(*schema.Set))
 	forbiddenContentCategories := resourceTypeSetToStringSlice(d.Get("forbidden_content_categories").(*schema.Set))
 
-	swgUrlFilteringRules := SwgUrlFilteringRules{
-		Name:                       name,
-		Description:                description,
-		Action:                     action,
-		AdvancedThreatProtection:   advancedThreatProtection,
-		Enabled:                    enabled,
-		Priority:                   priority,
-		ThreatCategory:             threatCategory,
-		ExemptSources:              exemptSources,
-		Sources:                    sources,
-		ForbiddenContentCategories: forbiddenContentCategories,
+	swgUrlFilteringRules := sdk.UpdateSwgUrlFilteringRulesRequest{
+		Name:                       &name,
+		Description:                &description,
+		Action:                     &action,
+		AdvancedThreatProtection:   &advancedThreatProtection,
+		Enabled:                    &enabled,
+		Priority:                   &priority,
+		ThreatCategory:             sdk.StringOrNull(threatCategory),
+		ExemptSources:              sdk.StringsOrNull(exemptSources),
+		Sources:                    sdk.StringsOrNull(sources),
+		ForbiddenContentCategories: sdk.StringsOrNull(forbiddenContentCategories),
 	}
 
-	var updatedSwgUrlFilteringRules *SwgUrlFilteringRules
-	updatedSwgUrlFilteringRules, err := client.UpdateSwgUrlFilteringRules(d.Id(), &swgUrlFilteringRules)
+	var updatedSwgUrlFilteringRules *sdk.SwgUrlFilteringRules
+	updatedSwgUrlFilteringRules, err := client.UpdateSwgUrlFilteringRules(ctx, d.Id(), &swgUrlFilteringRules)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(updatedSwgUrlFilteringRules.ID)
 
 	err = swgUrlFilteringRulesToResource(d, updatedSwgUrlFilteringRules)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceSwgUrlFilteringRulesRead(d, m)
+	return resourceSwgUrlFilteringRulesRead(ctx, d, m)
 }
 
-func resourceSwgUrlFilteringRulesDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*Client)
+func resourceSwgUrlFilteringRulesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(sdk.API)
 
-	err := client.DeleteSwgUrlFilteringRules(d.Id())
-	return err
+	err := client.DeleteSwgUrlFilteringRules(ctx, d.Id())
+	return diag.FromErr(err)
 }
 
-func swgUrlFilteringRulesToResource(d *schema.ResourceData, m *SwgUrlFilteringRules) error {
+func swgUrlFilteringRulesToResource(d *schema.ResourceData, m *sdk.SwgUrlFilteringRules) error {
 	d.Set("name", m.Name)
 	d.Set("description", m.Description)
 	d.Set("action", m.Action)
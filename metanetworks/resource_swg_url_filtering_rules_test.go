@@ -0,0 +1,101 @@
+package metanetworks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"terraform-provider-metanetworks/sdk"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSwgUrlFilteringRules_basic(t *testing.T) {
+	var id string
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_swg_url_filtering_rules.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_swg_url_filtering_rules", testAccGetSwgUrlFilteringRules),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSwgUrlFilteringRulesConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetSwgUrlFilteringRules),
+					testAccCheckStoreID(resourceName, &id),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "description", "first"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "action", "BLOCK"),
+					resource.TestCheckResourceAttr(resourceName, "priority", "1"),
+					resource.TestCheckResourceAttr(resourceName, "sources.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "forbidden_content_categories.#", "1"),
+				),
+			},
+			{
+				Config: testAccSwgUrlFilteringRulesConfig(rName, "second", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIDChanged(resourceName, &id, false),
+					resource.TestCheckResourceAttr(resourceName, "description", "second"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSwgUrlFilteringRules_disappears(t *testing.T) {
+	rName := testAccRandomName(t)
+	resourceName := "metanetworks_swg_url_filtering_rules.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckDestroy("metanetworks_swg_url_filtering_rules", testAccGetSwgUrlFilteringRules),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSwgUrlFilteringRulesConfig(rName, "first", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckExists(resourceName, testAccGetSwgUrlFilteringRules),
+					testAccCheckDisappears(resourceName, sdk.API.DeleteSwgUrlFilteringRules),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccGetSwgUrlFilteringRules(client sdk.API, ctx context.Context, id string) error {
+	_, err := client.GetSwgUrlFilteringRules(ctx, id)
+	return err
+}
+
+func testAccSwgUrlFilteringRulesConfig(rName, description string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "metanetworks_group" "test" {
+  name = %[1]q
+}
+
+resource "metanetworks_swg_content_categories" "test" {
+  name  = %[1]q
+  types = ["Gambling"]
+}
+
+resource "metanetworks_swg_url_filtering_rules" "test" {
+  name                         = %[1]q
+  description                  = %[2]q
+  enabled                      = %[3]t
+  action                       = "BLOCK"
+  priority                     = 1
+  sources                      = [metanetworks_group.test.id]
+  forbidden_content_categories = [metanetworks_swg_content_categories.test.id]
+}
+`, rName, description, enabled)
+}
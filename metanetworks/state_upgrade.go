@@ -0,0 +1,53 @@
+package metanetworks
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// stateUpgrade upgrades the state of a resource from one schema version to
+// the next.
+type stateUpgrade struct {
+	// changed holds the attributes of the version upgraded from that differ
+	// from the next version, with a nil schema for the attributes added by
+	// the next version.
+	changed map[string]*schema.Schema
+	upgrade schema.StateUpgradeFunc
+}
+
+// withStateUpgrades sets the SchemaVersion of r to the number of upgrades,
+// the first one upgrading from version 0, and returns r. Terraform runs the
+// upgrades in order from the version of the state, so that a change of the
+// schema doesn't require tainting or importing the existing resources again.
+//
+// To change the schema of a resource in a way the existing states don't fit,
+// append an upgrade with the attributes as they were and a function rewriting
+// the state of the previous version.
+func withStateUpgrades(r *schema.Resource, upgrades ...stateUpgrade) *schema.Resource {
+	r.SchemaVersion = len(upgrades)
+	r.StateUpgraders = make([]schema.StateUpgrader, len(upgrades))
+
+	// Each version is the next one with its changes
+	attributes := r.Schema
+	for version := len(upgrades) - 1; version >= 0; version-- {
+		previous := make(map[string]*schema.Schema, len(attributes))
+		for key, s := range attributes {
+			previous[key] = s
+		}
+		for key, s := range upgrades[version].changed {
+			if s == nil {
+				delete(previous, key)
+			} else {
+				previous[key] = s
+			}
+		}
+
+		r.StateUpgraders[version] = schema.StateUpgrader{
+			Version: version,
+			Type:    (&schema.Resource{Schema: previous, Timeouts: r.Timeouts}).CoreConfigSchema().ImpliedType(),
+			Upgrade: upgrades[version].upgrade,
+		}
+		attributes = previous
+	}
+
+	return r
+}
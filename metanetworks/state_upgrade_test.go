@@ -0,0 +1,89 @@
+package metanetworks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestStateUpgraders checks that the resources upgrade the states of all
+// their previous schema versions, in order.
+func TestStateUpgraders(t *testing.T) {
+	for name, r := range Provider().ResourcesMap {
+		if len(r.StateUpgraders) != r.SchemaVersion {
+			t.Errorf("%s: %d state upgraders for schema version %d", name, len(r.StateUpgraders), r.SchemaVersion)
+			continue
+		}
+		for version, upgrader := range r.StateUpgraders {
+			if upgrader.Version != version {
+				t.Errorf("%s: state upgrader %d upgrades version %d", name, version, upgrader.Version)
+			}
+			if upgrader.Upgrade == nil {
+				t.Errorf("%s: state upgrader %d has no upgrade function", name, version)
+			}
+		}
+	}
+}
+
+// TestWithStateUpgrades checks the types of the states of the previous
+// versions, each one being the next one with its changes.
+func TestWithStateUpgrades(t *testing.T) {
+	upgrade := func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		return rawState, nil
+	}
+	r := withStateUpgrades(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":  {Type: schema.TypeString, Required: true},
+			"count": {Type: schema.TypeInt, Optional: true},
+		},
+	},
+		stateUpgrade{
+			changed: map[string]*schema.Schema{"size": {Type: schema.TypeInt, Optional: true}},
+			upgrade: upgrade,
+		},
+		stateUpgrade{
+			changed: map[string]*schema.Schema{"count": nil, "name": {Type: schema.TypeList, Elem: &schema.Schema{Type: schema.TypeString}, Optional: true}},
+			upgrade: upgrade,
+		},
+	)
+
+	if r.SchemaVersion != 2 {
+		t.Fatalf("SchemaVersion %d, want 2", r.SchemaVersion)
+	}
+	for version, want := range []map[string]string{
+		{"id": "string", "name": "list of string", "size": "number"},
+		{"id": "string", "name": "list of string"},
+	} {
+		attributes := r.StateUpgraders[version].Type.AttributeTypes()
+		if len(attributes) != len(want) {
+			t.Errorf("version %d has attributes %v, want %v", version, attributes, want)
+			continue
+		}
+		for key, typeName := range want {
+			if got, ok := attributes[key]; !ok || got.FriendlyName() != typeName {
+				t.Errorf("version %d attribute %s is a %v, want a %s", version, key, got, typeName)
+			}
+		}
+	}
+}
+
+// testStateUpgrade runs the state upgraders of resourceType from version on
+// rawState, checks that the result fits the current schema and returns it.
+func testStateUpgrade(t *testing.T, resourceType string, version int, rawState map[string]interface{}) map[string]interface{} {
+	t.Helper()
+
+	r := Provider().ResourcesMap[resourceType]
+	for _, upgrader := range r.StateUpgraders[version:] {
+		var err error
+		rawState, err = upgrader.Upgrade(context.Background(), rawState, nil)
+		if err != nil {
+			t.Fatalf("upgrading %s from version %d: %s", resourceType, upgrader.Version, err)
+		}
+	}
+
+	if _, err := schema.JSONMapToStateValue(rawState, r.CoreConfigSchema()); err != nil {
+		t.Fatalf("upgraded state of %s doesn't fit its schema: %s", resourceType, err)
+	}
+	return rawState
+}
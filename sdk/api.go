@@ -0,0 +1,250 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// mergePatchContentType is the content type of the PATCH requests, which
+// follow RFC 7396.
+const mergePatchContentType string = "application/merge-patch+json"
+
+// Request ...
+func (c *Client) Request(ctx context.Context, endpoint, method string, data []byte, contentType string) ([]byte, error) {
+	body, _, err := c.request(ctx, endpoint, method, data, contentType, nil)
+	return body, err
+}
+
+// request sends a request with the extra headers in header, and returns the
+// body and the headers of the response. GET requests go through the read
+// cache when the Client has one, and the other requests invalidate it.
+func (c *Client) request(ctx context.Context, endpoint, method string, data []byte, contentType string, header http.Header) ([]byte, http.Header, error) {
+	if c.cache == nil {
+		return c.send(ctx, endpoint, method, data, contentType, header)
+	}
+	if method == "GET" {
+		fetch := func(endpoint string) ([]byte, http.Header, error) {
+			return c.send(ctx, endpoint, method, data, contentType, header)
+		}
+		fetchAll := func(list string) ([]byte, http.Header, error) {
+			items, err := readPages(list, c.MaxListItems, fetch)
+			if err != nil {
+				return nil, nil, err
+			}
+			body, err := json.Marshal(items)
+			return body, nil, err
+		}
+		return c.cache.read(ctx, endpoint, fetch, fetchAll)
+	}
+
+	defer c.cache.invalidate(endpoint)
+	return c.send(ctx, endpoint, method, data, contentType, header)
+}
+
+// send sends a request, retrying it and authenticating again as needed.
+func (c *Client) send(ctx context.Context, endpoint, method string, data []byte, contentType string, header http.Header) ([]byte, http.Header, error) {
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	reauthenticated := false
+	for attempt := 0; ; attempt++ {
+		// Get the token on every attempt, it may expire while retrying
+		token, err := c.tokens.accessToken(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+endpoint, bytes.NewReader(data))
+
+		if err != nil {
+			return nil, nil, err
+		}
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		requestID := newRequestID()
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-Request-ID", requestID)
+		req.Header.Add("Authorization", "Bearer "+token)
+
+		c.logger.Trace(ctx, "Sending API request",
+			"method", method,
+			"path", endpoint,
+			"request_id", requestID,
+			"headers", redactHeaders(req.Header),
+			"body", c.redactBody(data),
+		)
+
+		start := time.Now()
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			c.logger.Debug(ctx, "API request failed",
+				"method", method,
+				"path", endpoint,
+				"request_id", requestID,
+				"latency", time.Since(start).String(),
+				"error", err.Error(),
+			)
+			if ctx.Err() == nil && attempt < c.MaxRetries && isRetryableError(method, err) {
+				if wait, err := c.retryWait(attempt, nil); err == nil {
+					c.logger.Debug(ctx, "Retrying API request", "method", method, "path", endpoint, "request_id", requestID, "wait", wait.String())
+					if err := sleepContext(ctx, wait); err != nil {
+						return nil, nil, err
+					}
+					continue
+				}
+			}
+			return nil, nil, err
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		c.logger.Debug(ctx, "API response",
+			"method", method,
+			"path", endpoint,
+			"status", resp.StatusCode,
+			"latency", time.Since(start).String(),
+			"request_id", requestID,
+		)
+		c.logger.Trace(ctx, "API response body",
+			"method", method,
+			"path", endpoint,
+			"request_id", requestID,
+			"body", c.redactBody(body),
+		)
+
+		// The token was revoked before its expiry, authenticate again once
+		if resp.StatusCode == http.StatusUnauthorized && !reauthenticated {
+			c.logger.Debug(ctx, "Authenticating again", "method", method, "path", endpoint)
+			c.tokens.invalidate(token)
+			reauthenticated = true
+			attempt--
+			continue
+		}
+		if resp.StatusCode != 200 {
+			apiError := newApiError(resp.StatusCode, body)
+			apiError.RequestID = requestID
+			if attempt < c.MaxRetries && isRetryableStatus(method, resp.StatusCode) {
+				wait, err := c.retryWait(attempt, resp)
+				if err != nil {
+					return nil, nil, fmt.Errorf("%s: %w", err, apiError)
+				}
+				c.logger.Debug(ctx, "Retrying API request", "method", method, "path", endpoint, "request_id", requestID, "status", resp.StatusCode, "wait", wait.String())
+				if err := sleepContext(ctx, wait); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+			return nil, nil, apiError
+		}
+
+		return body, resp.Header, nil
+	}
+}
+
+// Create posts the request to endpoint and decodes the created object into
+// out.
+func (c *Client) Create(ctx context.Context, endpoint string, request interface{}, out interface{}) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Request(ctx, endpoint, "POST", data, "application/json")
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse("POST", endpoint, resp, out)
+}
+
+// Read ...
+func (c *Client) Read(ctx context.Context, endpoint string, o interface{}) error {
+	_, err := c.readVersion(ctx, endpoint, o)
+	return err
+}
+
+// List reads every page of the list endpoint and decodes all their items
+// into out, a pointer to a slice. It fails if the list has more than
+// MaxListItems items.
+func (c *Client) List(ctx context.Context, endpoint string, out interface{}) error {
+	items, err := readPages(endpoint, c.MaxListItems, func(page string) ([]byte, http.Header, error) {
+		return c.request(ctx, page, "GET", nil, "application/json", nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return decodeResponse("GET", endpoint, data, out)
+}
+
+// readVersion reads the object at endpoint into o and returns its ETag, empty
+// if the API didn't return one.
+func (c *Client) readVersion(ctx context.Context, endpoint string, o interface{}) (string, error) {
+	resp, header, err := c.request(ctx, endpoint, "GET", nil, "application/json", nil)
+	if err != nil {
+		return "", err
+	}
+
+	return header.Get("ETag"), decodeResponse("GET", endpoint, resp, o)
+}
+
+// Patch sends the request to endpoint as a merge patch and decodes the
+// updated object into out. The fields left out of the request are unchanged,
+// and the null ones are cleared.
+func (c *Client) Patch(ctx context.Context, endpoint string, request interface{}, out interface{}) error {
+	_, err := c.patchIfMatch(ctx, endpoint, request, out, "")
+	return err
+}
+
+// patchIfMatch is Patch, applied only if the ETag of the object is still
+// etag unless etag is empty. Otherwise the API answers 412, see
+// IsPreconditionFailed. It returns the ETag of the updated object.
+func (c *Client) patchIfMatch(ctx context.Context, endpoint string, request interface{}, out interface{}, etag string) (string, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	var header http.Header
+	if etag != "" {
+		header = http.Header{"If-Match": []string{etag}}
+	}
+	resp, respHeader, err := c.request(ctx, endpoint, "PATCH", data, mergePatchContentType, header)
+	if err != nil {
+		return "", err
+	}
+
+	return respHeader.Get("ETag"), decodeResponse("PATCH", endpoint, resp, out)
+}
+
+// Delete ...
+func (c *Client) Delete(ctx context.Context, endpoint string) error {
+	_, err := c.Request(ctx, endpoint, "DELETE", nil, "application/json")
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// decodeResponse decodes the json response of a request into out.
+func decodeResponse(method, endpoint string, body []byte, out interface{}) error {
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("Could not decode the response of %s %s: %s", method, endpoint, err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package sdk_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"terraform-provider-metanetworks/internal/fakeapi"
+	"terraform-provider-metanetworks/sdk"
+)
+
+// recordingTransport keeps the headers of the requests it sends.
+type recordingTransport struct {
+	mu      sync.Mutex
+	headers []http.Header
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.headers = append(t.headers, req.Header.Clone())
+	t.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRequestHeaders(t *testing.T) {
+	ctx := context.Background()
+	server := fakeapi.NewServer(nil)
+	defer server.Close()
+
+	transport := &recordingTransport{}
+	client, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, &sdk.ClientOptions{
+		BaseURL:   server.URL,
+		Transport: transport,
+		UserAgent: "test-agent/1.0",
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	client.MaxRetries = 0
+
+	if _, err := client.GetLocations(ctx); err != nil {
+		t.Fatalf("GetLocations: %s", err)
+	}
+	_, err = client.GetPolicy(ctx, "pol-missing")
+	if !sdk.IsNotFound(err) {
+		t.Fatalf("GetPolicy of a missing policy returned %v", err)
+	}
+
+	// The OAuth request, the list and the failed read
+	if len(transport.headers) != 3 {
+		t.Fatalf("sent %d requests, want 3", len(transport.headers))
+	}
+	requestIDs := make(map[string]bool)
+	for _, header := range transport.headers {
+		if agent := header.Get("User-Agent"); agent != "test-agent/1.0" {
+			t.Errorf("request sent with User-Agent %q", agent)
+		}
+		requestID := header.Get("X-Request-ID")
+		if requestID == "" || requestIDs[requestID] {
+			t.Errorf("request sent with X-Request-ID %q, want a new one", requestID)
+		}
+		requestIDs[requestID] = true
+	}
+
+	lastID := transport.headers[2].Get("X-Request-ID")
+	if apiError, ok := err.(*sdk.ApiError); !ok || apiError.RequestID != lastID || !strings.Contains(err.Error(), lastID) {
+		t.Errorf("error %q does not hold the request ID %s", err, lastID)
+	}
+}
@@ -0,0 +1,304 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bulkReadThreshold is the number of distinct objects of a collection read
+// within the TTL of the cache after which the whole collection is listed, to
+// prime the cache with one request instead of one per object.
+const bulkReadThreshold int = 10
+
+// cachedCollections are the collections whose reads are cached. They are read
+// by many resources on every refresh, the others by one resource each.
+var cachedCollections = map[string]bool{
+	networkElementsEndpoint: true,
+}
+
+// readCache keeps the responses to the GET requests of a Client for a short
+// time, so that the resources refreshing the same object share one request.
+// Concurrent reads of the same endpoint are coalesced into one request, and
+// any other request to an object drops its cached reads, along with the lists
+// of its collection.
+type readCache struct {
+	ttl    time.Duration
+	logger Logger
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	calls   map[string]*cacheCall
+	// versions counts the writes to each object and collection, a read
+	// started before a write is not cached.
+	versions map[string]uint64
+	// misses holds the objects read recently from each list endpoint, with
+	// the time of the read.
+	misses map[string]map[string]time.Time
+}
+
+type cacheEntry struct {
+	body      []byte
+	header    http.Header
+	expiresAt time.Time
+}
+
+// cacheCall is a read in flight, the callers reading the same endpoint wait
+// for done and share its result. cancelled is set when the context of the
+// caller sending the request was done, its error isn't shared then.
+type cacheCall struct {
+	done      chan struct{}
+	body      []byte
+	header    http.Header
+	err       error
+	cancelled bool
+}
+
+// fetchFunc sends the GET request to endpoint.
+type fetchFunc func(endpoint string) ([]byte, http.Header, error)
+
+type freshReadsKey struct{}
+
+// WithFreshReads returns a context whose reads skip the read cache of the
+// Client, for the callers waiting for a change to become visible in the API.
+// Their responses still replace the cached ones.
+func WithFreshReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshReadsKey{}, true)
+}
+
+func newReadCache(ttl time.Duration, logger Logger) *readCache {
+	return &readCache{
+		ttl:      ttl,
+		logger:   logger,
+		entries:  make(map[string]*cacheEntry),
+		calls:    make(map[string]*cacheCall),
+		versions: make(map[string]uint64),
+		misses:   make(map[string]map[string]time.Time),
+	}
+}
+
+// read returns the response to the GET request of endpoint, from the cache if
+// possible, or else from fetch. fetchAll reads all the pages of a list
+// endpoint as a single json array.
+func (c *readCache) read(ctx context.Context, endpoint string, fetch, fetchAll fetchFunc) ([]byte, http.Header, error) {
+	collection, object := splitEndpoint(endpoint)
+	if !cachedCollections[collection] {
+		return fetch(endpoint)
+	}
+	if fresh, _ := ctx.Value(freshReadsKey{}).(bool); fresh {
+		return c.do(ctx, endpoint, false, fetch)
+	}
+
+	if body, header, ok := c.lookup(ctx, endpoint); ok {
+		return body, header, nil
+	}
+
+	// Many objects of the collection are read, list them all at once
+	if list, id, ok := listEndpoint(endpoint, collection, object); ok && c.missed(list, id) {
+		if _, _, err := c.do(ctx, list, true, fetchAll); err != nil {
+			c.logger.Debug(ctx, "Could not list the collection to prime the read cache", "path", list, "error", err.Error())
+		}
+		if body, header, ok := c.lookup(ctx, endpoint); ok {
+			return body, header, nil
+		}
+	}
+
+	return c.do(ctx, endpoint, true, fetch)
+}
+
+// lookup returns the cached response to endpoint.
+func (c *readCache) lookup(ctx context.Context, endpoint string) ([]byte, http.Header, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[endpoint]
+	if !ok {
+		return nil, nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, endpoint)
+		return nil, nil, false
+	}
+
+	c.logger.Trace(ctx, "API response from the read cache", "path", endpoint)
+	return entry.body, entry.header, true
+}
+
+// do fetches endpoint and caches the response. When join is set, the callers
+// reading the same endpoint at the same time share one request. The request
+// is sent with the context of the first caller: when it is cancelled, the
+// others send the request again with their own.
+func (c *readCache) do(ctx context.Context, endpoint string, join bool, fetch fetchFunc) ([]byte, http.Header, error) {
+	collection, object := splitEndpoint(endpoint)
+
+	c.mu.Lock()
+	for join {
+		call, ok := c.calls[endpoint]
+		if !ok {
+			break
+		}
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			if !call.cancelled || ctx.Err() != nil {
+				return call.body, call.header, call.err
+			}
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+		c.mu.Lock()
+	}
+	call := &cacheCall{done: make(chan struct{})}
+	if join {
+		c.calls[endpoint] = call
+	}
+	version := c.versions[object]
+	c.mu.Unlock()
+
+	call.body, call.header, call.err = fetch(endpoint)
+	call.cancelled = call.err != nil && ctx.Err() != nil
+
+	c.mu.Lock()
+	if c.calls[endpoint] == call {
+		delete(c.calls, endpoint)
+	}
+	if call.err == nil && c.versions[object] == version {
+		c.store(endpoint, call.body, call.header)
+		if object == collection {
+			c.prime(ctx, endpoint, call.body)
+		}
+	}
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.body, call.header, call.err
+}
+
+// store caches the response to endpoint. mu must be held.
+func (c *readCache) store(endpoint string, body []byte, header http.Header) {
+	c.entries[endpoint] = &cacheEntry{
+		body:      body,
+		header:    header,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+// prime caches the objects of the response to the list endpoint, or to a
+// page of it, as the responses to their reads with the same query. mu must
+// be held.
+func (c *readCache) prime(ctx context.Context, list string, body []byte) {
+	items, _, err := decodePage(body)
+	if err != nil {
+		return
+	}
+
+	list = withoutPageParams(list)
+	path, query := splitQuery(list)
+	primed := 0
+	for _, item := range items {
+		var object struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal(item, &object) != nil || object.ID == "" {
+			continue
+		}
+		c.store(path+"/"+object.ID+query, item, nil)
+		primed++
+	}
+	delete(c.misses, list)
+
+	c.logger.Debug(ctx, "Primed the read cache from a list", "path", list, "objects", primed)
+}
+
+// missed records a cache miss on the object id of the list endpoint, and
+// reports whether the list should be read instead, because enough objects
+// were missed recently or it is being read already.
+func (c *readCache) missed(list, id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.calls[list]; ok {
+		return true
+	}
+
+	now := time.Now()
+	ids, ok := c.misses[list]
+	if !ok {
+		ids = make(map[string]time.Time)
+		c.misses[list] = ids
+	}
+	for missedID, at := range ids {
+		if now.Sub(at) > c.ttl {
+			delete(ids, missedID)
+		}
+	}
+	ids[id] = now
+
+	return len(ids) >= bulkReadThreshold
+}
+
+// invalidate drops the cached reads of the object written by a request to
+// endpoint, and the lists of its collection.
+func (c *readCache) invalidate(endpoint string) {
+	collection, object := splitEndpoint(endpoint)
+	if !cachedCollections[collection] {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.versions[object]++
+	if object != collection {
+		c.versions[collection]++
+	}
+	for key := range c.entries {
+		if _, keyObject := splitEndpoint(key); keyObject == object || keyObject == collection {
+			delete(c.entries, key)
+		}
+	}
+	// Reads in flight may return the object as it was before the write
+	for key := range c.calls {
+		if _, keyObject := splitEndpoint(key); keyObject == object || keyObject == collection {
+			delete(c.calls, key)
+		}
+	}
+}
+
+// splitEndpoint returns the collection of endpoint, like /v1/network_elements,
+// and the object it belongs to, like /v1/network_elements/ne-1 for its
+// aliases. The object of a collection endpoint is the collection.
+func splitEndpoint(endpoint string) (collection, object string) {
+	path, _ := splitQuery(endpoint)
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 4)
+	if len(segments) < 2 {
+		return path, path
+	}
+	collection = "/" + segments[0] + "/" + segments[1]
+	if len(segments) < 3 {
+		return collection, collection
+	}
+	return collection, collection + "/" + segments[2]
+}
+
+// listEndpoint returns the list endpoint of the collection with the query of
+// the object read by endpoint, and the id of the object. ok is false if
+// endpoint doesn't read a whole object.
+func listEndpoint(endpoint, collection, object string) (list, id string, ok bool) {
+	path, query := splitQuery(endpoint)
+	if object == collection || path != object {
+		return "", "", false
+	}
+	return collection + query, strings.TrimPrefix(object, collection+"/"), true
+}
+
+// splitQuery splits endpoint into its path and its query, with the leading ?.
+func splitQuery(endpoint string) (path, query string) {
+	if i := strings.IndexByte(endpoint, '?'); i >= 0 {
+		return endpoint[:i], endpoint[i:]
+	}
+	return endpoint, ""
+}
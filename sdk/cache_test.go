@@ -0,0 +1,201 @@
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-metanetworks/internal/fakeapi"
+	"terraform-provider-metanetworks/sdk"
+)
+
+// slowTransport delays the requests, so that concurrent reads overlap.
+type slowTransport struct {
+	delay time.Duration
+}
+
+func (t slowTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	time.Sleep(t.delay)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newCachingClient(t *testing.T, transport http.RoundTripper) (*fakeapi.Server, *sdk.Client) {
+	t.Helper()
+
+	server := fakeapi.NewServer(nil)
+	t.Cleanup(server.Close)
+
+	client, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, &sdk.ClientOptions{
+		BaseURL:      server.URL,
+		Transport:    transport,
+		ReadCacheTTL: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	return server, client
+}
+
+// countRequests returns the number of requests sent by f.
+func countRequests(server *fakeapi.Server, f func()) int {
+	before := server.API.Requests()
+	f()
+	return server.API.Requests() - before
+}
+
+func TestReadCache(t *testing.T) {
+	ctx := context.Background()
+	server, client := newCachingClient(t, nil)
+
+	created, err := client.CreateNetworkElement(ctx, &sdk.CreateNetworkElementRequest{Name: "service", MappedService: "app.internal"})
+	if err != nil {
+		t.Fatalf("CreateNetworkElement: %s", err)
+	}
+	id := created.ID
+
+	get := func() *sdk.NetworkElement {
+		t.Helper()
+		element, err := client.GetNetworkElement(ctx, id)
+		if err != nil {
+			t.Fatalf("GetNetworkElement: %s", err)
+		}
+		return element
+	}
+
+	if n := countRequests(server, func() { get(); get() }); n != 1 {
+		t.Errorf("two reads sent %d requests, want 1", n)
+	}
+	if n := countRequests(server, func() { client.GetNetworkElement(sdk.WithFreshReads(ctx), id) }); n != 1 {
+		t.Errorf("a fresh read sent %d requests, want 1", n)
+	}
+
+	// Writes to the object and to its subresources invalidate its reads
+	if _, err := client.UpdateNetworkElement(ctx, id, &sdk.UpdateNetworkElementRequest{Description: sdk.String("updated")}); err != nil {
+		t.Fatalf("UpdateNetworkElement: %s", err)
+	}
+	if element := get(); element.Description != "updated" {
+		t.Errorf("read after an update returned description %q", element.Description)
+	}
+	if _, err := client.SetNetworkElementAlias(ctx, id, "app.example.com"); err != nil {
+		t.Fatalf("SetNetworkElementAlias: %s", err)
+	}
+	if element := get(); len(element.Aliases) != 1 {
+		t.Errorf("read after adding an alias returned aliases %v", element.Aliases)
+	}
+
+	if err := client.DeleteNetworkElement(ctx, id); err != nil {
+		t.Fatalf("DeleteNetworkElement: %s", err)
+	}
+	if _, err := client.GetNetworkElement(ctx, id); !sdk.IsNotFound(err) {
+		t.Errorf("read after a delete returned %v", err)
+	}
+
+	// Only network elements are cached
+	metaport, err := client.CreateMetaPort(ctx, &sdk.CreateMetaPortRequest{Name: "metaport"})
+	if err != nil {
+		t.Fatalf("CreateMetaPort: %s", err)
+	}
+	if n := countRequests(server, func() { client.GetMetaPort(ctx, metaport.ID); client.GetMetaPort(ctx, metaport.ID) }); n != 2 {
+		t.Errorf("two reads of a Metaport sent %d requests, want 2", n)
+	}
+}
+
+func TestReadCacheCoalescing(t *testing.T) {
+	ctx := context.Background()
+	server, client := newCachingClient(t, slowTransport{delay: 100 * time.Millisecond})
+
+	created, err := client.CreateNetworkElement(ctx, &sdk.CreateNetworkElementRequest{Name: "service", MappedService: "app.internal"})
+	if err != nil {
+		t.Fatalf("CreateNetworkElement: %s", err)
+	}
+
+	n := countRequests(server, func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.GetNetworkElement(ctx, created.ID); err != nil {
+					t.Errorf("GetNetworkElement: %s", err)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+	if n != 1 {
+		t.Errorf("20 concurrent reads sent %d requests, want 1", n)
+	}
+}
+
+// TestReadCacheCancelledRead cancels the read that the other concurrent reads
+// of the same object wait for, which then send it again.
+func TestReadCacheCancelledRead(t *testing.T) {
+	ctx := context.Background()
+	_, client := newCachingClient(t, slowTransport{delay: 100 * time.Millisecond})
+
+	created, err := client.CreateNetworkElement(ctx, &sdk.CreateNetworkElementRequest{Name: "service", MappedService: "app.internal"})
+	if err != nil {
+		t.Fatalf("CreateNetworkElement: %s", err)
+	}
+
+	first, cancel := context.WithCancel(ctx)
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetNetworkElement(first, created.ID)
+		firstErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetNetworkElement(ctx, created.ID); err != nil {
+				t.Errorf("GetNetworkElement waiting for a cancelled read: %s", err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	wg.Wait()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("the cancelled read returned %v, want %s", err, context.Canceled)
+	}
+}
+
+func TestReadCacheBulkRead(t *testing.T) {
+	ctx := context.Background()
+	server, client := newCachingClient(t, nil)
+
+	var ids []string
+	for i := 0; i < 150; i++ {
+		created, err := client.CreateNetworkElement(ctx, &sdk.CreateNetworkElementRequest{Name: fmt.Sprintf("service-%d", i), MappedService: "app.internal"})
+		if err != nil {
+			t.Fatalf("CreateNetworkElement: %s", err)
+		}
+		ids = append(ids, created.ID)
+	}
+
+	// The first reads miss, then the list primes the cache for the others
+	n := countRequests(server, func() {
+		for i, id := range ids {
+			element, err := client.GetNetworkElement(ctx, id)
+			if err != nil {
+				t.Fatalf("GetNetworkElement: %s", err)
+			}
+			if want := fmt.Sprintf("service-%d", i); element.Name != want {
+				t.Errorf("GetNetworkElement(%s) returned name %q, want %q", id, element.Name, want)
+			}
+		}
+	})
+	if n != 10 {
+		t.Errorf("reading %d network elements sent %d requests, want 10", len(ids), n)
+	}
+}
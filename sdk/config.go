@@ -0,0 +1,241 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBaseURL        string = "https://api.nsof.io"
+	oauthPath             string = "/v1/oauth/token"
+	maxIdleConnections    int    = 10
+	DefaultRequestTimeout int    = 60
+	DefaultUserAgent      string = "metanetworks-sdk-go"
+	configPath            string = ".metanetworks/credentials.json"
+)
+
+// Config ...
+type Config struct {
+	APIKey    string `json:"api_key"`
+	APISecret string `json:"api_secret"`
+	Org       string `json:"org"`
+
+	CredentialProcess string `json:"credential_process,omitempty"`
+}
+
+// ClientOptions holds the connection settings of a Client. The zero value
+// connects to the public Meta Networks API.
+type ClientOptions struct {
+	BaseURL        string
+	OAuthURL       string
+	ProxyURL       string
+	CABundleFile   string
+	ClientCertFile string
+	ClientKeyFile  string
+	RequestTimeout time.Duration
+
+	// RateLimit is the maximum number of requests per second, with bursts of
+	// up to RateBurst requests. MaxConcurrentRequests bounds the requests in
+	// flight. Zero means no limit.
+	RateLimit             float64
+	RateBurst             int
+	MaxConcurrentRequests int
+
+	// ReadCacheTTL is how long the reads of network elements are cached,
+	// zero disables the cache. Concurrent reads of the same object are
+	// coalesced, and when many objects are read the whole list is read at
+	// once instead.
+	ReadCacheTTL time.Duration
+
+	// Transport sends the requests in place of the transport built from
+	// ProxyURL and the TLS settings, which are then ignored. The rate limits
+	// still apply.
+	Transport http.RoundTripper
+
+	// UserAgent is sent in the User-Agent header of the requests,
+	// DefaultUserAgent if empty.
+	UserAgent string
+
+	// Logger receives the log entries of the requests, none are logged if
+	// nil.
+	Logger Logger
+}
+
+// Client ...
+type Client struct {
+	Org            string
+	BaseURL        string
+	OAuthURL       string
+	HTTPClient     *http.Client
+	MaxRetries     int
+	MaxRetryWait   time.Duration
+	MaxListItems   int
+	tokens         *tokenManager
+	logger         Logger
+	redactedFields map[string]bool
+	cache          *readCache
+}
+
+// Token ...
+type Token struct {
+	Token         string `json:"access_token"`
+	Expiry        int64  `json:"expires_in"`
+	RefreshToken  string `json:"refresh_token"`
+	RefreshExpiry int    `json:"refresh_expires_in"`
+	TokenType     string `json:"token_type"`
+}
+
+// Credentials ...
+type Credentials struct {
+	GrantType    string `json:"grant_type"`
+	Scope        string `json:"scope"`
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// NewClientFromConfig Returns a Client from the credentials of a profile found in a config file.
+// An empty path or profile selects the default ones.
+func NewClientFromConfig(path, profile string, options *ClientOptions) (*Client, error) {
+	if path == "" {
+		var err error
+		path, err = defaultConfigPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	config, err := loadConfig(path, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.CredentialProcess != "" {
+		client, err := NewClientFromProcess(config.CredentialProcess, config.Org, options)
+		if err != nil {
+			return nil, fmt.Errorf("Could not authenticate with profile %q from credentials file %s: %s", profile, path, err)
+		}
+		return client, nil
+	}
+
+	client, err := NewClient(config.APIKey, config.APISecret, config.Org, options)
+	if err != nil {
+		return nil, fmt.Errorf("Could not authenticate with profile %q from credentials file %s: %s", profile, path, err)
+	}
+
+	return client, nil
+}
+
+// NewClient Returns a Client from credentials passed as parameters
+func NewClient(key, secret, org string, options *ClientOptions) (*Client, error) {
+	if options == nil {
+		options = &ClientOptions{}
+	}
+
+	baseURL := strings.TrimSuffix(options.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	oauthURL := options.OAuthURL
+	if oauthURL == "" {
+		oauthURL = baseURL + oauthPath
+	}
+
+	logger := options.Logger
+	if logger == nil {
+		logger = nopLogger{}
+	}
+
+	httpClient, err := newHTTPClient(options, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := &tokenManager{
+		oauthURL:   oauthURL,
+		httpClient: httpClient,
+		logger:     logger,
+		apiKey:     key,
+		apiSecret:  secret,
+		org:        org,
+	}
+	err = tokens.authenticate(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	client := &Client{
+		Org:          org,
+		BaseURL:      baseURL,
+		OAuthURL:     oauthURL,
+		HTTPClient:   httpClient,
+		MaxRetries:   DefaultMaxRetries,
+		MaxRetryWait: time.Duration(DefaultMaxRetryWait) * time.Second,
+		MaxListItems: DefaultMaxListItems,
+		tokens:       tokens,
+		logger:       logger,
+	}
+	client.AddRedactedFields(defaultRedactedFields...)
+	if options.ReadCacheTTL > 0 {
+		client.cache = newReadCache(options.ReadCacheTTL, logger)
+	}
+
+	return client, nil
+}
+
+// MakeAuthReqest ...
+func MakeAuthReqest(ctx context.Context, oauthURL string, credentials *Credentials, client *http.Client) (*Token, error) {
+	jsonData, err := json.Marshal(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", oauthURL, bytes.NewReader(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	requestID := newRequestID()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", requestID)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Authentication request %s failed: %s", requestID, err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("Authentication failed with %s grant, the API returned %d to request %s: %s", credentials.GrantType, resp.StatusCode, requestID, strings.TrimSpace(string(body)))
+	}
+	var token Token
+	err = json.Unmarshal(body, &token)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse the response of the OAuth endpoint %s: %s", oauthURL, err)
+	}
+	if token.Token == "" {
+		return nil, fmt.Errorf("Authentication failed with %s grant, the API returned no access_token", credentials.GrantType)
+	}
+	return &token, nil
+}
+
+// RefreshToken renews the OAuth token with the refresh token, authenticating
+// again with the client credentials if the refresh token is rejected.
+func (c *Client) RefreshToken() error {
+	c.tokens.mu.Lock()
+	defer c.tokens.mu.Unlock()
+
+	return c.tokens.renew(context.Background())
+}
@@ -0,0 +1,130 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	credentialProcessTimeout time.Duration = 1 * time.Minute
+)
+
+// NewClientFromProcess Returns a Client from credentials printed by an external command.
+// The org of the command output takes precedence over the org passed as parameter.
+func NewClientFromProcess(command, org string, options *ClientOptions) (*Client, error) {
+	logger := Logger(nopLogger{})
+	if options != nil && options.Logger != nil {
+		logger = options.Logger
+	}
+
+	config, err := runCredentialProcess(context.Background(), logger, command, org)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(config.APIKey, config.APISecret, config.Org, options)
+	if err != nil {
+		return nil, fmt.Errorf("Could not authenticate with the credentials of credential_process: %s", err)
+	}
+	client.tokens.credentialProcess = command
+
+	return client, nil
+}
+
+// runCredentialProcess runs command and parses the credentials it prints on
+// stdout, a json object with keys: api_key, api_secret and org.
+func runCredentialProcess(ctx context.Context, logger Logger, command, org string) (*Config, error) {
+	args, err := splitCommand(command)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid credential_process %q: %s", command, err)
+	}
+	if len(args) == 0 {
+		return nil, errors.New("Invalid credential_process: the command is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	logger.Debug(ctx, "Running credential_process", "command", args[0])
+	err = cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("credential_process %s failed: %s %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var config Config
+	err = json.Unmarshal(stdout.Bytes(), &config)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse the output of credential_process %s, needs to be one json object with keys: api_key, api_secret and org. %s", args[0], err)
+	}
+	if config.Org == "" {
+		config.Org = org
+	}
+	if config.APIKey == "" || config.APISecret == "" || config.Org == "" {
+		return nil, fmt.Errorf("The output of credential_process %s needs to contain api_key, api_secret and org", args[0])
+	}
+
+	return &config, nil
+}
+
+// splitCommand splits a command line into arguments. Like the AWS CLI, the
+// command is not run through a shell, but single quotes, double quotes and
+// backslash escapes are honored.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range command {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if escaped {
+		return nil, errors.New("trailing backslash")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
@@ -0,0 +1,213 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplitCommand(t *testing.T) {
+	for _, test := range []struct {
+		command string
+		args    []string
+		err     string
+	}{
+		{"", nil, ""},
+		{"   ", nil, ""},
+		{"get-credentials", []string{"get-credentials"}, ""},
+		{"  get-credentials --profile  prod\t-v\n", []string{"get-credentials", "--profile", "prod", "-v"}, ""},
+		{`get-credentials "my profile" 'it''s'`, []string{"get-credentials", "my profile", "its"}, ""},
+		{`get-credentials --name="a b"c`, []string{"get-credentials", "--name=a bc"}, ""},
+		{`get-credentials 'a\b' "a\"b" a\ b`, []string{"get-credentials", `a\b`, `a"b`, "a b"}, ""},
+		{`get-credentials "" ''`, []string{"get-credentials", "", ""}, ""},
+		{`get-credentials "profile`, nil, "unterminated quote"},
+		{`get-credentials profile\`, nil, "trailing backslash"},
+	} {
+		t.Run(test.command, func(t *testing.T) {
+			args, err := splitCommand(test.command)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("splitCommand returned %v, want %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("splitCommand: %s", err)
+			}
+			if !reflect.DeepEqual(args, test.args) {
+				t.Errorf("splitCommand = %q, want %q", args, test.args)
+			}
+		})
+	}
+}
+
+// TestHelperProcess isn't a real test, it is the credential process run by
+// the other tests: it prints its first argument, or with -fail prints it on
+// stderr and exits with 2.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+
+	args := os.Args
+	for len(args) > 0 && args[0] != "--" {
+		args = args[1:]
+	}
+	if len(args) == 3 && args[1] == "-fail" {
+		fmt.Fprint(os.Stderr, args[2])
+		os.Exit(2)
+	}
+	if len(args) == 2 {
+		fmt.Print(args[1])
+		os.Exit(0)
+	}
+	fmt.Fprintf(os.Stderr, "unexpected arguments %q", args)
+	os.Exit(1)
+}
+
+// helperCommand returns the command line running TestHelperProcess with args.
+func helperCommand(args ...string) string {
+	command := fmt.Sprintf("'%s' -test.run=TestHelperProcess --", os.Args[0])
+	for _, arg := range args {
+		command += " '" + arg + "'"
+	}
+	return command
+}
+
+func TestRunCredentialProcess(t *testing.T) {
+	os.Setenv("GO_WANT_HELPER_PROCESS", "1")
+	defer os.Unsetenv("GO_WANT_HELPER_PROCESS")
+
+	for _, test := range []struct {
+		name    string
+		command string
+		org     string
+		config  *Config
+		err     string
+	}{
+		{
+			name:    "credentials",
+			command: helperCommand(`{"api_key": "key", "api_secret": "secret", "org": "org", "expiration": "2030-01-01T00:00:00Z"}`),
+			org:     "default-org",
+			config:  &Config{APIKey: "key", APISecret: "secret", Org: "org"},
+		},
+		{
+			name:    "default org",
+			command: helperCommand(`{"api_key": "key", "api_secret": "secret"}`),
+			org:     "default-org",
+			config:  &Config{APIKey: "key", APISecret: "secret", Org: "default-org"},
+		},
+		{
+			name:    "missing api_secret",
+			command: helperCommand(`{"api_key": "key", "org": "org"}`),
+			err:     "needs to contain api_key, api_secret and org",
+		},
+		{
+			name:    "missing org",
+			command: helperCommand(`{"api_key": "key", "api_secret": "secret"}`),
+			err:     "needs to contain api_key, api_secret and org",
+		},
+		{
+			name:    "not json",
+			command: helperCommand("api_key=key"),
+			err:     "Could not parse the output of credential_process",
+		},
+		{
+			name:    "several objects",
+			command: helperCommand(`{"api_key": "key"} {"api_secret": "secret"}`),
+			err:     "Could not parse the output of credential_process",
+		},
+		{
+			name:    "non-zero exit",
+			command: helperCommand("-fail", "the session has expired"),
+			err:     "exit status 2 the session has expired",
+		},
+		{
+			name:    "not found",
+			command: "./metanetworks-credentials-not-found",
+			err:     "credential_process ./metanetworks-credentials-not-found failed",
+		},
+		{
+			name:    "empty",
+			command: " ",
+			err:     "the command is empty",
+		},
+		{
+			name:    "invalid",
+			command: `get-credentials "profile`,
+			err:     "unterminated quote",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := runCredentialProcess(context.Background(), nopLogger{}, test.command, test.org)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("runCredentialProcess returned %v, want an error containing %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("runCredentialProcess: %s", err)
+			}
+			if !reflect.DeepEqual(config, test.config) {
+				t.Errorf("runCredentialProcess = %+v, want %+v", config, test.config)
+			}
+		})
+	}
+}
+
+// newOAuthServer returns a server granting tokens to the credentials key and
+// secret, and answering the other requests with an empty object. The refresh
+// tokens are always rejected, as if they were revoked.
+func newOAuthServer(key, secret string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != oauthPath {
+			w.Write([]byte("{}"))
+			return
+		}
+
+		var credentials Credentials
+		json.NewDecoder(r.Body).Decode(&credentials)
+		if credentials.GrantType != "client_credentials" || credentials.ClientID != key || credentials.ClientSecret != secret {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("{}"))
+			return
+		}
+		json.NewEncoder(w).Encode(&Token{Token: "token", Expiry: 3600, RefreshToken: "refresh"})
+	}))
+}
+
+func TestNewClientFromProcess(t *testing.T) {
+	os.Setenv("GO_WANT_HELPER_PROCESS", "1")
+	defer os.Unsetenv("GO_WANT_HELPER_PROCESS")
+
+	server := newOAuthServer("key", "secret")
+	defer server.Close()
+
+	client, err := NewClientFromProcess(helperCommand(`{"api_key": "key", "api_secret": "secret"}`), "org", &ClientOptions{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClientFromProcess: %s", err)
+	}
+
+	// The credentials are fetched again when the token can't be renewed
+	client.tokens.token.Token = "expired"
+	client.tokens.renewAt = time.Time{}
+	if _, err := client.Request(context.Background(), "/v1/locations", "GET", nil, ""); err != nil {
+		t.Errorf("Request with an expired token: %s", err)
+	}
+	if client.tokens.token.Token != "token" {
+		t.Errorf("the expired token was replaced with %q", client.tokens.token.Token)
+	}
+
+	_, err = NewClientFromProcess(helperCommand(`{"api_key": "key", "api_secret": "wrong"}`), "org", &ClientOptions{BaseURL: server.URL})
+	if err == nil || !strings.Contains(err.Error(), "Could not authenticate with the credentials of credential_process") {
+		t.Errorf("NewClientFromProcess with wrong credentials returned %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	DefaultProfile string = "default"
+)
+
+// defaultConfigPath returns the location of the credentials file in the home
+// directory of the current user.
+func defaultConfigPath() (string, error) {
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("Could not find the home directory to load %s: %s", configPath, err)
+	}
+
+	return filepath.Join(dir, configPath), nil
+}
+
+// loadConfig reads the credentials of a profile from a credentials file.
+//
+// The file either holds named profiles:
+//
+//	{
+//	  "default": {"api_key": "...", "api_secret": "...", "org": "..."},
+//	  "staging": {"api_key": "...", "api_secret": "...", "org": "..."}
+//	}
+//
+// or, as in earlier versions, a single set of credentials which is then the
+// default profile. Instead of api_key and api_secret a profile may set
+// credential_process, a command printing the credentials.
+func loadConfig(path, profile string) (*Config, error) {
+	configBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Could not read credentials file %s for profile %q: %s", path, profile, err)
+	}
+
+	var entries map[string]json.RawMessage
+	err = json.Unmarshal(configBytes, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse credentials file %s, needs to contain a json object of profiles with keys: api_key, api_secret and org. %s", path, err)
+	}
+
+	if isSingleConfig(entries) {
+		if profile != DefaultProfile {
+			return nil, fmt.Errorf("Profile %q not found in credentials file %s, which only holds the %q profile", profile, path, DefaultProfile)
+		}
+
+		var config Config
+		err = json.Unmarshal(configBytes, &config)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse credentials file %s: %s", path, err)
+		}
+		return validateConfig(&config, path, profile)
+	}
+
+	entry, ok := entries[profile]
+	if !ok {
+		return nil, fmt.Errorf("Profile %q not found in credentials file %s, available profiles: %s", profile, path, strings.Join(profileNames(entries), ", "))
+	}
+
+	var config Config
+	err = json.Unmarshal(entry, &config)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse profile %q in credentials file %s, needs to contain keys: api_key, api_secret and org. %s", profile, path, err)
+	}
+
+	return validateConfig(&config, path, profile)
+}
+
+func validateConfig(config *Config, path, profile string) (*Config, error) {
+	var missing []string
+	// The credential process prints the key and secret, and may print the org
+	if config.CredentialProcess != "" {
+		return config, nil
+	}
+	if config.APIKey == "" {
+		missing = append(missing, "api_key")
+	}
+	if config.APISecret == "" {
+		missing = append(missing, "api_secret")
+	}
+	if config.Org == "" {
+		missing = append(missing, "org")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("Profile %q in credentials file %s is missing: %s", profile, path, strings.Join(missing, ", "))
+	}
+
+	return config, nil
+}
+
+// isSingleConfig reports whether a credentials file holds a single set of
+// credentials rather than named profiles. Profiles are objects, so any string
+// value means the keys are credential fields.
+func isSingleConfig(entries map[string]json.RawMessage) bool {
+	for _, value := range entries {
+		var s string
+		if json.Unmarshal(value, &s) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func profileNames(entries map[string]json.RawMessage) []string {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
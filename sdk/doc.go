@@ -0,0 +1,8 @@
+// Package sdk is a client of the Meta Networks API. It doesn't depend on
+// Terraform, so that any Go program can use it.
+//
+// A Client is created with NewClient, NewClientFromConfig or
+// NewClientFromProcess and implements API, which groups an interface per
+// service of the API, like PoliciesAPI. Callers should depend on the narrowest
+// interface they need, so that the client can be replaced by a mock in tests.
+package sdk
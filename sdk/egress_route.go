@@ -0,0 +1,101 @@
+package sdk
+
+import (
+	"context"
+)
+
+const (
+	egressRoutesEndpoint string = "/v1/egress_routes"
+)
+
+// EgressRoute ...
+type EgressRoute struct {
+	Description   string   `json:"description"`
+	Destinations  []string `json:"destinations,omitempty"`
+	Enabled       bool     `json:"enabled"`
+	ExemptSources []string `json:"exempt_sources,omitempty"`
+	Name          string   `json:"name"`
+	Sources       []string `json:"sources,omitempty"`
+	Via           string   `json:"via"`
+	CreatedAt     string   `json:"created_at,omitempty"`
+	ID            string   `json:"id,omitempty"`
+	ModifiedAt    string   `json:"modified_at,omitempty"`
+	OrgID         string   `json:"org_id,omitempty"`
+}
+
+// CreateEgressRouteRequest is the body of a request creating a EgressRoute.
+type CreateEgressRouteRequest struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Enabled       bool     `json:"enabled"`
+	Destinations  []string `json:"destinations,omitempty"`
+	ExemptSources []string `json:"exempt_sources,omitempty"`
+	Sources       []string `json:"sources,omitempty"`
+	Via           string   `json:"via"`
+}
+
+// UpdateEgressRouteRequest is a merge patch of a EgressRoute, its nil fields
+// are left unchanged.
+type UpdateEgressRouteRequest struct {
+	Name          *string          `json:"name,omitempty"`
+	Description   *string          `json:"description,omitempty"`
+	Enabled       *bool            `json:"enabled,omitempty"`
+	Destinations  *NullableStrings `json:"destinations,omitempty"`
+	ExemptSources *NullableStrings `json:"exempt_sources,omitempty"`
+	Sources       *NullableStrings `json:"sources,omitempty"`
+	Via           *string          `json:"via,omitempty"`
+}
+
+// ListEgressRoutes returns all the egress routes.
+func (c *Client) ListEgressRoutes(ctx context.Context) ([]EgressRoute, error) {
+	var egressRoutes []EgressRoute
+	err := c.List(ctx, egressRoutesEndpoint, &egressRoutes)
+	if err != nil {
+		return nil, err
+	}
+
+	return egressRoutes, nil
+}
+
+// GetEgressRoute ...
+func (c *Client) GetEgressRoute(ctx context.Context, egressRouteID string) (*EgressRoute, error) {
+	var egressRoute EgressRoute
+	err := c.Read(ctx, egressRoutesEndpoint+"/"+egressRouteID, &egressRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	return &egressRoute, nil
+}
+
+// UpdateEgressRoute ...
+func (c *Client) UpdateEgressRoute(ctx context.Context, egressRouteID string, request *UpdateEgressRouteRequest) (*EgressRoute, error) {
+	var updatedEgressRoute EgressRoute
+	err := c.Patch(ctx, egressRoutesEndpoint+"/"+egressRouteID, request, &updatedEgressRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedEgressRoute, nil
+}
+
+// CreateEgressRoute ...
+func (c *Client) CreateEgressRoute(ctx context.Context, request *CreateEgressRouteRequest) (*EgressRoute, error) {
+	var createdEgressRoute EgressRoute
+	err := c.Create(ctx, egressRoutesEndpoint, request, &createdEgressRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdEgressRoute, nil
+}
+
+// DeleteEgressRoute ...
+func (c *Client) DeleteEgressRoute(ctx context.Context, egressRouteID string) error {
+	err := c.Delete(ctx, egressRoutesEndpoint+"/"+egressRouteID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ApiError is returned by the Client when the API answers with an error status.
+// When the body is a json error payload, its fields are parsed into Code,
+// Message and Details. Err always holds the raw body. RequestID is the
+// X-Request-ID header of the failed request, for the support of Meta Networks
+// to find it in the logs of the API.
+type ApiError struct {
+	Err        error
+	StatusCode int
+	Code       string
+	Message    string
+	Details    []ApiErrorDetail
+	RequestID  string
+}
+
+// ApiErrorDetail is a validation error of a single field of the request.
+type ApiErrorDetail struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// apiErrorBody covers the error payloads of the API: {"code", "message",
+// "errors"} and the problem details of RFC 7807 {"title", "detail"}.
+type apiErrorBody struct {
+	Code    json.RawMessage  `json:"code"`
+	Error   string           `json:"error"`
+	Title   string           `json:"title"`
+	Message string           `json:"message"`
+	Detail  string           `json:"detail"`
+	Errors  []ApiErrorDetail `json:"errors"`
+	Fields  []ApiErrorDetail `json:"fields"`
+}
+
+func newApiError(statusCode int, body []byte) *ApiError {
+	apiError := &ApiError{
+		StatusCode: statusCode,
+		Err:        errors.New(string(body)),
+	}
+
+	var payload apiErrorBody
+	if json.Unmarshal(body, &payload) != nil {
+		return apiError
+	}
+
+	apiError.Code = firstNonEmpty(parseErrorCode(payload.Code), payload.Error, payload.Title)
+	apiError.Message = firstNonEmpty(payload.Message, payload.Detail)
+	apiError.Details = append(payload.Errors, payload.Fields...)
+
+	return apiError
+}
+
+func (e *ApiError) Error() string {
+	var b strings.Builder
+	// Bodies that aren't a json error payload are shown as they are, empty
+	// ones get the status instead
+	if e.Message == "" && e.Code == "" && len(e.Details) == 0 && e.Err != nil && e.Err.Error() != "" {
+		fmt.Fprintf(&b, "%s", e.Err)
+		if e.RequestID != "" {
+			fmt.Fprintf(&b, " (request %s)", e.RequestID)
+		}
+		return b.String()
+	}
+
+	status := http.StatusText(e.StatusCode)
+	fmt.Fprintf(&b, "%d %s", e.StatusCode, status)
+	if e.Code != "" && e.Code != status && e.Code != strconv.Itoa(e.StatusCode) {
+		fmt.Fprintf(&b, " (%s)", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request %s)", e.RequestID)
+	}
+	for _, detail := range e.Details {
+		if detail.Field != "" {
+			fmt.Fprintf(&b, "\n  %s: %s", detail.Field, detail.Message)
+		} else {
+			fmt.Fprintf(&b, "\n  %s", detail.Message)
+		}
+	}
+
+	return b.String()
+}
+
+// IsNotFound reports whether err is an API error for a missing object.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an API error for a conflicting change.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsPreconditionFailed reports whether err is an API error for an update
+// whose If-Match header didn't match the version of the object, because it
+// was changed since it was read.
+func IsPreconditionFailed(err error) bool {
+	return hasStatus(err, http.StatusPreconditionFailed)
+}
+
+// busyMessage ends the errors of the API for a write to an object which is
+// still being updated by a previous write.
+const busyMessage string = "is busy. Try again later."
+
+// IsBusy reports whether err is an API error for a write to an object which
+// is still being updated, which succeeds when sent again a bit later. The API
+// reports it with its message rather than a status of its own, so it is
+// classified by the message, or by a 423 Locked status.
+func IsBusy(err error) bool {
+	var apiError *ApiError
+	if !errors.As(err, &apiError) {
+		return false
+	}
+	return apiError.StatusCode == http.StatusLocked ||
+		strings.HasSuffix(apiError.Message, busyMessage) ||
+		strings.HasSuffix(strings.TrimSpace(apiError.Err.Error()), busyMessage)
+}
+
+// IsValidation reports whether err is an API error for an invalid request.
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest) || hasStatus(err, http.StatusUnprocessableEntity)
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var apiError *ApiError
+	return errors.As(err, &apiError) && apiError.StatusCode == statusCode
+}
+
+// parseErrorCode accepts both string and numeric error codes.
+func parseErrorCode(code json.RawMessage) string {
+	if len(code) == 0 {
+		return ""
+	}
+
+	var s string
+	if json.Unmarshal(code, &s) == nil {
+		return s
+	}
+	if string(code) == "null" {
+		return ""
+	}
+	return string(code)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestNewApiError(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		status  int
+		body    string
+		code    string
+		message string
+		details []ApiErrorDetail
+		err     string
+	}{
+		{
+			name:    "json",
+			status:  400,
+			body:    `{"code": "validation_error", "message": "Invalid request", "errors": [{"field": "name", "message": "is required"}, {"message": "too many tags"}]}`,
+			code:    "validation_error",
+			message: "Invalid request",
+			details: []ApiErrorDetail{{Field: "name", Message: "is required"}, {Message: "too many tags"}},
+			err:     "400 Bad Request (validation_error): Invalid request (request req-1)\n  name: is required\n  too many tags",
+		},
+		{
+			name:    "problem details",
+			status:  404,
+			body:    `{"title": "Not Found", "detail": "No such metaport"}`,
+			code:    "Not Found",
+			message: "No such metaport",
+			err:     "404 Not Found: No such metaport (request req-1)",
+		},
+		{
+			name:    "numeric code",
+			status:  409,
+			body:    `{"code": 1042, "message": "Name already in use", "fields": [{"field": "name", "message": "not unique"}]}`,
+			code:    "1042",
+			message: "Name already in use",
+			details: []ApiErrorDetail{{Field: "name", Message: "not unique"}},
+			err:     "409 Conflict (1042): Name already in use (request req-1)\n  name: not unique",
+		},
+		{
+			name:   "null code",
+			status: 500,
+			body:   `{"code": null, "error": "internal"}`,
+			code:   "internal",
+			err:    "500 Internal Server Error (internal) (request req-1)",
+		},
+		{
+			name:   "not json",
+			status: 502,
+			body:   "<html>Bad Gateway</html>",
+			err:    "<html>Bad Gateway</html> (request req-1)",
+		},
+		{
+			name:   "empty",
+			status: 503,
+			body:   "",
+			err:    "503 Service Unavailable (request req-1)",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			apiError := newApiError(test.status, []byte(test.body))
+			apiError.RequestID = "req-1"
+
+			if apiError.StatusCode != test.status {
+				t.Errorf("StatusCode = %d, want %d", apiError.StatusCode, test.status)
+			}
+			if apiError.Code != test.code {
+				t.Errorf("Code = %q, want %q", apiError.Code, test.code)
+			}
+			if apiError.Message != test.message {
+				t.Errorf("Message = %q, want %q", apiError.Message, test.message)
+			}
+			if !reflect.DeepEqual(apiError.Details, test.details) && (len(apiError.Details) > 0 || len(test.details) > 0) {
+				t.Errorf("Details = %v, want %v", apiError.Details, test.details)
+			}
+			if apiError.Err.Error() != test.body {
+				t.Errorf("Err = %q, want the raw body %q", apiError.Err, test.body)
+			}
+			if got := apiError.Error(); got != test.err {
+				t.Errorf("Error() = %q, want %q", got, test.err)
+			}
+		})
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	notFound := newApiError(404, []byte(`{"message": "not found"}`))
+
+	for _, test := range []struct {
+		name               string
+		err                error
+		notFound           bool
+		conflict           bool
+		preconditionFailed bool
+		validation         bool
+	}{
+		{"nil", nil, false, false, false, false},
+		{"not an api error", errors.New("404 not found"), false, false, false, false},
+		{"json body", notFound, true, false, false, false},
+		{"non-json body", newApiError(404, []byte("Not Found")), true, false, false, false},
+		{"empty body", newApiError(404, nil), true, false, false, false},
+		{"wrapped", fmt.Errorf("reading metaport: %w", notFound), true, false, false, false},
+		{"conflict", newApiError(409, nil), false, true, false, false},
+		{"precondition failed", newApiError(412, nil), false, false, true, false},
+		{"bad request", newApiError(400, nil), false, false, false, true},
+		{"unprocessable entity", newApiError(422, nil), false, false, false, true},
+		{"server error", newApiError(500, []byte(`{"code": 404}`)), false, false, false, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsNotFound(test.err); got != test.notFound {
+				t.Errorf("IsNotFound = %t, want %t", got, test.notFound)
+			}
+			if got := IsConflict(test.err); got != test.conflict {
+				t.Errorf("IsConflict = %t, want %t", got, test.conflict)
+			}
+			if got := IsPreconditionFailed(test.err); got != test.preconditionFailed {
+				t.Errorf("IsPreconditionFailed = %t, want %t", got, test.preconditionFailed)
+			}
+			if got := IsValidation(test.err); got != test.validation {
+				t.Errorf("IsValidation = %t, want %t", got, test.validation)
+			}
+		})
+	}
+}
+
+func TestIsBusy(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		err  error
+		busy bool
+	}{
+		{"nil", nil, false},
+		{"not an api error", errors.New("Metaport is busy. Try again later."), false},
+		{"json message", newApiError(400, []byte(`{"message": "Metaport is busy. Try again later."}`)), true},
+		{"raw body", newApiError(400, []byte("Metaport is busy. Try again later.\n")), true},
+		{"wrapped", fmt.Errorf("updating metaport: %w", newApiError(400, []byte("Metaport is busy. Try again later."))), true},
+		{"locked", newApiError(423, nil), true},
+		{"other validation error", newApiError(400, []byte(`{"message": "Invalid name"}`)), false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsBusy(test.err); got != test.busy {
+				t.Errorf("IsBusy = %t, want %t", got, test.busy)
+			}
+		})
+	}
+}
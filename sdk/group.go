@@ -0,0 +1,150 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+const (
+	groupsEndpoint string = "/v1/groups"
+)
+
+// Group ...
+type Group struct {
+	Description   string   `json:"description"`
+	Expression    string   `json:"expression,omitempty"`
+	Name          string   `json:"name"`
+	ProvisionedBy string   `json:"provisioned_by,omitempty"`
+	Roles         []string `json:"roles,omitempty"`
+	Users         []string `json:"users,omitempty"`
+	CreatedAt     string   `json:"created_at,omitempty"`
+	ID            string   `json:"id,omitempty"`
+	Members       []string `json:"members,omitempty"`
+	ModifiedAt    string   `json:"modified_at,omitempty"`
+	OrgID         string   `json:"org_id,omitempty"`
+}
+
+// CreateGroupRequest is the body of a request creating a Group.
+type CreateGroupRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Expression  string `json:"expression,omitempty"`
+}
+
+// UpdateGroupRequest is a merge patch of a Group, its nil fields are left
+// unchanged.
+type UpdateGroupRequest struct {
+	Name        *string         `json:"name,omitempty"`
+	Description *string         `json:"description,omitempty"`
+	Expression  *NullableString `json:"expression,omitempty"`
+}
+
+// GetGroups returns the groups named name, or all of them if name is empty.
+func (c *Client) GetGroups(ctx context.Context, name string) ([]Group, error) {
+	var groups []Group
+	err := c.List(ctx, groupsEndpoint+"?expand=true&name="+url.QueryEscape(name), &groups)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if name != "" && len(groups) == 0 {
+		return nil, fmt.Errorf("Not found: %s", name)
+	}
+
+	return groups, nil
+}
+
+// GetGroup ...
+func (c *Client) GetGroup(ctx context.Context, elementID string) (*Group, error) {
+	var Group Group
+	err := c.Read(ctx, groupsEndpoint+"/"+elementID, &Group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Group, nil
+}
+
+// UpdateGroup ...
+func (c *Client) UpdateGroup(ctx context.Context, groupID string, request *UpdateGroupRequest) (*Group, error) {
+	var updatedGroup Group
+	err := c.Patch(ctx, groupsEndpoint+"/"+groupID, request, &updatedGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedGroup, nil
+}
+
+// CreateGroup ...
+func (c *Client) CreateGroup(ctx context.Context, request *CreateGroupRequest) (*Group, error) {
+	var createdGroup Group
+	err := c.Create(ctx, groupsEndpoint, request, &createdGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdGroup, nil
+}
+
+// DeleteGroup ...
+func (c *Client) DeleteGroup(ctx context.Context, groupID string) error {
+	err := c.Delete(ctx, groupsEndpoint+"/"+groupID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) updateGroupUsers(ctx context.Context, groupID string, users []string, operation string) (*Group, error) {
+	jsonData, err := json.Marshal(users)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Request(ctx, groupsEndpoint+"/"+groupID+"/"+operation+"/", "POST", jsonData, "application/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var group Group
+	err = json.Unmarshal(resp, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// AddGroupUsers ...
+func (c *Client) AddGroupUsers(ctx context.Context, groupID string, users []string) (*Group, error) {
+	return c.updateGroupUsers(ctx, groupID, users, "add_users")
+}
+
+// RemoveGroupUsers ...
+func (c *Client) RemoveGroupUsers(ctx context.Context, groupID string, users []string) (*Group, error) {
+	return c.updateGroupUsers(ctx, groupID, users, "remove_users")
+}
+
+// SetGroupRoles ...
+func (c *Client) SetGroupRoles(ctx context.Context, groupID string, roles []string) (*Group, error) {
+	jsonData, err := json.Marshal(roles)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Request(ctx, groupsEndpoint+"/"+groupID+"/roles/", "PUT", jsonData, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	var group Group
+	err = json.Unmarshal(resp, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
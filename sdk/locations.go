@@ -1,4 +1,8 @@
-package metanetworks
+package sdk
+
+import (
+	"context"
+)
 
 const (
 	locationsEndpoint string = "/v1/locations"
@@ -16,9 +20,9 @@ type Location struct {
 }
 
 // GetLocations ...
-func (c *Client) GetLocations() ([]Location, error) {
+func (c *Client) GetLocations(ctx context.Context) ([]Location, error) {
 	var locations []Location
-	err := c.Read(locationsEndpoint, &locations)
+	err := c.List(ctx, locationsEndpoint, &locations)
 	if err != nil {
 		return nil, err
 	}
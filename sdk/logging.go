@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const redactedValue string = "***"
+
+// defaultRedactedFields are the json keys whose values never reach the logs.
+var defaultRedactedFields = []string{
+	"access_token",
+	"api_secret",
+	"client_secret",
+	"password",
+	"refresh_token",
+	"secret",
+}
+
+// Logger receives the log entries of the API requests of a Client, with their
+// fields as alternating keys and values. The bodies are only logged at the
+// trace level, with secrets redacted.
+type Logger interface {
+	Debug(ctx context.Context, msg string, fields ...interface{})
+	Trace(ctx context.Context, msg string, fields ...interface{})
+}
+
+// nopLogger discards the log entries of the clients without a Logger.
+type nopLogger struct{}
+
+func (nopLogger) Debug(ctx context.Context, msg string, fields ...interface{}) {}
+
+func (nopLogger) Trace(ctx context.Context, msg string, fields ...interface{}) {}
+
+// AddRedactedFields adds json keys to redact from the logged bodies.
+func (c *Client) AddRedactedFields(names ...string) {
+	if c.redactedFields == nil {
+		c.redactedFields = make(map[string]bool)
+	}
+	for _, name := range names {
+		c.redactedFields[name] = true
+	}
+}
+
+// redactBody returns a json body for the logs, with the values of redacted
+// keys replaced at any depth. Bodies that are not json are not logged.
+func (c *Client) redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if json.Unmarshal(body, &value) != nil {
+		return fmt.Sprintf("<%d bytes not json>", len(body))
+	}
+
+	redacted, err := json.Marshal(c.redactValue(value))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	return string(redacted)
+}
+
+func (c *Client) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if c.redactedFields[strings.ToLower(key)] {
+				v[key] = redactedValue
+			} else {
+				v[key] = c.redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = c.redactValue(item)
+		}
+	}
+	return value
+}
+
+// redactHeaders returns the headers of a request for the logs, without the
+// bearer token.
+func redactHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name := range header {
+		if name == "Authorization" {
+			headers[name] = "Bearer " + redactedValue
+			continue
+		}
+		headers[name] = header.Get(name)
+	}
+	return headers
+}
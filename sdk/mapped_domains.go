@@ -1,8 +1,8 @@
-package metanetworks
+package sdk
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 )
 
 // MappedDomain ...
@@ -13,11 +13,9 @@ type MappedDomain struct {
 }
 
 // GetMappedDomain ...
-func (c *Client) GetMappedDomain(networkElementID string, name string) (*MappedDomain, error) {
+func (c *Client) GetMappedDomain(ctx context.Context, networkElementID string, name string) (*MappedDomain, error) {
 	var mappedDomain MappedDomain
-	url := networkElementsEndpoint + " / " + networkElementID + " / mapped_domains / " + name
-	log.Printf("URL: %s", url)
-	err := c.Read(networkElementsEndpoint+"/"+networkElementID+"/mapped_domains/"+name, &mappedDomain)
+	err := c.Read(ctx, networkElementsEndpoint+"/"+networkElementID+"/mapped_domains/"+name, &mappedDomain)
 	if err != nil {
 		return nil, err
 	}
@@ -26,12 +24,12 @@ func (c *Client) GetMappedDomain(networkElementID string, name string) (*MappedD
 }
 
 // SetMappedDomain ...
-func (c *Client) SetMappedDomain(endpoint string, mappedDomain MappedDomain) (*MappedDomain, error) {
+func (c *Client) SetMappedDomain(ctx context.Context, endpoint string, mappedDomain MappedDomain) (*MappedDomain, error) {
 	jsonData, err := json.Marshal(mappedDomain)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.Request(endpoint, "PUT", jsonData, "application/json")
+	resp, err := c.Request(ctx, endpoint, "PUT", jsonData, "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -1,8 +1,8 @@
-package metanetworks
+package sdk
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 )
 
 // MappedHost ...
@@ -13,11 +13,9 @@ type MappedHost struct {
 }
 
 // GetMappedHost ...
-func (c *Client) GetMappedHost(networkElementID string, name string) (*MappedHost, error) {
+func (c *Client) GetMappedHost(ctx context.Context, networkElementID string, name string) (*MappedHost, error) {
 	var mappedHost MappedHost
-	url := networkElementsEndpoint + " / " + networkElementID + " / mapped_hosts / " + name
-	log.Printf("URL: %s", url)
-	err := c.Read(networkElementsEndpoint+"/"+networkElementID+"/mapped_hosts/"+name, &mappedHost)
+	err := c.Read(ctx, networkElementsEndpoint+"/"+networkElementID+"/mapped_hosts/"+name, &mappedHost)
 	if err != nil {
 		return nil, err
 	}
@@ -26,12 +24,12 @@ func (c *Client) GetMappedHost(networkElementID string, name string) (*MappedHos
 }
 
 // SetMappedHost ...
-func (c *Client) SetMappedHost(endpoint string, mappedHost MappedHost) (*MappedHost, error) {
+func (c *Client) SetMappedHost(ctx context.Context, endpoint string, mappedHost MappedHost) (*MappedHost, error) {
 	jsonData, err := json.Marshal(mappedHost)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.Request(endpoint, "PUT", jsonData, "application/json")
+	resp, err := c.Request(ctx, endpoint, "PUT", jsonData, "application/json")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,147 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	metaportsEndpoint string = "/v1/metaports"
+)
+
+// MetaPort ...
+type MetaPort struct {
+	Name           string      `json:"name"`
+	Description    string      `json:"description"`
+	Enabled        bool        `json:"enabled"`
+	AllowSupport   *bool       `json:"allow_support,omitempty"`
+	MappedElements []string    `json:"mapped_elements,omitempty"`
+	Connection     *Connection `json:"connection,omitempty"`
+	CreatedAt      string      `json:"created_at,omitempty"`
+	DNSName        string      `json:"dns_name,omitempty"`
+	ExpiresAt      string      `json:"expires_at,omitempty"`
+	ID             string      `json:"id,omitempty"`
+	ModifiedAt     string      `json:"modified_at,omitempty"`
+	OrgID          string      `json:"org_id,omitempty"`
+
+	// ETag is the version of the object returned by the API, empty if it
+	// returned none. See UpdateMetaPortRequest.IfMatch.
+	ETag string `json:"-"`
+}
+
+// CreateMetaPortRequest is the body of a request creating a MetaPort.
+type CreateMetaPortRequest struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	Enabled        bool     `json:"enabled"`
+	MappedElements []string `json:"mapped_elements,omitempty"`
+	// AllowSupport can't be set on creation, CreateMetaPort patches it
+	// right after.
+	AllowSupport *bool `json:"-"`
+}
+
+// UpdateMetaPortRequest is a merge patch of a MetaPort, its nil fields are left
+// unchanged.
+type UpdateMetaPortRequest struct {
+	Name           *string          `json:"name,omitempty"`
+	Description    *string          `json:"description,omitempty"`
+	Enabled        *bool            `json:"enabled,omitempty"`
+	AllowSupport   *bool            `json:"allow_support,omitempty"`
+	MappedElements *NullableStrings `json:"mapped_elements,omitempty"`
+
+	// IfMatch is the ETag of the object the update was computed from. When
+	// set, the update fails with a precondition error if the object was
+	// changed since, see IsPreconditionFailed.
+	IfMatch string `json:"-"`
+}
+
+// OTAC ...
+type OTAC struct {
+	ExpiresIn int64  `json:"expires_in"`
+	Secret    string `json:"secret"`
+}
+
+// Connection ...
+type Connection struct {
+	Connected      bool   `json:"connected"`
+	ConnectedAt    string `json:"connected_at"`
+	DisconnectedAt string `json:"disconnected_at"`
+	Location       string `json:"location"`
+	VPNProto       string `json:"vpn_proto"`
+}
+
+// ListMetaPorts returns all the Metaports.
+func (c *Client) ListMetaPorts(ctx context.Context) ([]MetaPort, error) {
+	var metaports []MetaPort
+	err := c.List(ctx, metaportsEndpoint, &metaports)
+	if err != nil {
+		return nil, err
+	}
+
+	return metaports, nil
+}
+
+// GetMetaPort ...
+func (c *Client) GetMetaPort(ctx context.Context, metaportID string) (*MetaPort, error) {
+	var metaport MetaPort
+	etag, err := c.readVersion(ctx, metaportsEndpoint+"/"+metaportID+"?connection=true", &metaport)
+	if err != nil {
+		return nil, err
+	}
+
+	metaport.ETag = etag
+	return &metaport, nil
+}
+
+// UpdateMetaPort ...
+func (c *Client) UpdateMetaPort(ctx context.Context, metaportID string, request *UpdateMetaPortRequest) (*MetaPort, error) {
+	var updatedMetaport MetaPort
+	etag, err := c.patchIfMatch(ctx, metaportsEndpoint+"/"+metaportID, request, &updatedMetaport, request.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedMetaport.ETag = etag
+	return &updatedMetaport, nil
+}
+
+// CreateMetaPort ...
+func (c *Client) CreateMetaPort(ctx context.Context, request *CreateMetaPortRequest) (*MetaPort, error) {
+	var createdMetaport MetaPort
+	err := c.Create(ctx, metaportsEndpoint, request, &createdMetaport)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.AllowSupport != nil {
+		return c.UpdateMetaPort(ctx, createdMetaport.ID, &UpdateMetaPortRequest{AllowSupport: request.AllowSupport})
+	}
+
+	return &createdMetaport, nil
+}
+
+// DeleteMetaPort ...
+func (c *Client) DeleteMetaPort(ctx context.Context, metaportID string) error {
+	err := c.Delete(ctx, metaportsEndpoint+"/"+metaportID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GenerateMetaPortOTAC ...
+func (c *Client) GenerateMetaPortOTAC(ctx context.Context, metaportID string) (string, error) {
+	resp, err := c.Request(ctx, metaportsEndpoint+"/"+metaportID+"/otac", "POST", nil, "")
+	if err != nil {
+		return "", err
+	}
+
+	var createdOTAC OTAC
+	err = json.Unmarshal(resp, &createdOTAC)
+	if err != nil {
+		return "", err
+	}
+
+	return createdOTAC.Secret, nil
+}
@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+)
+
+const (
+	metaportClustersEndpoint string = "/v1/metaport_clusters"
+)
+
+// Metaport Cluster ...
+type MetaportCluster struct {
+	CreatedAt      string   `json:"created_at,omitempty"`
+	Description    string   `json:"description"`
+	ID             string   `json:"id,omitempty"`
+	MappedElements []string `json:"mapped_elements,omitempty"`
+	Metaports      []string `json:"metaports,omitempty"`
+	ModifiedAt     string   `json:"modified_at,omitempty"`
+	Name           string   `json:"name"`
+
+	// ETag is the version of the object returned by the API, empty if it
+	// returned none. See UpdateMetaportClusterRequest.IfMatch.
+	ETag string `json:"-"`
+}
+
+// CreateMetaportClusterRequest is the body of a request creating a
+// MetaportCluster.
+type CreateMetaportClusterRequest struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	MappedElements []string `json:"mapped_elements,omitempty"`
+	Metaports      []string `json:"metaports,omitempty"`
+}
+
+// UpdateMetaportClusterRequest is a merge patch of a MetaportCluster, its nil
+// fields are left unchanged.
+type UpdateMetaportClusterRequest struct {
+	Name           *string          `json:"name,omitempty"`
+	Description    *string          `json:"description,omitempty"`
+	MappedElements *NullableStrings `json:"mapped_elements,omitempty"`
+	Metaports      *NullableStrings `json:"metaports,omitempty"`
+
+	// IfMatch is the ETag of the object the update was computed from. When
+	// set, the update fails with a precondition error if the object was
+	// changed since, see IsPreconditionFailed.
+	IfMatch string `json:"-"`
+}
+
+// ListMetaPortClusters returns all the Metaport clusters.
+func (c *Client) ListMetaPortClusters(ctx context.Context) ([]MetaportCluster, error) {
+	var metaportClusters []MetaportCluster
+	err := c.List(ctx, metaportClustersEndpoint+"?expand=true", &metaportClusters)
+	if err != nil {
+		return nil, err
+	}
+
+	return metaportClusters, nil
+}
+
+func (c *Client) GetMetaPortCluster(ctx context.Context, metaportClusterID string) (*MetaportCluster, error) {
+	var metaportCluster MetaportCluster
+	etag, err := c.readVersion(ctx, metaportClustersEndpoint+"/"+metaportClusterID+"?expand=true", &metaportCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	metaportCluster.ETag = etag
+	return &metaportCluster, nil
+}
+
+func (c *Client) UpdateMetaPortCluster(ctx context.Context, metaportClusterID string, request *UpdateMetaportClusterRequest) (*MetaportCluster, error) {
+	var updatedMetaportCluster MetaportCluster
+	etag, err := c.patchIfMatch(ctx, metaportClustersEndpoint+"/"+metaportClusterID, request, &updatedMetaportCluster, request.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedMetaportCluster.ETag = etag
+	return &updatedMetaportCluster, nil
+}
+
+func (c *Client) CreateMetaPortCluster(ctx context.Context, request *CreateMetaportClusterRequest) (*MetaportCluster, error) {
+	var createdMetaportCluster MetaportCluster
+	err := c.Create(ctx, metaportClustersEndpoint, request, &createdMetaportCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdMetaportCluster, nil
+}
+
+func (c *Client) DeleteMetaPortCluster(ctx context.Context, metaportClusterID string) error {
+	err := c.Delete(ctx, metaportClustersEndpoint+"/"+metaportClusterID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	networkElementsEndpoint string = "/v1/network_elements"
+)
+
+// NetworkElement ...
+type NetworkElement struct {
+	Aliases       []string       `json:"aliases,omitempty"`
+	CreatedAt     string         `json:"created_at,omitempty"`
+	Description   string         `json:"description"`
+	DNSName       string         `json:"dns_name,omitempty"`
+	Enabled       *bool          `json:"enabled,omitempty"`
+	ExpiresAt     string         `json:"expires_at,omitempty"`
+	ID            string         `json:"id,omitempty"`
+	MappedService string         `json:"mapped_service,omitempty"`
+	MappedSubnets []string       `json:"mapped_subnets,omitempty"`
+	ModifiedAt    string         `json:"modified_at,omitempty"`
+	Name          string         `json:"name"`
+	NetID         int64          `json:"net_id,omitempty"`
+	OrgID         string         `json:"org_id,omitempty"`
+	OwnerID       string         `json:"owner_id,omitempty"`
+	Platform      string         `json:"platform,omitempty"`
+	Type          string         `json:"type,omitempty"`
+	Tags          Tags           `json:"tags,omitempty"`
+	MappedDomains []MappedDomain `json:"mapped_domains,omitempty"`
+	MappedHosts   []MappedHost   `json:"mapped_hosts,omitempty"`
+}
+
+// CreateNetworkElementRequest is the body of a request creating a
+// NetworkElement.
+type CreateNetworkElementRequest struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	Enabled       *bool    `json:"enabled,omitempty"`
+	MappedService string   `json:"mapped_service,omitempty"`
+	MappedSubnets []string `json:"mapped_subnets,omitempty"`
+	OwnerID       string   `json:"owner_id,omitempty"`
+	Platform      string   `json:"platform,omitempty"`
+}
+
+// UpdateNetworkElementRequest is a merge patch of a NetworkElement, its nil
+// fields are left unchanged.
+type UpdateNetworkElementRequest struct {
+	Name          *string          `json:"name,omitempty"`
+	Description   *string          `json:"description,omitempty"`
+	Enabled       *bool            `json:"enabled,omitempty"`
+	MappedService *string          `json:"mapped_service,omitempty"`
+	MappedSubnets *NullableStrings `json:"mapped_subnets,omitempty"`
+	OwnerID       *string          `json:"owner_id,omitempty"`
+	Platform      *string          `json:"platform,omitempty"`
+}
+
+// ListNetworkElements returns all the network elements, with their aliases, mapped domains and mapped hosts.
+func (c *Client) ListNetworkElements(ctx context.Context) ([]NetworkElement, error) {
+	var networkElements []NetworkElement
+	err := c.List(ctx, networkElementsEndpoint+"?expand=true", &networkElements)
+	if err != nil {
+		return nil, err
+	}
+
+	return networkElements, nil
+}
+
+// GetNetworkElement ...
+func (c *Client) GetNetworkElement(ctx context.Context, elementID string) (*NetworkElement, error) {
+	var networkElement NetworkElement
+	err := c.Read(ctx, networkElementsEndpoint+"/"+elementID+"?expand=true", &networkElement)
+	if err != nil {
+		return nil, err
+	}
+
+	return &networkElement, nil
+}
+
+// UpdateNetworkElement ...
+func (c *Client) UpdateNetworkElement(ctx context.Context, networkElementID string, request *UpdateNetworkElementRequest) (*NetworkElement, error) {
+	var updatedNetworkElement NetworkElement
+	err := c.Patch(ctx, networkElementsEndpoint+"/"+networkElementID, request, &updatedNetworkElement)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedNetworkElement, nil
+}
+
+// CreateNetworkElement ...
+func (c *Client) CreateNetworkElement(ctx context.Context, request *CreateNetworkElementRequest) (*NetworkElement, error) {
+	var createdNetworkElement NetworkElement
+	err := c.Create(ctx, networkElementsEndpoint, request, &createdNetworkElement)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdNetworkElement, nil
+}
+
+// DeleteNetworkElement ...
+func (c *Client) DeleteNetworkElement(ctx context.Context, networkElementID string) error {
+	err := c.Delete(ctx, networkElementsEndpoint+"/"+networkElementID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetNetworkElementAlias ...
+func (c *Client) SetNetworkElementAlias(ctx context.Context, networkElementID string, alias string) (*NetworkElement, error) {
+	resp, err := c.Request(ctx, networkElementsEndpoint+"/"+networkElementID+"/aliases/"+alias, "PUT", nil, "")
+	if err != nil {
+		return nil, err
+	}
+	var networkElement NetworkElement
+	err = json.Unmarshal(resp, &networkElement)
+	if err != nil {
+		return nil, err
+	}
+
+	return &networkElement, nil
+}
+
+// DeleteNetworkElementAlias ...
+func (c *Client) DeleteNetworkElementAlias(ctx context.Context, networkElementID string, alias string) (*NetworkElement, error) {
+	resp, err := c.Request(ctx, networkElementsEndpoint+"/"+networkElementID+"/aliases/"+alias, "DELETE", nil, "")
+	if err != nil {
+		return nil, err
+	}
+	var networkElement NetworkElement
+	err = json.Unmarshal(resp, &networkElement)
+	if err != nil {
+		return nil, err
+	}
+	return &networkElement, nil
+}
+
+// SetNetworkElementMappedDomains ...
+func (c *Client) SetNetworkElementMappedDomains(ctx context.Context, networkElementID string, name string, mappedDomain *MappedDomain) (*MappedDomain, error) {
+	resp, err := c.SetMappedDomain(ctx, networkElementsEndpoint+"/"+networkElementID+"/mapped_domains/"+name, *mappedDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// DeleteNetworkElementMappedDomains ...
+func (c *Client) DeleteNetworkElementMappedDomains(ctx context.Context, networkElementID string, name string) error {
+	err := c.Delete(ctx, networkElementsEndpoint+"/"+networkElementID+"/mapped_domains/"+name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) SetNetworkElementMappedHosts(ctx context.Context, networkElementID string, name string, mappedHost *MappedHost) (*MappedHost, error) {
+	resp, err := c.SetMappedHost(ctx, networkElementsEndpoint+"/"+networkElementID+"/mapped_hosts/"+name, *mappedHost)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// DeleteNetworkElementMappedDomains ...
+func (c *Client) DeleteNetworkElementMappedHosts(ctx context.Context, networkElementID string, name string) error {
+	err := c.Delete(ctx, networkElementsEndpoint+"/"+networkElementID+"/mapped_hosts/"+name)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetNetworkElementTags returns the tags of a network element.
+func (c *Client) GetNetworkElementTags(ctx context.Context, networkElementID string) (map[string]string, error) {
+	return c.GetTags(ctx, networkElementsEndpoint+"/"+networkElementID+"/tags")
+}
+
+// SetNetworkElementTags replaces the tags of a network element.
+func (c *Client) SetNetworkElementTags(ctx context.Context, networkElementID string, tags map[string]string) error {
+	return c.UpdateTags(ctx, networkElementsEndpoint+"/"+networkElementID+"/tags", tags)
+}
@@ -0,0 +1,84 @@
+package sdk
+
+import "encoding/json"
+
+// The patch requests only change the fields they set. Their fields that can't
+// be cleared are pointers, left out of the request when nil. The fields that
+// can be cleared are pointers to the Nullable types, which are sent as null
+// when Null is set, and as their Value otherwise.
+
+// NullableString is a string field of a patch request that can be cleared.
+type NullableString struct {
+	Value string
+	Null  bool
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableString) MarshalJSON() ([]byte, error) {
+	if n.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// NullableInt is an int field of a patch request that can be cleared.
+type NullableInt struct {
+	Value int
+	Null  bool
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableInt) MarshalJSON() ([]byte, error) {
+	if n.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// NullableStrings is a list field of a patch request that can be cleared. A
+// nil Value is sent as an empty list unless Null is set.
+type NullableStrings struct {
+	Value []string
+	Null  bool
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullableStrings) MarshalJSON() ([]byte, error) {
+	if n.Null {
+		return []byte("null"), nil
+	}
+	if n.Value == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// String returns a pointer to v.
+func String(v string) *string {
+	return &v
+}
+
+// Bool returns a pointer to v.
+func Bool(v bool) *bool {
+	return &v
+}
+
+// Int returns a pointer to v.
+func Int(v int) *int {
+	return &v
+}
+
+// StringOrNull returns a field set to v, or cleared if v is empty.
+func StringOrNull(v string) *NullableString {
+	return &NullableString{Value: v, Null: v == ""}
+}
+
+// IntOrNull returns a field set to v, or cleared if v is zero.
+func IntOrNull(v int) *NullableInt {
+	return &NullableInt{Value: v, Null: v == 0}
+}
+
+// StringsOrNull returns a field set to v, or cleared if v is empty.
+func StringsOrNull(v []string) *NullableStrings {
+	return &NullableStrings{Value: v, Null: len(v) == 0}
+}
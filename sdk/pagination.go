@@ -0,0 +1,114 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DefaultMaxListItems is the default maximum number of items read from a
+	// list, see Client.MaxListItems.
+	DefaultMaxListItems int = 50000
+)
+
+// pageParams are the query parameters selecting a page of a list.
+var pageParams = []string{"cursor"}
+
+// listPage is a page of a list with the items wrapped in an object. When
+// NextCursor is set, the next page is read with it.
+type listPage struct {
+	Items      []json.RawMessage `json:"items"`
+	NextCursor string            `json:"next_cursor"`
+}
+
+// readPages reads the list endpoint with fetch and returns its items. The
+// paging of the lists isn't documented, so the list is asked for without any
+// page parameter: a json array is the whole list, and only a page whose
+// next_cursor is set is followed, by asking for the list again with that
+// cursor. It fails when the list has more than maxItems items, unless maxItems
+// is zero, and when the API returns a cursor it was just sent, as following it
+// would never end.
+func readPages(endpoint string, maxItems int, fetch fetchFunc) ([]json.RawMessage, error) {
+	items := []json.RawMessage{}
+	pageEndpoint := endpoint
+	cursor := ""
+	for {
+		body, _, err := fetch(pageEndpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		pageItems, nextCursor, err := decodePage(body)
+		if err != nil {
+			return nil, fmt.Errorf("Could not decode the response of GET %s: %s", pageEndpoint, err)
+		}
+		items = append(items, pageItems...)
+		if maxItems > 0 && len(items) > maxItems {
+			return nil, fmt.Errorf("Listing %s returned more than %d items, the maximum read from a list", endpoint, maxItems)
+		}
+
+		if nextCursor == "" {
+			return items, nil
+		}
+		if nextCursor == cursor {
+			return nil, fmt.Errorf("Listing %s returned the cursor %q of the page it was asked for as the next one", endpoint, cursor)
+		}
+		cursor = nextCursor
+		pageEndpoint = withQuery(endpoint, "cursor="+url.QueryEscape(cursor))
+	}
+}
+
+// decodePage returns the items of a page of a list, either a json array or
+// a listPage, and the cursor of the next page if any.
+func decodePage(body []byte) ([]json.RawMessage, string, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(body, &items); err == nil {
+		return items, "", nil
+	}
+
+	var page listPage
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, "", err
+	}
+	return page.Items, page.NextCursor, nil
+}
+
+// withQuery appends the query parameters params to endpoint.
+func withQuery(endpoint, params string) string {
+	if strings.Contains(endpoint, "?") {
+		return endpoint + "&" + params
+	}
+	return endpoint + "?" + params
+}
+
+// withoutPageParams removes the page parameters from the query of endpoint,
+// keeping the order of the others.
+func withoutPageParams(endpoint string) string {
+	path, query := splitQuery(endpoint)
+	if query == "" {
+		return endpoint
+	}
+
+	var kept []string
+	for _, param := range strings.Split(query[1:], "&") {
+		name := strings.SplitN(param, "=", 2)[0]
+		if !containsParam(pageParams, name) {
+			kept = append(kept, param)
+		}
+	}
+	if len(kept) == 0 {
+		return path
+	}
+	return path + "?" + strings.Join(kept, "&")
+}
+
+func containsParam(params []string, name string) bool {
+	for _, param := range params {
+		if param == name {
+			return true
+		}
+	}
+	return false
+}
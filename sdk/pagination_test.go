@@ -0,0 +1,115 @@
+package sdk_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"terraform-provider-metanetworks/internal/fakeapi"
+	"terraform-provider-metanetworks/sdk"
+)
+
+// cursorlessTransport drops the cursor of the requests, like an API ignoring
+// it and returning the first page again.
+type cursorlessTransport struct{}
+
+func (cursorlessTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	query.Del("cursor")
+	req.URL.RawQuery = query.Encode()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newListClient(t *testing.T, transport http.RoundTripper) (*fakeapi.Server, *sdk.Client) {
+	t.Helper()
+
+	server := fakeapi.NewServer(nil)
+	t.Cleanup(server.Close)
+
+	client, err := sdk.NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, &sdk.ClientOptions{
+		BaseURL:   server.URL,
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+
+	return server, client
+}
+
+func TestListPages(t *testing.T) {
+	ctx := context.Background()
+	server, client := newListClient(t, nil)
+
+	// Groups are wrapped lists with a cursor
+	for i := 0; i < 250; i++ {
+		if _, err := client.CreateGroup(ctx, &sdk.CreateGroupRequest{Name: fmt.Sprintf("group-%d", i)}); err != nil {
+			t.Fatalf("CreateGroup: %s", err)
+		}
+	}
+	var groups []sdk.Group
+	n := countRequests(server, func() {
+		var err error
+		if groups, err = client.GetGroups(ctx, ""); err != nil {
+			t.Fatalf("GetGroups: %s", err)
+		}
+	})
+	if len(groups) != 250 || groups[249].Name != "group-249" {
+		t.Errorf("GetGroups returned %d groups, want 250", len(groups))
+	}
+	if n != 3 {
+		t.Errorf("GetGroups sent %d requests, want 3", n)
+	}
+	if groups, err := client.GetGroups(ctx, "group-42"); err != nil || len(groups) != 1 {
+		t.Errorf("GetGroups(group-42) returned %v, %v", groups, err)
+	}
+
+	// Protocol groups are plain lists returned whole
+	for i := 0; i < 95; i++ {
+		if _, err := client.CreateProtocolGroup(ctx, &sdk.CreateProtocolGroupRequest{Name: fmt.Sprintf("protocols-%d", i)}); err != nil {
+			t.Fatalf("CreateProtocolGroup: %s", err)
+		}
+	}
+	var protocolGroups []sdk.ProtocolGroup
+	n = countRequests(server, func() {
+		var err error
+		if protocolGroups, err = client.GetProtocolGroups(ctx); err != nil {
+			t.Fatalf("GetProtocolGroups: %s", err)
+		}
+	})
+	if len(protocolGroups) != 100 {
+		t.Errorf("GetProtocolGroups returned %d protocol groups, want 100", len(protocolGroups))
+	}
+	if n != 1 {
+		t.Errorf("GetProtocolGroups sent %d requests, want 1", n)
+	}
+
+	client.MaxListItems = 200
+	if _, err := client.GetGroups(ctx, ""); err == nil || !strings.Contains(err.Error(), "more than 200 items") {
+		t.Errorf("GetGroups above MaxListItems returned %v", err)
+	}
+}
+
+func TestListRepeatedCursor(t *testing.T) {
+	ctx := context.Background()
+	server, client := newListClient(t, cursorlessTransport{})
+
+	for i := 0; i < 150; i++ {
+		if _, err := client.CreateGroup(ctx, &sdk.CreateGroupRequest{Name: fmt.Sprintf("group-%d", i)}); err != nil {
+			t.Fatalf("CreateGroup: %s", err)
+		}
+	}
+
+	var err error
+	n := countRequests(server, func() {
+		_, err = client.GetGroups(ctx, "")
+	})
+	if err == nil || !strings.Contains(err.Error(), "returned the cursor") {
+		t.Errorf("GetGroups with the cursor ignored returned %v", err)
+	}
+	if n != 2 {
+		t.Errorf("GetGroups with the cursor ignored sent %d requests, want 2", n)
+	}
+}
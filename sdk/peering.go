@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"context"
+)
+
+const (
+	peeringsEndpoint string = "/v1/peerings"
+)
+
+// Peering ...
+type Peering struct {
+	Description string   `json:"description"`
+	EgressNAT   bool     `json:"egress_nat"`
+	Enabled     bool     `json:"enabled"`
+	Name        string   `json:"name"`
+	Peers       []string `json:"peers,omitempty"`
+	CreatedAt   string   `json:"created_at,omitempty"`
+	ID          string   `json:"id,omitempty"`
+	ModifiedAt  string   `json:"modified_at,omitempty"`
+	OrgID       string   `json:"org_id,omitempty"`
+
+	// ETag is the version of the object returned by the API, empty if it
+	// returned none. See UpdatePeeringRequest.IfMatch.
+	ETag string `json:"-"`
+}
+
+// CreatePeeringRequest is the body of a request creating a Peering.
+type CreatePeeringRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	EgressNAT   bool     `json:"egress_nat"`
+	Enabled     bool     `json:"enabled"`
+	Peers       []string `json:"peers,omitempty"`
+}
+
+// UpdatePeeringRequest is a merge patch of a Peering, its nil fields are left
+// unchanged.
+type UpdatePeeringRequest struct {
+	Name        *string          `json:"name,omitempty"`
+	Description *string          `json:"description,omitempty"`
+	EgressNAT   *bool            `json:"egress_nat,omitempty"`
+	Enabled     *bool            `json:"enabled,omitempty"`
+	Peers       *NullableStrings `json:"peers,omitempty"`
+
+	// IfMatch is the ETag of the object the update was computed from. When
+	// set, the update fails with a precondition error if the object was
+	// changed since, see IsPreconditionFailed.
+	IfMatch string `json:"-"`
+}
+
+// ListPeerings returns all the peerings.
+func (c *Client) ListPeerings(ctx context.Context) ([]Peering, error) {
+	var peerings []Peering
+	err := c.List(ctx, peeringsEndpoint, &peerings)
+	if err != nil {
+		return nil, err
+	}
+
+	return peerings, nil
+}
+
+// GetPeering ...
+func (c *Client) GetPeering(ctx context.Context, peeringID string) (*Peering, error) {
+	var peering Peering
+	etag, err := c.readVersion(ctx, peeringsEndpoint+"/"+peeringID, &peering)
+	if err != nil {
+		return nil, err
+	}
+
+	peering.ETag = etag
+	return &peering, nil
+}
+
+// UpdatePeering ...
+func (c *Client) UpdatePeering(ctx context.Context, peeringID string, request *UpdatePeeringRequest) (*Peering, error) {
+	var updatedPeering Peering
+	etag, err := c.patchIfMatch(ctx, peeringsEndpoint+"/"+peeringID, request, &updatedPeering, request.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedPeering.ETag = etag
+	return &updatedPeering, nil
+}
+
+// CreatePeering ...
+func (c *Client) CreatePeering(ctx context.Context, request *CreatePeeringRequest) (*Peering, error) {
+	var createdPeering Peering
+	err := c.Create(ctx, peeringsEndpoint, request, &createdPeering)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdPeering, nil
+}
+
+// DeletePeering ...
+func (c *Client) DeletePeering(ctx context.Context, peeringID string) error {
+	err := c.Delete(ctx, peeringsEndpoint+"/"+peeringID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
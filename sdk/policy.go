@@ -0,0 +1,101 @@
+package sdk
+
+import (
+	"context"
+)
+
+const (
+	policiesEndpoint string = "/v1/policies"
+)
+
+// Policy ...
+type Policy struct {
+	Description    string   `json:"description"`
+	Destinations   []string `json:"destinations,omitempty"`
+	Enabled        bool     `json:"enabled"`
+	Name           string   `json:"name"`
+	ProtocolGroups []string `json:"protocol_groups,omitempty"`
+	ExemptSources  []string `json:"exempt_sources,omitempty"`
+	Sources        []string `json:"sources,omitempty"`
+	CreatedAt      string   `json:"created_at,omitempty"`
+	ID             string   `json:"id,omitempty"`
+	ModifiedAt     string   `json:"modified_at,omitempty"`
+	OrgID          string   `json:"org_id,omitempty"`
+}
+
+// CreatePolicyRequest is the body of a request creating a Policy.
+type CreatePolicyRequest struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	Enabled        bool     `json:"enabled"`
+	Destinations   []string `json:"destinations,omitempty"`
+	ExemptSources  []string `json:"exempt_sources,omitempty"`
+	ProtocolGroups []string `json:"protocol_groups,omitempty"`
+	Sources        []string `json:"sources,omitempty"`
+}
+
+// UpdatePolicyRequest is a merge patch of a Policy, its nil fields are left
+// unchanged.
+type UpdatePolicyRequest struct {
+	Name           *string          `json:"name,omitempty"`
+	Description    *string          `json:"description,omitempty"`
+	Enabled        *bool            `json:"enabled,omitempty"`
+	Destinations   *NullableStrings `json:"destinations,omitempty"`
+	ExemptSources  *NullableStrings `json:"exempt_sources,omitempty"`
+	ProtocolGroups *NullableStrings `json:"protocol_groups,omitempty"`
+	Sources        *NullableStrings `json:"sources,omitempty"`
+}
+
+// ListPolicies returns all the policies.
+func (c *Client) ListPolicies(ctx context.Context) ([]Policy, error) {
+	var policies []Policy
+	err := c.List(ctx, policiesEndpoint, &policies)
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// GetPolicy ...
+func (c *Client) GetPolicy(ctx context.Context, policyID string) (*Policy, error) {
+	var policy Policy
+	err := c.Read(ctx, policiesEndpoint+"/"+policyID, &policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// UpdatePolicy ...
+func (c *Client) UpdatePolicy(ctx context.Context, policyID string, request *UpdatePolicyRequest) (*Policy, error) {
+	var updatedPolicy Policy
+	err := c.Patch(ctx, policiesEndpoint+"/"+policyID, request, &updatedPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedPolicy, nil
+}
+
+// CreatePolicy ...
+func (c *Client) CreatePolicy(ctx context.Context, request *CreatePolicyRequest) (*Policy, error) {
+	var createdPolicy Policy
+	err := c.Create(ctx, policiesEndpoint, request, &createdPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdPolicy, nil
+}
+
+// DeletePolicy ...
+func (c *Client) DeletePolicy(ctx context.Context, policyID string) error {
+	err := c.Delete(ctx, policiesEndpoint+"/"+policyID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
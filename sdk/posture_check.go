@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"context"
+)
+
+const (
+	postureCheckEndpoint string = "/v1/posture_checks"
+)
+
+// PostureCheckFactor is a templated scenario checked by a PostureCheck, like
+// a minimum OS version.
+type PostureCheckFactor struct {
+	Type       string `json:"type"`
+	MinVersion string `json:"min_version,omitempty"`
+}
+
+type PostureCheck struct {
+	Description       string               `json:"description,omitempty"`
+	Name              string               `json:"name"`
+	Action            string               `json:"action"`
+	OSQuery           string               `json:"osquery,omitempty"`
+	Platform          string               `json:"platform"`
+	UserMessageOnFail string               `json:"user_message_on_fail,omitempty"`
+	Enabled           bool                 `json:"enabled"`
+	ApplyToOrg        bool                 `json:"apply_to_org,omitempty"`
+	Interval          int                  `json:"interval,omitempty"`
+	Check             []PostureCheckFactor `json:"allowed_factors,omitempty"`
+	When              []string             `json:"when"`
+	ExemptEntities    []string             `json:"exempt_entities,omitempty"`
+	ApplyToEntities   []string             `json:"apply_to_entities,omitempty"`
+	CreatedAt         string               `json:"created_at,omitempty"`
+	ID                string               `json:"id,omitempty"`
+	ModifiedAt        string               `json:"modified_at,omitempty"`
+}
+
+// CreatePostureCheckRequest is the body of a request creating a PostureCheck.
+type CreatePostureCheckRequest struct {
+	Name              string               `json:"name"`
+	Description       string               `json:"description,omitempty"`
+	Action            string               `json:"action"`
+	OSQuery           string               `json:"osquery,omitempty"`
+	Platform          string               `json:"platform"`
+	UserMessageOnFail string               `json:"user_message_on_fail,omitempty"`
+	Enabled           bool                 `json:"enabled"`
+	ApplyToOrg        bool                 `json:"apply_to_org"`
+	Interval          int                  `json:"interval,omitempty"`
+	Check             []PostureCheckFactor `json:"allowed_factors,omitempty"`
+	When              []string             `json:"when"`
+	ExemptEntities    []string             `json:"exempt_entities,omitempty"`
+	ApplyToEntities   []string             `json:"apply_to_entities,omitempty"`
+}
+
+// UpdatePostureCheckRequest is a merge patch of a PostureCheck, its nil fields
+// are left unchanged.
+type UpdatePostureCheckRequest struct {
+	Name              *string               `json:"name,omitempty"`
+	Description       *NullableString       `json:"description,omitempty"`
+	Action            *string               `json:"action,omitempty"`
+	OSQuery           *NullableString       `json:"osquery,omitempty"`
+	Platform          *string               `json:"platform,omitempty"`
+	UserMessageOnFail *NullableString       `json:"user_message_on_fail,omitempty"`
+	Enabled           *bool                 `json:"enabled,omitempty"`
+	ApplyToOrg        *bool                 `json:"apply_to_org,omitempty"`
+	Interval          *NullableInt          `json:"interval,omitempty"`
+	Check             *[]PostureCheckFactor `json:"allowed_factors,omitempty"`
+	When              *NullableStrings      `json:"when,omitempty"`
+	ExemptEntities    *NullableStrings      `json:"exempt_entities,omitempty"`
+	ApplyToEntities   *NullableStrings      `json:"apply_to_entities,omitempty"`
+}
+
+// ListPostureChecks returns all the posture checks.
+func (c *Client) ListPostureChecks(ctx context.Context) ([]PostureCheck, error) {
+	var postureChecks []PostureCheck
+	err := c.List(ctx, postureCheckEndpoint, &postureChecks)
+	if err != nil {
+		return nil, err
+	}
+
+	return postureChecks, nil
+}
+
+func (c *Client) GetPostureCheck(ctx context.Context, postureCheckID string) (*PostureCheck, error) {
+	var postureCheck PostureCheck
+	err := c.Read(ctx, postureCheckEndpoint+"/"+postureCheckID, &postureCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	return &postureCheck, nil
+}
+
+func (c *Client) UpdatePostureCheck(ctx context.Context, postureCheckID string, request *UpdatePostureCheckRequest) (*PostureCheck, error) {
+	var updatedPostureCheck PostureCheck
+	err := c.Patch(ctx, postureCheckEndpoint+"/"+postureCheckID, request, &updatedPostureCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedPostureCheck, nil
+}
+
+func (c *Client) CreatePostureCheck(ctx context.Context, request *CreatePostureCheckRequest) (*PostureCheck, error) {
+	var createdPostureCheck PostureCheck
+	err := c.Create(ctx, postureCheckEndpoint, request, &createdPostureCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdPostureCheck, nil
+}
+
+func (c *Client) DeletePostureCheck(ctx context.Context, postureCheckID string) error {
+	err := c.Delete(ctx, postureCheckEndpoint+"/"+postureCheckID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
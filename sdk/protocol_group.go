@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	protocolGroupsEndpoint string = "/v1/protocol_groups"
+)
+
+// ProtocolGroup ...
+type ProtocolGroup struct {
+	Description string     `json:"description"`
+	Name        string     `json:"name"`
+	Protocols   []Protocol `json:"protocols,omitempty"`
+	CreatedAt   string     `json:"created_at,omitempty"`
+	ID          string     `json:"id,omitempty"`
+	ModifiedAt  string     `json:"modified_at,omitempty"`
+	OrgID       string     `json:"org_id,omitempty"`
+	ReadOnly    bool       `json:"read_only,omitempty"`
+}
+
+// CreateProtocolGroupRequest is the body of a request creating a ProtocolGroup.
+type CreateProtocolGroupRequest struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Protocols   []Protocol `json:"protocols,omitempty"`
+}
+
+// UpdateProtocolGroupRequest is a merge patch of a ProtocolGroup, its nil
+// fields are left unchanged.
+type UpdateProtocolGroupRequest struct {
+	Name        *string     `json:"name,omitempty"`
+	Description *string     `json:"description,omitempty"`
+	Protocols   *[]Protocol `json:"protocols,omitempty"`
+}
+
+// Protocol ...
+type Protocol struct {
+	FromPort int64  `json:"from_port"`
+	Port     int64  `json:"port"`
+	Protocol string `json:"proto"`
+	ToPort   int64  `json:"to_port"`
+}
+
+func (c *Client) GetProtocolGroups(ctx context.Context) ([]ProtocolGroup, error) {
+	var protocolGroups []ProtocolGroup
+	err := c.List(ctx, protocolGroupsEndpoint, &protocolGroups)
+	if err != nil {
+
+		return nil, err
+	}
+
+	if len(protocolGroups) == 0 {
+		return nil, fmt.Errorf("Protocol Groups Not found")
+	}
+
+	return protocolGroups, nil
+}
+
+// GetProtocolGroup ...
+func (c *Client) GetProtocolGroup(ctx context.Context, protocolGroupID string) (*ProtocolGroup, error) {
+	var protocolGroup ProtocolGroup
+	err := c.Read(ctx, protocolGroupsEndpoint+"/"+protocolGroupID, &protocolGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protocolGroup, nil
+}
+
+// UpdateProtocolGroup ...
+func (c *Client) UpdateProtocolGroup(ctx context.Context, protocolGroupID string, request *UpdateProtocolGroupRequest) (*ProtocolGroup, error) {
+	var updatedProtocolGroup ProtocolGroup
+	err := c.Patch(ctx, protocolGroupsEndpoint+"/"+protocolGroupID, request, &updatedProtocolGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedProtocolGroup, nil
+}
+
+// CreateProtocolGroup ...
+func (c *Client) CreateProtocolGroup(ctx context.Context, request *CreateProtocolGroupRequest) (*ProtocolGroup, error) {
+	var createdProtocolGroup ProtocolGroup
+	err := c.Create(ctx, protocolGroupsEndpoint, request, &createdProtocolGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdProtocolGroup, nil
+}
+
+// DeleteProtocolGroup ...
+func (c *Client) DeleteProtocolGroup(ctx context.Context, protocolGroupID string) error {
+	err := c.Delete(ctx, protocolGroupsEndpoint+"/"+protocolGroupID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package sdk
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket refilled with rate tokens per second, holding
+// at most burst tokens.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns how long to wait before it can be used.
+// The bucket goes negative so that waiting callers are served in order.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a token reserved but not used.
+func (l *rateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
+
+// limitedTransport holds every request to the API, including the OAuth ones,
+// until the rate limiter and the number of requests in flight allow it.
+type limitedTransport struct {
+	next    http.RoundTripper
+	logger  Logger
+	limiter *rateLimiter
+	slots   chan struct{}
+}
+
+// newLimitedTransport wraps next with a rate limit of rate requests per
+// second and at most maxConcurrent requests in flight. Zero disables a limit.
+// The waits on the limits are logged to logger.
+func newLimitedTransport(next http.RoundTripper, logger Logger, rate float64, burst, maxConcurrent int) http.RoundTripper {
+	if rate <= 0 && maxConcurrent <= 0 {
+		return next
+	}
+
+	transport := &limitedTransport{next: next, logger: logger}
+	if rate > 0 {
+		transport.limiter = newRateLimiter(rate, burst)
+	}
+	if maxConcurrent > 0 {
+		transport.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	return transport
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	ctx := req.Context()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if wait := t.limiter.reserve(); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				t.limiter.cancel()
+				t.release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	if waited := time.Since(start); waited >= time.Millisecond {
+		t.logger.Debug(ctx, "Waited on the API rate limit",
+			"method", req.Method,
+			"path", req.URL.Path,
+			"wait", waited.Round(time.Millisecond).String(),
+		)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.release()
+		return nil, err
+	}
+
+	// The request is in flight until its body is read
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: t.release}
+
+	return resp, nil
+}
+
+func (t *limitedTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
+
+// releasingBody frees the slot of its request once closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
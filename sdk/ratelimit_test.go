@@ -0,0 +1,186 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// heldTransport answers the requests once they are released, and tracks how
+// many are in flight.
+type heldTransport struct {
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	received    chan struct{}
+	release     chan struct{}
+}
+
+func newHeldTransport() *heldTransport {
+	return &heldTransport{
+		received: make(chan struct{}, 100),
+		release:  make(chan struct{}),
+	}
+}
+
+func (t *heldTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	t.inFlight++
+	if t.inFlight > t.maxInFlight {
+		t.maxInFlight = t.inFlight
+	}
+	t.mu.Unlock()
+
+	t.received <- struct{}{}
+	<-t.release
+
+	t.mu.Lock()
+	t.inFlight--
+	t.mu.Unlock()
+
+	return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+func sendRequest(ctx context.Context, transport http.RoundTripper) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.example.com/v1/metaports", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestRateLimiterReserve(t *testing.T) {
+	limiter := newRateLimiter(10, 2)
+
+	for i, want := range []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond} {
+		wait := limiter.reserve()
+		if wait < want-10*time.Millisecond || wait > want {
+			t.Errorf("reservation %d waits %s, want %s", i, wait, want)
+		}
+	}
+
+	// The tokens returned go to the next callers
+	limiter.cancel()
+	limiter.cancel()
+	if wait := limiter.reserve(); wait < 90*time.Millisecond || wait > 100*time.Millisecond {
+		t.Errorf("reservation after cancelling two waits %s, want 100ms", wait)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	next := newHeldTransport()
+	close(next.release)
+	transport := newLimitedTransport(next, nopLogger{}, 20, 1, 0)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := sendRequest(context.Background(), transport); err != nil {
+			t.Fatalf("request %d: %s", i, err)
+		}
+	}
+
+	// The first request uses the burst, the others wait 50ms each
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("5 requests at 20 per second took %s, want at least 200ms", elapsed)
+	}
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	next := newHeldTransport()
+	transport := newLimitedTransport(next, nopLogger{}, 0, 0, 3)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- sendRequest(context.Background(), transport)
+		}()
+	}
+
+	// Only 3 requests get through until one of them completes
+	for i := 0; i < 3; i++ {
+		<-next.received
+	}
+	select {
+	case <-next.received:
+		t.Errorf("a fourth request was sent while 3 were in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(next.release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("request: %s", err)
+		}
+	}
+	if next.maxInFlight != 3 {
+		t.Errorf("%d requests were in flight at once, want 3", next.maxInFlight)
+	}
+}
+
+func TestLimitsCancel(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		rate          float64
+		maxConcurrent int
+	}{
+		{"concurrency", 0, 1},
+		{"rate", 5, 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			next := newHeldTransport()
+			transport := newLimitedTransport(next, nopLogger{}, test.rate, 1, test.maxConcurrent)
+
+			// The first request takes the slot or the token of the burst
+			first := make(chan error, 1)
+			go func() {
+				first <- sendRequest(context.Background(), transport)
+			}()
+			<-next.received
+
+			ctx, cancel := context.WithCancel(context.Background())
+			waiting := make(chan error, 1)
+			go func() {
+				waiting <- sendRequest(ctx, transport)
+			}()
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+
+			select {
+			case err := <-waiting:
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("the cancelled request returned %v, want %s", err, context.Canceled)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("the cancelled request is still waiting")
+			}
+
+			close(next.release)
+			if err := <-first; err != nil {
+				t.Errorf("first request: %s", err)
+			}
+
+			// The cancelled request gave its slot or token back
+			start := time.Now()
+			if err := sendRequest(context.Background(), transport); err != nil {
+				t.Errorf("request after the cancel: %s", err)
+			}
+			if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+				t.Errorf("the request after the cancel waited %s", elapsed)
+			}
+		})
+	}
+}
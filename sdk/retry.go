@@ -0,0 +1,138 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxRetries   int           = 4
+	DefaultMaxRetryWait int           = 30
+	retryWaitMin        time.Duration = 1 * time.Second
+)
+
+// isIdempotentMethod reports whether a request with the given method can be
+// safely sent more than once. PATCH is included because every PATCH issued by
+// the provider is a merge-patch with absolute values.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRetryableStatus reports whether a response with the given status code
+// should be retried.
+func isRetryableStatus(method string, statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		// The request was rejected before being processed, so it is safe to
+		// send it again whatever the method.
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotentMethod(method)
+	}
+	return false
+}
+
+// isRetryableError reports whether a transport error should be retried.
+// Idempotent requests are retried on any transport error, such as a connection
+// reset. Other requests are only retried when the connection could not be
+// established, as then the request provably never reached the server.
+func isRetryableError(method string, err error) bool {
+	if isIdempotentMethod(method) {
+		return true
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	return false
+}
+
+// retryWait returns how long to wait before the next attempt. A Retry-After
+// longer than MaxRetryWait is not shortened, as the API would most likely
+// reject an earlier attempt again: an error saying so is returned instead, for
+// the request to fail right away.
+func (c *Client) retryWait(attempt int, resp *http.Response) (time.Duration, error) {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > c.MaxRetryWait {
+				return 0, fmt.Errorf("Not retried, the API asked to wait %s, longer than the maximum retry wait of %s", wait.Round(time.Second), c.MaxRetryWait)
+			}
+			return wait, nil
+		}
+	}
+
+	// Exponential backoff with full jitter.
+	backoff := float64(retryWaitMin) * math.Pow(2, float64(attempt))
+	if backoff > float64(c.MaxRetryWait) {
+		backoff = float64(c.MaxRetryWait)
+	}
+	wait := time.Duration(rand.Int63n(int64(backoff) + 1))
+
+	// Don't hammer the API, unless MaxRetryWait is below the minimum
+	minWait := retryWaitMin
+	if c.MaxRetryWait < minWait {
+		minWait = c.MaxRetryWait
+	}
+	if wait < minWait {
+		wait = minWait
+	}
+
+	return wait, nil
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for d, returning early with the error of ctx if it is
+// cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,172 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-metanetworks/internal/fakeapi"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"empty", "", 0, false},
+		{"seconds", "5", 5 * time.Second, true},
+		{"zero seconds", "0", 0, true},
+		{"negative seconds", "-1", 0, false},
+		{"fractional seconds", "1.5", 0, false},
+		{"garbage", "soon", 0, false},
+		{"future date", time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), 10 * time.Second, true},
+		{"past date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(test.header)
+			if ok != test.ok {
+				t.Fatalf("parseRetryAfter(%q) ok = %t, want %t", test.header, ok, test.ok)
+			}
+			// HTTP dates have a resolution of one second
+			if got < test.want-time.Second || got > test.want {
+				t.Errorf("parseRetryAfter(%q) = %s, want %s", test.header, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		maxRetryWait time.Duration
+		attempt      int
+		retryAfter   string
+		min, max     time.Duration
+		err          string
+	}{
+		{"first attempt", 30 * time.Second, 0, "", retryWaitMin, retryWaitMin, ""},
+		{"backoff", 30 * time.Second, 3, "", retryWaitMin, 8 * time.Second, ""},
+		{"backoff capped", 30 * time.Second, 10, "", retryWaitMin, 30 * time.Second, ""},
+		{"below the minimum", 100 * time.Millisecond, 3, "", 100 * time.Millisecond, 100 * time.Millisecond, ""},
+		{"no wait", 0, 3, "", 0, 0, ""},
+		{"retry after", 30 * time.Second, 0, "2", 2 * time.Second, 2 * time.Second, ""},
+		{"retry after too long", 30 * time.Second, 0, "60", 0, 0, "asked to wait 1m0s, longer than the maximum retry wait of 30s"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			client := &Client{MaxRetryWait: test.maxRetryWait}
+			var resp *http.Response
+			if test.retryAfter != "" {
+				resp = &http.Response{Header: http.Header{"Retry-After": []string{test.retryAfter}}}
+			}
+
+			for i := 0; i < 20; i++ {
+				wait, err := client.retryWait(test.attempt, resp)
+				if test.err != "" {
+					if err == nil || !strings.Contains(err.Error(), test.err) {
+						t.Fatalf("retryWait returned %v, want an error containing %q", err, test.err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("retryWait: %s", err)
+				}
+				if wait < test.min || wait > test.max {
+					t.Fatalf("retryWait = %s, want between %s and %s", wait, test.min, test.max)
+				}
+			}
+		})
+	}
+}
+
+// retryAfterTransport sets a Retry-After header on the 429 responses.
+type retryAfterTransport struct {
+	retryAfter string
+}
+
+func (t *retryAfterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests && t.retryAfter != "" {
+		resp.Header.Set("Retry-After", t.retryAfter)
+	}
+	return resp, err
+}
+
+func TestRetries(t *testing.T) {
+	ctx := context.Background()
+	server := fakeapi.NewServer(nil)
+	defer server.Close()
+
+	transport := &retryAfterTransport{}
+	client, err := NewClient(fakeapi.DefaultAPIKey, fakeapi.DefaultAPISecret, fakeapi.DefaultOrg, &ClientOptions{
+		BaseURL:   server.URL,
+		Transport: transport,
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	client.MaxRetries = 2
+	client.MaxRetryWait = 10 * time.Millisecond
+
+	metaport, err := client.CreateMetaPort(ctx, &CreateMetaPortRequest{Name: "metaport"})
+	if err != nil {
+		t.Fatalf("CreateMetaPort: %s", err)
+	}
+	path := "/v1/metaports/" + metaport.ID
+
+	for _, test := range []struct {
+		name       string
+		method     string
+		path       string
+		statuses   []int
+		retryAfter string
+		requests   int
+		status     int
+		err        string
+	}{
+		{"retried", "GET", path, []int{503, 502}, "", 3, 0, ""},
+		{"retries exhausted", "GET", path, []int{500, 503, 504}, "", 3, 504, ""},
+		{"not retryable", "GET", path, []int{400}, "", 1, 400, ""},
+		{"post not retried", "POST", "/v1/metaports", []int{503}, "", 1, 503, ""},
+		{"post rate limited", "POST", "/v1/metaports", []int{429}, "0", 2, 0, ""},
+		{"retry after too long", "PATCH", path, []int{429}, "60", 1, 429, "Not retried, the API asked to wait 1m0s"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			transport.retryAfter = test.retryAfter
+			for _, status := range test.statuses {
+				server.API.FailNext(test.method, test.path, status, "fault")
+			}
+
+			before := server.API.Requests()
+			switch test.method {
+			case "GET":
+				_, err = client.GetMetaPort(ctx, metaport.ID)
+			case "POST":
+				_, err = client.CreateMetaPort(ctx, &CreateMetaPortRequest{Name: test.name})
+			case "PATCH":
+				_, err = client.UpdateMetaPort(ctx, metaport.ID, &UpdateMetaPortRequest{Name: String(test.name)})
+			}
+			if requests := server.API.Requests() - before; requests != test.requests {
+				t.Errorf("%d requests sent, want %d", requests, test.requests)
+			}
+
+			if test.status == 0 {
+				if err != nil {
+					t.Errorf("request failed: %s", err)
+				}
+				return
+			}
+			var apiError *ApiError
+			if !errors.As(err, &apiError) || apiError.StatusCode != test.status {
+				t.Fatalf("request returned %v, want a %d error", err, test.status)
+			}
+			if test.err != "" && !strings.Contains(err.Error(), test.err) {
+				t.Errorf("request returned %q, want it to contain %q", err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package sdk
+
+import (
+	"context"
+)
+
+const (
+	routingGroupsEndpoint string = "/v1/routing_groups"
+)
+
+// RoutingGroup ...
+type RoutingGroup struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	MappedElements []string `json:"mapped_elements_ids,omitempty"`
+	ExemptSources  []string `json:"exempt_sources,omitempty"`
+	Sources        []string `json:"sources,omitempty"`
+	CreatedAt      string   `json:"created_at,omitempty"`
+	ID             string   `json:"id,omitempty"`
+	ModifiedAt     string   `json:"modified_at,omitempty"`
+	OrgID          string   `json:"org_id,omitempty"`
+	Priority       int      `json:"priority,omitempty"`
+
+	// ETag is the version of the object returned by the API, empty if it
+	// returned none. See UpdateRoutingGroupRequest.IfMatch.
+	ETag string `json:"-"`
+}
+
+// CreateRoutingGroupRequest is the body of a request creating a RoutingGroup.
+type CreateRoutingGroupRequest struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	MappedElements []string `json:"mapped_elements_ids,omitempty"`
+	ExemptSources  []string `json:"exempt_sources,omitempty"`
+	Sources        []string `json:"sources,omitempty"`
+	// Priority can't be set on creation, CreateRoutingGroup patches it
+	// right after.
+	Priority *int `json:"-"`
+}
+
+// UpdateRoutingGroupRequest is a merge patch of a RoutingGroup, its nil fields
+// are left unchanged.
+type UpdateRoutingGroupRequest struct {
+	Name           *string          `json:"name,omitempty"`
+	Description    *string          `json:"description,omitempty"`
+	MappedElements *NullableStrings `json:"mapped_elements_ids,omitempty"`
+	ExemptSources  *NullableStrings `json:"exempt_sources,omitempty"`
+	Sources        *NullableStrings `json:"sources,omitempty"`
+	Priority       *int             `json:"priority,omitempty"`
+
+	// IfMatch is the ETag of the object the update was computed from. When
+	// set, the update fails with a precondition error if the object was
+	// changed since, see IsPreconditionFailed.
+	IfMatch string `json:"-"`
+}
+
+// ListRoutingGroups returns all the routing groups.
+func (c *Client) ListRoutingGroups(ctx context.Context) ([]RoutingGroup, error) {
+	var routingGroups []RoutingGroup
+	err := c.List(ctx, routingGroupsEndpoint, &routingGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	return routingGroups, nil
+}
+
+// GetRoutingGroup ...
+func (c *Client) GetRoutingGroup(ctx context.Context, routingGroupID string) (*RoutingGroup, error) {
+	var routingGroup RoutingGroup
+	etag, err := c.readVersion(ctx, routingGroupsEndpoint+"/"+routingGroupID, &routingGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	routingGroup.ETag = etag
+	return &routingGroup, nil
+}
+
+// UpdateRoutingGroup ...
+func (c *Client) UpdateRoutingGroup(ctx context.Context, routingGroupID string, request *UpdateRoutingGroupRequest) (*RoutingGroup, error) {
+	var updatedRoutingGroup RoutingGroup
+	etag, err := c.patchIfMatch(ctx, routingGroupsEndpoint+"/"+routingGroupID, request, &updatedRoutingGroup, request.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedRoutingGroup.ETag = etag
+	return &updatedRoutingGroup, nil
+}
+
+// CreateRoutingGroup ...
+func (c *Client) CreateRoutingGroup(ctx context.Context, request *CreateRoutingGroupRequest) (*RoutingGroup, error) {
+	var createdRoutingGroup RoutingGroup
+	err := c.Create(ctx, routingGroupsEndpoint, request, &createdRoutingGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Priority != nil {
+		return c.UpdateRoutingGroup(ctx, createdRoutingGroup.ID, &UpdateRoutingGroupRequest{Priority: request.Priority})
+	}
+
+	return &createdRoutingGroup, nil
+}
+
+// DeleteRoutingGroup ...
+func (c *Client) DeleteRoutingGroup(ctx context.Context, routingGroupID string) error {
+	err := c.Delete(ctx, routingGroupsEndpoint+"/"+routingGroupID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
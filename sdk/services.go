@@ -0,0 +1,176 @@
+package sdk
+
+import "context"
+
+// API is the whole Meta Networks API, implemented by Client.
+type API interface {
+	EgressRoutesAPI
+	GroupsAPI
+	LocationsAPI
+	MetaPortsAPI
+	MetaPortClustersAPI
+	NetworkElementsAPI
+	PeeringsAPI
+	PoliciesAPI
+	PostureChecksAPI
+	ProtocolGroupsAPI
+	RoutingGroupsAPI
+	SwgContentCategoriesAPI
+	SwgThreatCategoriesAPI
+	SwgUrlFilteringRulesAPI
+	UsersAPI
+}
+
+var _ API = (*Client)(nil)
+
+// EgressRoutesAPI manages egress routes.
+type EgressRoutesAPI interface {
+	ListEgressRoutes(ctx context.Context) ([]EgressRoute, error)
+	GetEgressRoute(ctx context.Context, egressRouteID string) (*EgressRoute, error)
+	CreateEgressRoute(ctx context.Context, request *CreateEgressRouteRequest) (*EgressRoute, error)
+	UpdateEgressRoute(ctx context.Context, egressRouteID string, request *UpdateEgressRouteRequest) (*EgressRoute, error)
+	DeleteEgressRoute(ctx context.Context, egressRouteID string) error
+}
+
+// GroupsAPI manages groups, their users and roles.
+type GroupsAPI interface {
+	GetGroups(ctx context.Context, name string) ([]Group, error)
+	GetGroup(ctx context.Context, groupID string) (*Group, error)
+	CreateGroup(ctx context.Context, request *CreateGroupRequest) (*Group, error)
+	UpdateGroup(ctx context.Context, groupID string, request *UpdateGroupRequest) (*Group, error)
+	DeleteGroup(ctx context.Context, groupID string) error
+	AddGroupUsers(ctx context.Context, groupID string, users []string) (*Group, error)
+	RemoveGroupUsers(ctx context.Context, groupID string, users []string) (*Group, error)
+	SetGroupRoles(ctx context.Context, groupID string, roles []string) (*Group, error)
+}
+
+// LocationsAPI lists the Meta Networks locations.
+type LocationsAPI interface {
+	GetLocations(ctx context.Context) ([]Location, error)
+}
+
+// MetaPortsAPI manages Metaports.
+type MetaPortsAPI interface {
+	ListMetaPorts(ctx context.Context) ([]MetaPort, error)
+	GetMetaPort(ctx context.Context, metaportID string) (*MetaPort, error)
+	CreateMetaPort(ctx context.Context, request *CreateMetaPortRequest) (*MetaPort, error)
+	UpdateMetaPort(ctx context.Context, metaportID string, request *UpdateMetaPortRequest) (*MetaPort, error)
+	DeleteMetaPort(ctx context.Context, metaportID string) error
+	GenerateMetaPortOTAC(ctx context.Context, metaportID string) (string, error)
+}
+
+// MetaPortClustersAPI manages Metaport clusters.
+type MetaPortClustersAPI interface {
+	ListMetaPortClusters(ctx context.Context) ([]MetaportCluster, error)
+	GetMetaPortCluster(ctx context.Context, metaportClusterID string) (*MetaportCluster, error)
+	CreateMetaPortCluster(ctx context.Context, request *CreateMetaportClusterRequest) (*MetaportCluster, error)
+	UpdateMetaPortCluster(ctx context.Context, metaportClusterID string, request *UpdateMetaportClusterRequest) (*MetaportCluster, error)
+	DeleteMetaPortCluster(ctx context.Context, metaportClusterID string) error
+}
+
+// NetworkElementsAPI manages network elements, which are the devices, mapped
+// services, mapped subnets and native services, with their aliases, mapped
+// domains, mapped hosts and tags.
+type NetworkElementsAPI interface {
+	ListNetworkElements(ctx context.Context) ([]NetworkElement, error)
+	GetNetworkElement(ctx context.Context, networkElementID string) (*NetworkElement, error)
+	CreateNetworkElement(ctx context.Context, request *CreateNetworkElementRequest) (*NetworkElement, error)
+	UpdateNetworkElement(ctx context.Context, networkElementID string, request *UpdateNetworkElementRequest) (*NetworkElement, error)
+	DeleteNetworkElement(ctx context.Context, networkElementID string) error
+	SetNetworkElementAlias(ctx context.Context, networkElementID string, alias string) (*NetworkElement, error)
+	DeleteNetworkElementAlias(ctx context.Context, networkElementID string, alias string) (*NetworkElement, error)
+	GetMappedDomain(ctx context.Context, networkElementID string, name string) (*MappedDomain, error)
+	SetNetworkElementMappedDomains(ctx context.Context, networkElementID string, name string, mappedDomain *MappedDomain) (*MappedDomain, error)
+	DeleteNetworkElementMappedDomains(ctx context.Context, networkElementID string, name string) error
+	GetMappedHost(ctx context.Context, networkElementID string, name string) (*MappedHost, error)
+	SetNetworkElementMappedHosts(ctx context.Context, networkElementID string, name string, mappedHost *MappedHost) (*MappedHost, error)
+	DeleteNetworkElementMappedHosts(ctx context.Context, networkElementID string, name string) error
+	GetNetworkElementTags(ctx context.Context, networkElementID string) (map[string]string, error)
+	SetNetworkElementTags(ctx context.Context, networkElementID string, tags map[string]string) error
+}
+
+// PeeringsAPI manages peerings.
+type PeeringsAPI interface {
+	ListPeerings(ctx context.Context) ([]Peering, error)
+	GetPeering(ctx context.Context, peeringID string) (*Peering, error)
+	CreatePeering(ctx context.Context, request *CreatePeeringRequest) (*Peering, error)
+	UpdatePeering(ctx context.Context, peeringID string, request *UpdatePeeringRequest) (*Peering, error)
+	DeletePeering(ctx context.Context, peeringID string) error
+}
+
+// PoliciesAPI manages policies.
+type PoliciesAPI interface {
+	ListPolicies(ctx context.Context) ([]Policy, error)
+	GetPolicy(ctx context.Context, policyID string) (*Policy, error)
+	CreatePolicy(ctx context.Context, request *CreatePolicyRequest) (*Policy, error)
+	UpdatePolicy(ctx context.Context, policyID string, request *UpdatePolicyRequest) (*Policy, error)
+	DeletePolicy(ctx context.Context, policyID string) error
+}
+
+// PostureChecksAPI manages posture checks.
+type PostureChecksAPI interface {
+	ListPostureChecks(ctx context.Context) ([]PostureCheck, error)
+	GetPostureCheck(ctx context.Context, postureCheckID string) (*PostureCheck, error)
+	CreatePostureCheck(ctx context.Context, request *CreatePostureCheckRequest) (*PostureCheck, error)
+	UpdatePostureCheck(ctx context.Context, postureCheckID string, request *UpdatePostureCheckRequest) (*PostureCheck, error)
+	DeletePostureCheck(ctx context.Context, postureCheckID string) error
+}
+
+// ProtocolGroupsAPI manages protocol groups.
+type ProtocolGroupsAPI interface {
+	GetProtocolGroups(ctx context.Context) ([]ProtocolGroup, error)
+	GetProtocolGroup(ctx context.Context, protocolGroupID string) (*ProtocolGroup, error)
+	CreateProtocolGroup(ctx context.Context, request *CreateProtocolGroupRequest) (*ProtocolGroup, error)
+	UpdateProtocolGroup(ctx context.Context, protocolGroupID string, request *UpdateProtocolGroupRequest) (*ProtocolGroup, error)
+	DeleteProtocolGroup(ctx context.Context, protocolGroupID string) error
+}
+
+// RoutingGroupsAPI manages routing groups.
+type RoutingGroupsAPI interface {
+	ListRoutingGroups(ctx context.Context) ([]RoutingGroup, error)
+	GetRoutingGroup(ctx context.Context, routingGroupID string) (*RoutingGroup, error)
+	CreateRoutingGroup(ctx context.Context, request *CreateRoutingGroupRequest) (*RoutingGroup, error)
+	UpdateRoutingGroup(ctx context.Context, routingGroupID string, request *UpdateRoutingGroupRequest) (*RoutingGroup, error)
+	DeleteRoutingGroup(ctx context.Context, routingGroupID string) error
+}
+
+// SwgContentCategoriesAPI manages the content categories of the secure web
+// gateway.
+type SwgContentCategoriesAPI interface {
+	ListSwgContentCategories(ctx context.Context) ([]SwgContentCategories, error)
+	GetSwgContentCategories(ctx context.Context, swgContentCategoriesID string) (*SwgContentCategories, error)
+	CreateSwgContentCategories(ctx context.Context, request *CreateSwgContentCategoriesRequest) (*SwgContentCategories, error)
+	UpdateSwgContentCategories(ctx context.Context, swgContentCategoriesID string, request *UpdateSwgContentCategoriesRequest) (*SwgContentCategories, error)
+	DeleteSwgContentCategories(ctx context.Context, swgContentCategoriesID string) error
+}
+
+// SwgThreatCategoriesAPI manages the threat categories of the secure web
+// gateway.
+type SwgThreatCategoriesAPI interface {
+	ListSwgThreatCategories(ctx context.Context) ([]SwgThreatCategories, error)
+	GetSwgThreatCategories(ctx context.Context, swgThreatCategoriesID string) (*SwgThreatCategories, error)
+	CreateSwgThreatCategories(ctx context.Context, request *CreateSwgThreatCategoriesRequest) (*SwgThreatCategories, error)
+	UpdateSwgThreatCategories(ctx context.Context, swgThreatCategoriesID string, request *UpdateSwgThreatCategoriesRequest) (*SwgThreatCategories, error)
+	DeleteSwgThreatCategories(ctx context.Context, swgThreatCategoriesID string) error
+}
+
+// SwgUrlFilteringRulesAPI manages the URL filtering rules of the secure web
+// gateway.
+type SwgUrlFilteringRulesAPI interface {
+	ListSwgUrlFilteringRules(ctx context.Context) ([]SwgUrlFilteringRules, error)
+	GetSwgUrlFilteringRules(ctx context.Context, swgUrlFilteringRulesID string) (*SwgUrlFilteringRules, error)
+	CreateSwgUrlFilteringRules(ctx context.Context, request *CreateSwgUrlFilteringRulesRequest) (*SwgUrlFilteringRules, error)
+	UpdateSwgUrlFilteringRules(ctx context.Context, swgUrlFilteringRulesID string, request *UpdateSwgUrlFilteringRulesRequest) (*SwgUrlFilteringRules, error)
+	DeleteSwgUrlFilteringRules(ctx context.Context, swgUrlFilteringRulesID string) error
+}
+
+// UsersAPI manages users and their tags.
+type UsersAPI interface {
+	GetUsers(ctx context.Context, email string) ([]User, error)
+	GetUser(ctx context.Context, userID string) (*User, error)
+	CreateUser(ctx context.Context, request *CreateUserRequest) (*User, error)
+	UpdateUser(ctx context.Context, userID string, request *UpdateUserRequest) (*User, error)
+	DeleteUser(ctx context.Context, userID string) error
+	GetUserTags(ctx context.Context, userID string) (map[string]string, error)
+	SetUserTags(ctx context.Context, userID string, tags map[string]string) error
+}
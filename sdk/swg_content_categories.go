@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+)
+
+const (
+	swgContentCategoriesEndpoint string = "/v1/content_categories"
+)
+
+// SwgContentCategories ...
+type SwgContentCategories struct {
+	Description             string   `json:"description"`
+	ConfidenceLevel         string   `json:"confidence_level,omitempty"`
+	Name                    string   `json:"name"`
+	ForbidUncategorizedUrls bool     `json:"forbid_uncategorized_urls,omitempty"`
+	Types                   []string `json:"types,omitempty"`
+	Urls                    []string `json:"urls,omitempty"`
+	ID                      string   `json:"id,omitempty"`
+	OrgID                   string   `json:"org_id,omitempty"`
+	CreatedAt               string   `json:"created_at,omitempty"`
+	ModifiedAt              string   `json:"modified_at,omitempty"`
+}
+
+// CreateSwgContentCategoriesRequest is the body of a request creating a
+// SwgContentCategories.
+type CreateSwgContentCategoriesRequest struct {
+	Name                    string   `json:"name"`
+	Description             string   `json:"description"`
+	ConfidenceLevel         string   `json:"confidence_level,omitempty"`
+	ForbidUncategorizedUrls bool     `json:"forbid_uncategorized_urls"`
+	Types                   []string `json:"types,omitempty"`
+	Urls                    []string `json:"urls,omitempty"`
+}
+
+// UpdateSwgContentCategoriesRequest is a merge patch of a SwgContentCategories,
+// its nil fields are left unchanged.
+type UpdateSwgContentCategoriesRequest struct {
+	Name                    *string          `json:"name,omitempty"`
+	Description             *string          `json:"description,omitempty"`
+	ConfidenceLevel         *NullableString  `json:"confidence_level,omitempty"`
+	ForbidUncategorizedUrls *bool            `json:"forbid_uncategorized_urls,omitempty"`
+	Types                   *NullableStrings `json:"types,omitempty"`
+	Urls                    *NullableStrings `json:"urls,omitempty"`
+}
+
+// ListSwgContentCategories returns all the content categories of the secure web gateway.
+func (c *Client) ListSwgContentCategories(ctx context.Context) ([]SwgContentCategories, error) {
+	var swgContentCategories []SwgContentCategories
+	err := c.List(ctx, swgContentCategoriesEndpoint+"?expand=true", &swgContentCategories)
+	if err != nil {
+		return nil, err
+	}
+
+	return swgContentCategories, nil
+}
+
+// GetSwgContentCategories ...
+func (c *Client) GetSwgContentCategories(ctx context.Context, swgContentCategoriesID string) (*SwgContentCategories, error) {
+	var SwgContentCategories SwgContentCategories
+	err := c.Read(ctx, swgContentCategoriesEndpoint+"/"+swgContentCategoriesID+"?expand=true", &SwgContentCategories)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SwgContentCategories, nil
+}
+
+// UpdateSwgContentCategories ...
+func (c *Client) UpdateSwgContentCategories(ctx context.Context, swgContentCategoriesID string, request *UpdateSwgContentCategoriesRequest) (*SwgContentCategories, error) {
+	var updatedSwgContentCategories SwgContentCategories
+	err := c.Patch(ctx, swgContentCategoriesEndpoint+"/"+swgContentCategoriesID, request, &updatedSwgContentCategories)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedSwgContentCategories, nil
+}
+
+// CreateSwgContentCategories ...
+func (c *Client) CreateSwgContentCategories(ctx context.Context, request *CreateSwgContentCategoriesRequest) (*SwgContentCategories, error) {
+	var createdSwgContentCategories SwgContentCategories
+	err := c.Create(ctx, swgContentCategoriesEndpoint, request, &createdSwgContentCategories)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdSwgContentCategories, nil
+}
+
+// DeleteSwgContentCategories ...
+func (c *Client) DeleteSwgContentCategories(ctx context.Context, swgContentCategoriesID string) error {
+	err := c.Delete(ctx, swgContentCategoriesEndpoint+"/"+swgContentCategoriesID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+)
+
+const (
+	swgThreatCategoriessEndpoint string = "/v1/threat_categories"
+)
+
+// SwgThreatCategories ...
+type SwgThreatCategories struct {
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Countries       []string `json:"countries,omitempty"`
+	Types           []string `json:"types"`
+	ConfidenceLevel string   `json:"confidence_level,omitempty"`
+	RiskLevel       string   `json:"risk_level,omitempty"`
+	CreatedAt       string   `json:"created_at,omitempty"`
+	ID              string   `json:"id,omitempty"`
+	ModifiedAt      string   `json:"modified_at,omitempty"`
+	OrgID           string   `json:"org_id,omitempty"`
+}
+
+// CreateSwgThreatCategoriesRequest is the body of a request creating a
+// SwgThreatCategories.
+type CreateSwgThreatCategoriesRequest struct {
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Countries       []string `json:"countries,omitempty"`
+	Types           []string `json:"types"`
+	ConfidenceLevel string   `json:"confidence_level,omitempty"`
+	RiskLevel       string   `json:"risk_level,omitempty"`
+}
+
+// UpdateSwgThreatCategoriesRequest is a merge patch of a SwgThreatCategories,
+// its nil fields are left unchanged.
+type UpdateSwgThreatCategoriesRequest struct {
+	Name            *string          `json:"name,omitempty"`
+	Description     *string          `json:"description,omitempty"`
+	Countries       *NullableStrings `json:"countries,omitempty"`
+	Types           *NullableStrings `json:"types,omitempty"`
+	ConfidenceLevel *NullableString  `json:"confidence_level,omitempty"`
+	RiskLevel       *NullableString  `json:"risk_level,omitempty"`
+}
+
+// ListSwgThreatCategories returns all the threat categories of the secure web gateway.
+func (c *Client) ListSwgThreatCategories(ctx context.Context) ([]SwgThreatCategories, error) {
+	var swgThreatCategories []SwgThreatCategories
+	err := c.List(ctx, swgThreatCategoriessEndpoint+"?expand=true", &swgThreatCategories)
+	if err != nil {
+		return nil, err
+	}
+
+	return swgThreatCategories, nil
+}
+
+// GetSwgThreatCategories ...
+func (c *Client) GetSwgThreatCategories(ctx context.Context, swgThreatCategoriesID string) (*SwgThreatCategories, error) {
+	var swgThreatCategories SwgThreatCategories
+	err := c.Read(ctx, swgThreatCategoriessEndpoint+"/"+swgThreatCategoriesID+"?expand=true", &swgThreatCategories)
+	if err != nil {
+		return nil, err
+	}
+
+	return &swgThreatCategories, nil
+}
+
+// UpdateSwgThreatCategories ...
+func (c *Client) UpdateSwgThreatCategories(ctx context.Context, swgThreatCategoriesID string, request *UpdateSwgThreatCategoriesRequest) (*SwgThreatCategories, error) {
+	var updatedSwgThreatCategories SwgThreatCategories
+	err := c.Patch(ctx, swgThreatCategoriessEndpoint+"/"+swgThreatCategoriesID, request, &updatedSwgThreatCategories)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedSwgThreatCategories, nil
+}
+
+// CreateSwgThreatCategories ...
+func (c *Client) CreateSwgThreatCategories(ctx context.Context, request *CreateSwgThreatCategoriesRequest) (*SwgThreatCategories, error) {
+	var createdSwgThreatCategories SwgThreatCategories
+	err := c.Create(ctx, swgThreatCategoriessEndpoint, request, &createdSwgThreatCategories)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdSwgThreatCategories, nil
+}
+
+// DeleteSwgThreatCategories ...
+func (c *Client) DeleteSwgThreatCategories(ctx context.Context, swgThreatCategoriesID string) error {
+	err := c.Delete(ctx, swgThreatCategoriessEndpoint+"/"+swgThreatCategoriesID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
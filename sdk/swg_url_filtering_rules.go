@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"context"
+)
+
+const (
+	swgUrlFilteringRulessEndpoint string = "/v1/url_filtering_rules"
+)
+
+// SwgUrlFilteringRules ...
+type SwgUrlFilteringRules struct {
+	Name                       string   `json:"name"`
+	Description                string   `json:"description"`
+	Action                     string   `json:"action"`
+	AdvancedThreatProtection   bool     `json:"advanced_threat_protection,omitempty"`
+	Enabled                    bool     `json:"enabled"`
+	Priority                   int      `json:"priority"`
+	ThreatCategory             string   `json:"threat_category,omitempty"`
+	ExemptSources              []string `json:"exempt_sources,omitempty"`
+	Sources                    []string `json:"sources,omitempty"`
+	ForbiddenContentCategories []string `json:"forbidden_content_categories,omitempty"`
+	CreatedAt                  string   `json:"created_at,omitempty"`
+	ID                         string   `json:"id,omitempty"`
+	ModifiedAt                 string   `json:"modified_at,omitempty"`
+	OrgID                      string   `json:"org_id,omitempty"`
+}
+
+// CreateSwgUrlFilteringRulesRequest is the body of a request creating a
+// SwgUrlFilteringRules.
+type CreateSwgUrlFilteringRulesRequest struct {
+	Name                       string   `json:"name"`
+	Description                string   `json:"description"`
+	Action                     string   `json:"action"`
+	AdvancedThreatProtection   bool     `json:"advanced_threat_protection"`
+	Enabled                    bool     `json:"enabled"`
+	Priority                   int      `json:"priority"`
+	ThreatCategory             string   `json:"threat_category,omitempty"`
+	ExemptSources              []string `json:"exempt_sources,omitempty"`
+	Sources                    []string `json:"sources,omitempty"`
+	ForbiddenContentCategories []string `json:"forbidden_content_categories,omitempty"`
+}
+
+// UpdateSwgUrlFilteringRulesRequest is a merge patch of a SwgUrlFilteringRules,
+// its nil fields are left unchanged.
+type UpdateSwgUrlFilteringRulesRequest struct {
+	Name                       *string          `json:"name,omitempty"`
+	Description                *string          `json:"description,omitempty"`
+	Action                     *string          `json:"action,omitempty"`
+	AdvancedThreatProtection   *bool            `json:"advanced_threat_protection,omitempty"`
+	Enabled                    *bool            `json:"enabled,omitempty"`
+	Priority                   *int             `json:"priority,omitempty"`
+	ThreatCategory             *NullableString  `json:"threat_category,omitempty"`
+	ExemptSources              *NullableStrings `json:"exempt_sources,omitempty"`
+	Sources                    *NullableStrings `json:"sources,omitempty"`
+	ForbiddenContentCategories *NullableStrings `json:"forbidden_content_categories,omitempty"`
+}
+
+// ListSwgUrlFilteringRules returns all the URL filtering rules of the secure web gateway.
+func (c *Client) ListSwgUrlFilteringRules(ctx context.Context) ([]SwgUrlFilteringRules, error) {
+	var swgUrlFilteringRules []SwgUrlFilteringRules
+	err := c.List(ctx, swgUrlFilteringRulessEndpoint+"?expand=true", &swgUrlFilteringRules)
+	if err != nil {
+		return nil, err
+	}
+
+	return swgUrlFilteringRules, nil
+}
+
+// GetSwgUrlFilteringRules ...
+func (c *Client) GetSwgUrlFilteringRules(ctx context.Context, swgUrlFilteringRulesID string) (*SwgUrlFilteringRules, error) {
+	var swgUrlFilteringRules SwgUrlFilteringRules
+	err := c.Read(ctx, swgUrlFilteringRulessEndpoint+"/"+swgUrlFilteringRulesID+"?expand=true", &swgUrlFilteringRules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &swgUrlFilteringRules, nil
+}
+
+// UpdateSwgUrlFilteringRules ...
+func (c *Client) UpdateSwgUrlFilteringRules(ctx context.Context, swgUrlFilteringRulesID string, request *UpdateSwgUrlFilteringRulesRequest) (*SwgUrlFilteringRules, error) {
+	var updatedSwgUrlFilteringRules SwgUrlFilteringRules
+	err := c.Patch(ctx, swgUrlFilteringRulessEndpoint+"/"+swgUrlFilteringRulesID, request, &updatedSwgUrlFilteringRules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedSwgUrlFilteringRules, nil
+}
+
+// CreateSwgUrlFilteringRules ...
+func (c *Client) CreateSwgUrlFilteringRules(ctx context.Context, request *CreateSwgUrlFilteringRulesRequest) (*SwgUrlFilteringRules, error) {
+	var createdSwgUrlFilteringRules SwgUrlFilteringRules
+	err := c.Create(ctx, swgUrlFilteringRulessEndpoint, request, &createdSwgUrlFilteringRules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdSwgUrlFilteringRules, nil
+}
+
+// DeleteSwgUrlFilteringRules ...
+func (c *Client) DeleteSwgUrlFilteringRules(ctx context.Context, swgUrlFilteringRulesID string) error {
+	err := c.Delete(ctx, swgUrlFilteringRulessEndpoint+"/"+swgUrlFilteringRulesID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
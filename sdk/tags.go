@@ -0,0 +1,77 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Tag ...
+type Tag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Tags are the tags of an object, read from the object itself. The API returns
+// them as a list of Tag, a json object of names and values is accepted too. A
+// nil Tags means that the object was returned without its tags.
+type Tags map[string]string
+
+// UnmarshalJSON decodes a list of Tag or a json object.
+func (t *Tags) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = nil
+		return nil
+	}
+
+	var tags []Tag
+	if err := json.Unmarshal(data, &tags); err != nil {
+		var values map[string]string
+		if json.Unmarshal(data, &values) != nil {
+			return err
+		}
+		*t = values
+		return nil
+	}
+
+	*t = make(Tags, len(tags))
+	for _, tag := range tags {
+		(*t)[tag.Name] = tag.Value
+	}
+	return nil
+}
+
+// GetTags ...
+func (c *Client) GetTags(ctx context.Context, endpoint string) (map[string]string, error) {
+	var tags []Tag
+	err := c.Read(ctx, endpoint, &tags)
+	if err != nil {
+		return nil, err
+	}
+
+	tagMap := make(map[string]string)
+	for _, tag := range tags {
+		tagMap[tag.Name] = tag.Value
+	}
+
+	return tagMap, nil
+}
+
+// UpdateTags ...
+func (c *Client) UpdateTags(ctx context.Context, endpoint string, tags map[string]string) error {
+	tagsStruct := make([]Tag, 0, len(tags))
+	for key, value := range tags {
+		tagsStruct = append(tagsStruct, Tag{Name: key, Value: value})
+	}
+
+	jsonData, err := json.Marshal(&tagsStruct)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Request(ctx, endpoint, "PUT", jsonData, "application/json")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package sdk
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTagsUnmarshalJSON(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		json string
+		want Tags
+	}{
+		{"list", `{"tags": [{"name": "team", "value": "blue"}, {"name": "env", "value": "prod"}]}`, Tags{"team": "blue", "env": "prod"}},
+		{"empty list", `{"tags": []}`, Tags{}},
+		{"object", `{"tags": {"team": "blue"}}`, Tags{"team": "blue"}},
+		{"null", `{"tags": null}`, nil},
+		{"missing", `{}`, nil},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var networkElement NetworkElement
+			if err := json.Unmarshal([]byte(test.json), &networkElement); err != nil {
+				t.Fatalf("Unmarshal: %s", err)
+			}
+			if !reflect.DeepEqual(networkElement.Tags, test.want) {
+				t.Errorf("Tags = %#v, want %#v", networkElement.Tags, test.want)
+			}
+		})
+	}
+
+	var networkElement NetworkElement
+	if err := json.Unmarshal([]byte(`{"tags": "team"}`), &networkElement); err == nil {
+		t.Errorf("Unmarshal of invalid tags succeeded: %#v", networkElement.Tags)
+	}
+}
@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// tokenRefreshWindow is how long before expiry a token is renewed, so
+	// that it does not expire while a request is in flight.
+	tokenRefreshWindow time.Duration = 60 * time.Second
+)
+
+// tokenManager holds the OAuth token of a Client. It is safe for concurrent
+// use: the first caller to find the token about to expire renews it while the
+// others wait for the new one.
+type tokenManager struct {
+	mu sync.Mutex
+
+	oauthURL   string
+	httpClient *http.Client
+	logger     Logger
+
+	apiKey            string
+	apiSecret         string
+	org               string
+	credentialProcess string
+
+	token            *Token
+	renewAt          time.Time
+	refreshExpiresAt time.Time
+}
+
+// accessToken returns a valid access token, renewing it first if it expires
+// within the refresh window.
+func (m *tokenManager) accessToken(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token != nil && time.Now().Before(m.renewAt) {
+		return m.token.Token, nil
+	}
+
+	err := m.renew(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return m.token.Token, nil
+}
+
+// invalidate drops token if it is still the current one, so that the next
+// call to accessToken renews it. Callers that lost the race keep the token
+// renewed in the meantime.
+func (m *tokenManager) invalidate(token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.token != nil && m.token.Token == token {
+		m.renewAt = time.Time{}
+	}
+}
+
+// renew uses the refresh token while it is valid and falls back to a full
+// authentication when there is none or the API rejects it. mu must be held.
+func (m *tokenManager) renew(ctx context.Context) error {
+	if m.token != nil && m.token.RefreshToken != "" &&
+		(m.refreshExpiresAt.IsZero() || time.Until(m.refreshExpiresAt) > tokenRefreshWindow) {
+		credentialData := Credentials{
+			GrantType:    "refresh_token",
+			Scope:        "org:" + m.org,
+			RefreshToken: m.token.RefreshToken,
+		}
+
+		token, err := MakeAuthReqest(ctx, m.oauthURL, &credentialData, m.httpClient)
+		if err == nil {
+			m.setToken(token)
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		m.logger.Debug(ctx, "Could not refresh the OAuth token, authenticating again", "error", err.Error())
+	}
+
+	return m.authenticate(ctx)
+}
+
+// authenticate requests a new token with the client credentials, fetching
+// them again first when they come from a credential process. mu must be held
+// once the manager is shared.
+func (m *tokenManager) authenticate(ctx context.Context) error {
+	if m.credentialProcess != "" {
+		config, err := runCredentialProcess(ctx, m.logger, m.credentialProcess, m.org)
+		if err != nil {
+			return err
+		}
+		m.apiKey = config.APIKey
+		m.apiSecret = config.APISecret
+		m.org = config.Org
+	}
+
+	credentialData := Credentials{
+		GrantType:    "client_credentials",
+		Scope:        "org:" + m.org,
+		ClientID:     m.apiKey,
+		ClientSecret: m.apiSecret,
+	}
+
+	token, err := MakeAuthReqest(ctx, m.oauthURL, &credentialData, m.httpClient)
+	if err != nil {
+		return err
+	}
+	m.setToken(token)
+
+	return nil
+}
+
+func (m *tokenManager) setToken(token *Token) {
+	now := time.Now()
+
+	// Short lived tokens are renewed half way through their lifetime
+	lifetime := time.Duration(token.Expiry) * time.Second
+	window := tokenRefreshWindow
+	if lifetime/2 < window {
+		window = lifetime / 2
+	}
+
+	m.token = token
+	m.renewAt = now.Add(lifetime - window)
+	m.refreshExpiresAt = time.Time{}
+	if token.RefreshExpiry > 0 {
+		m.refreshExpiresAt = now.Add(time.Duration(token.RefreshExpiry) * time.Second)
+	}
+}
@@ -0,0 +1,222 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tokenServer grants tokens of the given lifetime to the credentials key and
+// secret and answers the API requests with an empty object when their token
+// is valid. It records the grant types of the OAuth requests, and slows them
+// down so that the requests waiting for a token pile up.
+type tokenServer struct {
+	*httptest.Server
+
+	lifetime int64
+
+	mu            sync.Mutex
+	issued        int
+	tokens        map[string]bool
+	refreshTokens map[string]bool
+	unauthorized  int
+	grants        []string
+}
+
+func newTokenServer(lifetime int64) *tokenServer {
+	s := &tokenServer{
+		lifetime:      lifetime,
+		tokens:        make(map[string]bool),
+		refreshTokens: make(map[string]bool),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *tokenServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == oauthPath {
+		s.serveToken(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !s.tokens[token] || s.unauthorized > 0 {
+		if s.unauthorized > 0 {
+			s.unauthorized--
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("unauthorized"))
+		return
+	}
+	w.Write([]byte("{}"))
+}
+
+func (s *tokenServer) serveToken(w http.ResponseWriter, r *http.Request) {
+	var credentials Credentials
+	json.NewDecoder(r.Body).Decode(&credentials)
+	time.Sleep(50 * time.Millisecond)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.grants = append(s.grants, credentials.GrantType)
+	switch {
+	case credentials.GrantType == "client_credentials" && credentials.ClientID == "key" && credentials.ClientSecret == "secret":
+	case credentials.GrantType == "refresh_token" && s.refreshTokens[credentials.RefreshToken]:
+		delete(s.refreshTokens, credentials.RefreshToken)
+	default:
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("{}"))
+		return
+	}
+
+	s.issued++
+	token := &Token{
+		Token:        fmt.Sprintf("token-%d", s.issued),
+		Expiry:       s.lifetime,
+		RefreshToken: fmt.Sprintf("refresh-%d", s.issued),
+	}
+	s.tokens[token.Token] = true
+	s.refreshTokens[token.RefreshToken] = true
+	json.NewEncoder(w).Encode(token)
+}
+
+// revoke revokes all the tokens and refresh tokens granted so far.
+func (s *tokenServer) revoke() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens = make(map[string]bool)
+	s.refreshTokens = make(map[string]bool)
+}
+
+// takeGrants returns the grant types recorded since the last call.
+func (s *tokenServer) takeGrants() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grants := s.grants
+	s.grants = nil
+	return grants
+}
+
+func TestTokenRenewal(t *testing.T) {
+	server := newTokenServer(1)
+	defer server.Close()
+
+	client, err := NewClient("key", "secret", "org", &ClientOptions{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	if grants, want := server.takeGrants(), []string{"client_credentials"}; !reflect.DeepEqual(grants, want) {
+		t.Fatalf("NewClient authenticated with %v, want %v", grants, want)
+	}
+
+	// getAll reads a Metaport from many goroutines at once
+	getAll := func(t *testing.T) {
+		t.Helper()
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 20)
+		for i := 0; i < cap(errs); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.Request(context.Background(), "/v1/metaports/mp-1", "GET", nil, "")
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Errorf("Request: %s", err)
+			}
+		}
+	}
+
+	t.Run("expired", func(t *testing.T) {
+		server.takeGrants()
+		time.Sleep(1100 * time.Millisecond)
+
+		getAll(t)
+		if grants, want := server.takeGrants(), []string{"refresh_token"}; !reflect.DeepEqual(grants, want) {
+			t.Errorf("the expired token was renewed with %v, want %v", grants, want)
+		}
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		server.takeGrants()
+		server.revoke()
+
+		// The refresh token is revoked as well, so the client falls back to
+		// its credentials
+		getAll(t)
+		if grants, want := server.takeGrants(), []string{"refresh_token", "client_credentials"}; !reflect.DeepEqual(grants, want) {
+			t.Errorf("the revoked token was renewed with %v, want %v", grants, want)
+		}
+	})
+
+	t.Run("unauthorized twice", func(t *testing.T) {
+		server.takeGrants()
+		server.mu.Lock()
+		server.unauthorized = 2
+		server.mu.Unlock()
+
+		// A second 401 with a new token is not a token problem, the request
+		// fails instead of authenticating again
+		_, err := client.Request(context.Background(), "/v1/metaports/mp-1", "GET", nil, "")
+		var apiError *ApiError
+		if !errors.As(err, &apiError) || apiError.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Request returned %v, want a 401 error", err)
+		}
+		if grants, want := server.takeGrants(), []string{"refresh_token"}; !reflect.DeepEqual(grants, want) {
+			t.Errorf("the rejected token was renewed with %v, want %v", grants, want)
+		}
+	})
+}
+
+func TestNewClientInvalidCredentials(t *testing.T) {
+	server := newTokenServer(3600)
+	defer server.Close()
+
+	_, err := NewClient("key", "wrong", "org", &ClientOptions{BaseURL: server.URL})
+	if err == nil || !strings.Contains(err.Error(), "Authentication failed with client_credentials grant, the API returned 401") {
+		t.Errorf("NewClient with wrong credentials returned %v", err)
+	}
+}
+
+func TestTokenRenewalCancel(t *testing.T) {
+	server := newTokenServer(3600)
+	defer server.Close()
+
+	client, err := NewClient("key", "secret", "org", &ClientOptions{BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	client.tokens.invalidate(client.tokens.token.Token)
+
+	// The OAuth requests take 50ms, the renewal gives up as soon as the
+	// request waiting for the token is cancelled
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = client.Request(ctx, "/v1/metaports/mp-1", "GET", nil, "")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Request returned %v, want %s", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("the cancelled renewal took %s", elapsed)
+	}
+}
@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// newHTTPClient builds the HTTP client used to talk to the API, applying the
+// proxy, TLS, timeout and rate limit settings of the options.
+func newHTTPClient(options *ClientOptions, logger Logger) (*http.Client, error) {
+	transport := options.Transport
+	if transport == nil {
+		var err error
+		transport, err = newTransport(options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	timeout := options.RequestTimeout
+	if timeout == 0 {
+		timeout = time.Duration(DefaultRequestTimeout) * time.Second
+	}
+
+	userAgent := options.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	transport = &userAgentTransport{next: transport, userAgent: userAgent}
+
+	return &http.Client{
+		Transport: newLimitedTransport(transport, logger, options.RateLimit, options.RateBurst, options.MaxConcurrentRequests),
+		Timeout:   timeout,
+	}, nil
+}
+
+// userAgentTransport sets the User-Agent header of every request to the API,
+// including the OAuth ones.
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
+
+// newRequestID returns a random UUID identifying a request in the logs of the
+// client and of the API.
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// newTransport returns the transport for the proxy and TLS settings of the
+// options.
+func newTransport(options *ClientOptions) (*http.Transport, error) {
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		MaxIdleConnsPerHost: maxIdleConnections,
+	}
+
+	if options.ProxyURL != "" {
+		proxyURL, err := url.Parse(options.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid proxy URL %q: %s", options.ProxyURL, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// newTLSConfig returns the TLS configuration for the custom CA bundle and
+// client certificate of the options, or nil if none is set.
+func newTLSConfig(options *ClientOptions) (*tls.Config, error) {
+	if options.CABundleFile == "" && options.ClientCertFile == "" && options.ClientKeyFile == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if options.CABundleFile != "" {
+		caBundle, err := ioutil.ReadFile(options.CABundleFile)
+		if err != nil {
+			return nil, fmt.Errorf("Could not read CA bundle %q: %s", options.CABundleFile, err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("No PEM certificates found in CA bundle %q", options.CABundleFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if options.ClientCertFile != "" || options.ClientKeyFile != "" {
+		if options.ClientCertFile == "" || options.ClientKeyFile == "" {
+			return nil, errors.New("Both a client certificate and a client key are required for mutual TLS")
+		}
+
+		certificate, err := tls.LoadX509KeyPair(options.ClientCertFile, options.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("Could not load client certificate %q: %s", options.ClientCertFile, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
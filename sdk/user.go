@@ -0,0 +1,131 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+const (
+	usersEndpoint string = "/v1/users"
+)
+
+// User ...
+type User struct {
+	Description       string              `json:"description"`
+	Email             string              `json:"email"`
+	Enabled           bool                `json:"enabled"`
+	FamilyName        string              `json:"family_name"`
+	GivenName         string              `json:"given_name"`
+	Phone             string              `json:"phone,omitempty"`
+	ProvisionedBy     string              `json:"provisioned_by,omitempty"`
+	CreatedAt         string              `json:"created_at,omitempty"`
+	ID                string              `json:"id,omitempty"`
+	Inventory         []string            `json:"inventory,omitempty"`
+	MFAEnabled        bool                `json:"mfa_enabled,omitempty"`
+	ModifiedAt        string              `json:"modified_at,omitempty"`
+	Name              string              `json:"name,omitempty"`
+	OrgID             string              `json:"org_id,omitempty"`
+	OverlayMFAEnabled bool                `json:"overlay_mfa_enabled,omitempty"`
+	PhoneVerified     bool                `json:"phone_verified,omitempty"`
+	Roles             []string            `json:"roles,omitempty"`
+	Tags              []map[string]string `json:"tags,omitempty"`
+}
+
+// CreateUserRequest is the body of a request creating a User.
+type CreateUserRequest struct {
+	Email       string `json:"email"`
+	GivenName   string `json:"given_name"`
+	FamilyName  string `json:"family_name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Phone       string `json:"phone,omitempty"`
+}
+
+// UpdateUserRequest is a merge patch of a User, its nil fields are left
+// unchanged.
+type UpdateUserRequest struct {
+	GivenName   *string         `json:"given_name,omitempty"`
+	FamilyName  *string         `json:"family_name,omitempty"`
+	Description *string         `json:"description,omitempty"`
+	Enabled     *bool           `json:"enabled,omitempty"`
+	Phone       *NullableString `json:"phone,omitempty"`
+}
+
+// GetUsers returns the users with the email address email, or all of them if
+// email is empty.
+func (c *Client) GetUsers(ctx context.Context, email string) ([]User, error) {
+	var users []User
+	err := c.List(ctx, usersEndpoint+"?expand=true&email="+url.QueryEscape(email), &users)
+	if err != nil {
+		return nil, err
+	}
+
+	if email != "" && len(users) == 0 {
+		return nil, fmt.Errorf("Not found: %s", email)
+	}
+
+	return users, nil
+}
+
+// GetUser ...
+func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
+	var user User
+	err := c.Read(ctx, usersEndpoint+"/"+userID, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// UpdateUser ...
+func (c *Client) UpdateUser(ctx context.Context, userID string, request *UpdateUserRequest) (*User, error) {
+	var updatedUser User
+	err := c.Patch(ctx, usersEndpoint+"/"+userID, request, &updatedUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedUser, nil
+}
+
+// CreateUser ...
+func (c *Client) CreateUser(ctx context.Context, request *CreateUserRequest) (*User, error) {
+	var createdUser User
+	err := c.Create(ctx, usersEndpoint, request, &createdUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &createdUser, nil
+}
+
+// DeleteUser ...
+func (c *Client) DeleteUser(ctx context.Context, userID string) error {
+	err := c.Delete(ctx, usersEndpoint+"/"+userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetUserTags ...
+func (c *Client) GetUserTags(ctx context.Context, userID string) (map[string]string, error) {
+	tagMap, err := c.GetTags(ctx, usersEndpoint+"/"+userID+"/tags")
+	if err != nil {
+		return nil, err
+	}
+
+	return tagMap, nil
+}
+
+// SetUserTags ...
+func (c *Client) SetUserTags(ctx context.Context, userID string, tags map[string]string) error {
+	err := c.UpdateTags(ctx, usersEndpoint+"/"+userID+"/tags", tags)
+	if err != nil {
+		return err
+	}
+	return nil
+}